@@ -9,7 +9,9 @@ import (
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
@@ -30,7 +32,12 @@ type Client interface {
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	ReloadConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	SetPublicIP(ctx context.Context, ip string, port uint16, options ...rpc.Option) error
 	DBGet(ctx context.Context, key []byte, options ...rpc.Option) ([]byte, error)
+	AddAccessListEntries(ctx context.Context, list string, entries []string, options ...rpc.Option) error
+	RemoveAccessListEntries(ctx context.Context, list string, entries []string, options ...rpc.Option) error
+	GetAccessList(ctx context.Context, list string, options ...rpc.Option) ([]string, error)
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -144,6 +151,19 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	return res, err
 }
 
+func (c *client) ReloadConfig(ctx context.Context, options ...rpc.Option) (interface{}, error) {
+	var res interface{}
+	err := c.requester.SendRequest(ctx, "admin.reloadConfig", struct{}{}, &res, options...)
+	return res, err
+}
+
+func (c *client) SetPublicIP(ctx context.Context, ip string, port uint16, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.setPublicIP", &SetPublicIPArgs{
+		IP:   ip,
+		Port: json.Uint16(port),
+	}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) DBGet(ctx context.Context, key []byte, options ...rpc.Option) ([]byte, error) {
 	keyStr, err := formatting.Encode(formatting.HexNC, key)
 	if err != nil {
@@ -163,3 +183,25 @@ func (c *client) DBGet(ctx context.Context, key []byte, options ...rpc.Option) (
 	}
 	return formatting.Decode(formatting.HexNC, res.Value)
 }
+
+func (c *client) AddAccessListEntries(ctx context.Context, list string, entries []string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.addAccessListEntries", &AccessListArgs{
+		List:    network.AccessListType(list),
+		Entries: entries,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) RemoveAccessListEntries(ctx context.Context, list string, entries []string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.removeAccessListEntries", &AccessListArgs{
+		List:    network.AccessListType(list),
+		Entries: entries,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetAccessList(ctx context.Context, list string, options ...rpc.Option) ([]string, error) {
+	res := &GetAccessListReply{}
+	err := c.requester.SendRequest(ctx, "admin.getAccessList", &GetAccessListArgs{
+		List: network.AccessListType(list),
+	}, res, options...)
+	return res.Entries, err
+}
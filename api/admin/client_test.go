@@ -65,6 +65,9 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *LoggerLevelReply:
 		response := mc.response.(*LoggerLevelReply)
 		*p = *response
+	case *GetAccessListReply:
+		response := mc.response.(*GetAccessListReply)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -155,6 +158,57 @@ func TestGetChainAliases(t *testing.T) {
 	})
 }
 
+func TestAddAccessListEntries(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.AddAccessListEntries(context.Background(), "deny", []string{"10.0.0.0/8"})
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestRemoveAccessListEntries(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.RemoveAccessListEntries(context.Background(), "deny", []string{"10.0.0.0/8"})
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestSetPublicIP(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.SetPublicIP(context.Background(), "1.2.3.4", 9651)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestGetAccessList(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedReply := []string{"10.0.0.0/8", "NodeID-111111111111111111116DBWJs"}
+		mockClient := client{requester: NewMockClient(&GetAccessListReply{
+			Entries: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.GetAccessList(context.Background(), "deny")
+		require.NoError(err)
+		require.Equal(expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetAccessListReply{}, errTest)}
+		_, err := mockClient.GetAccessList(context.Background(), "deny")
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
 func TestStacktrace(t *testing.T) {
 	for _, test := range SuccessResponseTests {
 		t.Run(test.name, func(t *testing.T) {
@@ -356,3 +410,42 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestReloadConfig(t *testing.T) {
+	type test struct {
+		name             string
+		serviceErr       error
+		clientErr        error
+		expectedResponse interface{}
+	}
+	var resp interface{} = "response"
+	tests := []test{
+		{
+			name:             "Happy path",
+			serviceErr:       nil,
+			clientErr:        nil,
+			expectedResponse: &resp,
+		},
+		{
+			name:             "service errors",
+			serviceErr:       errTest,
+			clientErr:        errTest,
+			expectedResponse: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			c := client{
+				requester: NewMockClient(tt.expectedResponse, tt.serviceErr),
+			}
+			res, err := c.ReloadConfig(context.Background())
+			require.ErrorIs(err, tt.clientErr)
+			if tt.clientErr != nil {
+				return
+			}
+			require.Equal(resp, res)
+		})
+	}
+}
@@ -5,6 +5,8 @@ package admin
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"path"
 	"sync"
@@ -18,9 +20,11 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/perms"
@@ -39,8 +43,9 @@ const (
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong    = errors.New("alias length is too long")
+	errNoLogLevel      = errors.New("need to specify either displayLevel or logLevel")
+	errInvalidPublicIP = errors.New("invalid public IP")
 )
 
 type Config struct {
@@ -53,6 +58,12 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	AccessLists  network.AccessLists
+	// PublicIP is the IP and port that the node advertises to its peers
+	PublicIP *ips.OverridableIPPort
+	// ConfigReloader re-reads the config of the node, applies the changes
+	// that don't require a restart and returns a description of the changes.
+	ConfigReloader func() (interface{}, error)
 }
 
 // Admin is the API service for node admin management
@@ -320,6 +331,65 @@ func (a *Admin) GetConfig(_ *http.Request, _ *struct{}, reply *interface{}) erro
 	return nil
 }
 
+// ReloadConfig re-reads the config of the node and applies the changes that
+// can be made without restarting the node. Changes that require a restart are
+// returned, but aren't applied.
+func (a *Admin) ReloadConfig(_ *http.Request, _ *struct{}, reply *interface{}) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "reloadConfig"),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	reload, err := a.ConfigReloader()
+	if err != nil {
+		return err
+	}
+	*reply = reload
+	return nil
+}
+
+// SetPublicIPArgs are the arguments for calling SetPublicIP
+type SetPublicIPArgs struct {
+	IP string `json:"ip"`
+	// Port is the port to advertise. If 0, the current port is kept.
+	Port json.Uint16 `json:"port"`
+}
+
+// SetPublicIP overrides the IP and port that the node advertises to its peers.
+// Once overridden, the IP is no longer updated by NAT traversal or public IP
+// resolution. Peers that connect after the override receive the new IP.
+func (a *Admin) SetPublicIP(_ *http.Request, args *SetPublicIPArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "setPublicIP"),
+		logging.UserString("ip", args.IP),
+		zap.Uint16("port", uint16(args.Port)),
+	)
+
+	ip := net.ParseIP(args.IP)
+	if ip == nil || ip.IsUnspecified() {
+		return fmt.Errorf("%w: %q", errInvalidPublicIP, args.IP)
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	ipPort := a.PublicIP.IPPort()
+	ipPort.IP = ip
+	if args.Port != 0 {
+		ipPort.Port = uint16(args.Port)
+	}
+	a.PublicIP.Override(ipPort)
+
+	a.Log.Info("overrode public IP",
+		zap.Stringer("ip", ipPort),
+	)
+	return nil
+}
+
 // LoadVMsReply contains the response metadata for LoadVMs
 type LoadVMsReply struct {
 	// VMs and their aliases which were successfully loaded
@@ -355,6 +425,91 @@ func (a *Admin) LoadVMs(r *http.Request, _ *struct{}, reply *LoadVMsReply) error
 	return err
 }
 
+// AccessListArgs are the arguments for adding entries to, or removing entries
+// from, a peer access list.
+type AccessListArgs struct {
+	// List is either "allow" or "deny".
+	List network.AccessListType `json:"list"`
+	// Entries are NodeIDs, IPs or CIDRs. Allow list NodeIDs may specify the
+	// IP to connect to the node at in the form "NodeID-...@<ip>:<port>".
+	Entries []string `json:"entries"`
+}
+
+// AddAccessListEntries adds entries to the persisted allow or deny list of
+// peers. Connections to newly denied peers are closed.
+func (a *Admin) AddAccessListEntries(_ *http.Request, args *AccessListArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "addAccessListEntries"),
+		logging.UserString("list", string(args.List)),
+		logging.UserStrings("entries", args.Entries),
+	)
+
+	entries, err := parseAccessListEntries(args.Entries)
+	if err != nil {
+		return err
+	}
+	return a.AccessLists.AddAccessListEntries(args.List, entries)
+}
+
+// RemoveAccessListEntries removes entries from the persisted allow or deny
+// list of peers.
+func (a *Admin) RemoveAccessListEntries(_ *http.Request, args *AccessListArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "removeAccessListEntries"),
+		logging.UserString("list", string(args.List)),
+		logging.UserStrings("entries", args.Entries),
+	)
+
+	entries, err := parseAccessListEntries(args.Entries)
+	if err != nil {
+		return err
+	}
+	return a.AccessLists.RemoveAccessListEntries(args.List, entries)
+}
+
+type GetAccessListArgs struct {
+	// List is either "allow" or "deny".
+	List network.AccessListType `json:"list"`
+}
+
+type GetAccessListReply struct {
+	Entries []string `json:"entries"`
+}
+
+// GetAccessList returns the entries of the allow or deny list of peers.
+func (a *Admin) GetAccessList(_ *http.Request, args *GetAccessListArgs, reply *GetAccessListReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getAccessList"),
+		logging.UserString("list", string(args.List)),
+	)
+
+	entries, err := a.AccessLists.AccessListEntries(args.List)
+	if err != nil {
+		return err
+	}
+
+	reply.Entries = make([]string, len(entries))
+	for i, entry := range entries {
+		reply.Entries[i] = entry.String()
+	}
+	return nil
+}
+
+func parseAccessListEntries(entryStrs []string) ([]network.AccessListEntry, error) {
+	entries := make([]network.AccessListEntry, len(entryStrs))
+	for i, entryStr := range entryStrs {
+		entry, err := network.ParseAccessListEntry(entryStr)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
+
 func (a *Admin) getLoggerNames(loggerName string) []string {
 	if len(loggerName) == 0 {
 		// Empty name means all loggers
@@ -4,15 +4,20 @@
 package admin
 
 import (
+	"net"
 	"net/http"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/registry"
@@ -167,3 +172,123 @@ func TestServiceDBGet(t *testing.T) {
 		})
 	}
 }
+
+type testAccessLists map[network.AccessListType][]network.AccessListEntry
+
+func (l testAccessLists) AddAccessListEntries(list network.AccessListType, entries []network.AccessListEntry) error {
+	if err := list.Valid(); err != nil {
+		return err
+	}
+	l[list] = append(l[list], entries...)
+	return nil
+}
+
+func (l testAccessLists) RemoveAccessListEntries(list network.AccessListType, entries []network.AccessListEntry) error {
+	if err := list.Valid(); err != nil {
+		return err
+	}
+	l[list] = slices.DeleteFunc(l[list], func(entry network.AccessListEntry) bool {
+		return slices.ContainsFunc(entries, func(removed network.AccessListEntry) bool {
+			return entry.String() == removed.String()
+		})
+	})
+	return nil
+}
+
+func (l testAccessLists) AccessListEntries(list network.AccessListType) ([]network.AccessListEntry, error) {
+	return l[list], list.Valid()
+}
+
+func TestServiceAccessLists(t *testing.T) {
+	require := require.New(t)
+
+	a := &Admin{Config: Config{
+		Log:         logging.NoLog{},
+		AccessLists: testAccessLists{},
+	}}
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(a.AddAccessListEntries(
+		nil,
+		&AccessListArgs{
+			List:    network.DenyList,
+			Entries: []string{nodeID.String(), "10.1.2.3/8"},
+		},
+		&api.EmptyReply{},
+	))
+
+	reply := &GetAccessListReply{}
+	require.NoError(a.GetAccessList(
+		nil,
+		&GetAccessListArgs{
+			List: network.DenyList,
+		},
+		reply,
+	))
+	require.Equal([]string{nodeID.String(), "10.0.0.0/8"}, reply.Entries)
+
+	require.NoError(a.RemoveAccessListEntries(
+		nil,
+		&AccessListArgs{
+			List:    network.DenyList,
+			Entries: []string{"10.0.0.0/8"},
+		},
+		&api.EmptyReply{},
+	))
+
+	reply = &GetAccessListReply{}
+	require.NoError(a.GetAccessList(
+		nil,
+		&GetAccessListArgs{
+			List: network.DenyList,
+		},
+		reply,
+	))
+	require.Equal([]string{nodeID.String()}, reply.Entries)
+}
+
+func TestServiceReloadConfig(t *testing.T) {
+	require := require.New(t)
+
+	a := &Admin{Config: Config{
+		Log: logging.NoLog{},
+		ConfigReloader: func() (interface{}, error) {
+			return "reloaded", nil
+		},
+	}}
+
+	var reply interface{}
+	require.NoError(a.ReloadConfig(nil, &struct{}{}, &reply))
+	require.Equal("reloaded", reply)
+
+	a.ConfigReloader = func() (interface{}, error) {
+		return nil, errTest
+	}
+	err := a.ReloadConfig(nil, &struct{}{}, &reply)
+	require.ErrorIs(err, errTest)
+}
+
+func TestServiceSetPublicIP(t *testing.T) {
+	require := require.New(t)
+
+	publicIP := ips.NewOverridableIPPort(net.IPv4(1, 2, 3, 4), 9651)
+	a := &Admin{Config: Config{
+		Log:      logging.NoLog{},
+		PublicIP: publicIP,
+	}}
+
+	err := a.SetPublicIP(nil, &SetPublicIPArgs{IP: "not an ip"}, &api.EmptyReply{})
+	require.ErrorIs(err, errInvalidPublicIP)
+
+	err = a.SetPublicIP(nil, &SetPublicIPArgs{IP: "0.0.0.0"}, &api.EmptyReply{})
+	require.ErrorIs(err, errInvalidPublicIP)
+	require.False(publicIP.Overridden())
+
+	// The port is kept if it isn't provided
+	require.NoError(a.SetPublicIP(nil, &SetPublicIPArgs{IP: "5.6.7.8"}, &api.EmptyReply{}))
+	require.True(publicIP.Overridden())
+	require.Equal("5.6.7.8:9651", publicIP.IPPort().String())
+
+	require.NoError(a.SetPublicIP(nil, &SetPublicIPArgs{IP: "5.6.7.8", Port: 9000}, &api.EmptyReply{}))
+	require.Equal("5.6.7.8:9000", publicIP.IPPort().String())
+}
@@ -158,3 +158,27 @@ type GetUTXOsReply struct {
 	// Encoding specifies the encoding format the UTXOs are returned in
 	Encoding formatting.Encoding `json:"encoding"`
 }
+
+// MempoolTx is a tx that is pending in a mempool
+type MempoolTx struct {
+	TxID ids.ID         `json:"txID"`
+	Size avajson.Uint64 `json:"size"`
+}
+
+// DroppedTx is a tx that was recently dropped from a mempool
+type DroppedTx struct {
+	TxID   ids.ID `json:"txID"`
+	Reason string `json:"reason"`
+}
+
+// GetMempoolReply defines the GetMempool replies returned from the API
+type GetMempoolReply struct {
+	// The pending txs, from oldest to newest
+	Txs []MempoolTx `json:"txs"`
+	// The recently dropped txs, from least to most recently dropped
+	DroppedTxs []DroppedTx `json:"droppedTxs"`
+	// Number of bytes used by the pending txs
+	Bytes avajson.Uint64 `json:"bytes"`
+	// Number of bytes of space available for additional txs
+	BytesAvailable avajson.Uint64 `json:"bytesAvailable"`
+}
@@ -14,14 +14,19 @@ import (
 )
 
 type mockClient struct {
-	reply  APIReply
-	err    error
-	onCall func()
+	reply        APIReply
+	historyReply HistoryReply
+	err          error
+	onCall       func()
 }
 
 func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, replyIntf interface{}, _ ...rpc.Option) error {
-	reply := replyIntf.(*APIReply)
-	*reply = mc.reply
+	switch reply := replyIntf.(type) {
+	case *APIReply:
+		*reply = mc.reply
+	case *HistoryReply:
+		*reply = mc.historyReply
+	}
 	mc.onCall()
 	return mc.err
 }
@@ -65,6 +70,20 @@ func TestClient(t *testing.T) {
 		require.True(liveness.Healthy)
 	}
 
+	{
+		mc.historyReply = HistoryReply{
+			Transitions: []Transition{
+				{
+					Name:    "check",
+					Healthy: true,
+				},
+			},
+		}
+		history, err := c.History(context.Background(), nil, time.Time{}, time.Time{})
+		require.NoError(err)
+		require.Equal(&mc.historyReply, history)
+	}
+
 	{
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 		healthy, err := AwaitHealthy(ctx, c, time.Second, nil)
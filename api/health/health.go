@@ -52,6 +52,10 @@ type Reporter interface {
 	Readiness(tags ...string) (map[string]Result, bool)
 	Health(tags ...string) (map[string]Result, bool)
 	Liveness(tags ...string) (map[string]Result, bool)
+	// History returns the state transitions of the readiness, health and
+	// liveness checks with [tags] that happened in [start, end], from oldest
+	// to newest. A zero [end] isn't bounded.
+	History(start, end time.Time, tags ...string) []Transition
 }
 
 type health struct {
@@ -126,6 +130,14 @@ func (h *health) Liveness(tags ...string) (map[string]Result, bool) {
 	return results, healthy
 }
 
+func (h *health) History(start, end time.Time, tags ...string) []Transition {
+	transitions := h.readiness.History(start, end, tags...)
+	transitions = append(transitions, h.health.History(start, end, tags...)...)
+	transitions = append(transitions, h.liveness.History(start, end, tags...)...)
+	sortTransitions(transitions)
+	return transitions
+}
+
 func (h *health) Start(ctx context.Context, freq time.Duration) {
 	h.readiness.Start(ctx, freq)
 	h.health.Start(ctx, freq)
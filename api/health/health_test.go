@@ -390,3 +390,105 @@ func TestTags(t *testing.T) {
 		require.False(health)
 	}
 }
+
+func TestHistory(t *testing.T) {
+	require := require.New(t)
+
+	var shouldCheckErr utils.Atomic[bool]
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		if shouldCheckErr.Get() {
+			return errUnhealthy.Error(), errUnhealthy
+		}
+		return "", nil
+	})
+
+	w, err := newWorker(logging.NoLog{}, "health", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(w.RegisterCheck("check1", check, "tag1"))
+	require.NoError(w.RegisterCheck("check2", check, "tag2"))
+
+	ctx := context.Background()
+	w.runChecks(ctx)
+	shouldCheckErr.Set(true)
+	w.runChecks(ctx)
+
+	failedAt := time.Now()
+
+	// Checks that keep failing don't add transitions.
+	w.runChecks(ctx)
+	shouldCheckErr.Set(false)
+	w.runChecks(ctx)
+
+	unhealthyErr := errUnhealthy.Error()
+	expected := []Transition{
+		{
+			Namespace: "health",
+			Name:      "check1",
+			Healthy:   false,
+			Error:     notYetRunResult.Error,
+		},
+		{
+			Namespace: "health",
+			Name:      "check1",
+			Healthy:   true,
+		},
+		{
+			Namespace: "health",
+			Name:      "check1",
+			Healthy:   false,
+			Error:     &unhealthyErr,
+		},
+		{
+			Namespace: "health",
+			Name:      "check1",
+			Healthy:   true,
+		},
+	}
+
+	transitions := w.History(time.Time{}, time.Time{}, "tag1")
+	require.Len(transitions, len(expected))
+	for i, transition := range transitions {
+		require.False(transition.Timestamp.IsZero())
+		transition.Timestamp = time.Time{}
+		require.Equal(expected[i], transition)
+	}
+
+	transitions = w.History(time.Time{}, time.Time{})
+	require.Len(transitions, 2*len(expected))
+
+	transitions = w.History(failedAt, time.Time{}, "tag2")
+	require.Len(transitions, 1)
+	require.Equal("check2", transitions[0].Name)
+	require.True(transitions[0].Healthy)
+
+	transitions = w.History(time.Time{}, failedAt, "tag2")
+	require.Len(transitions, 3)
+	require.False(transitions[2].Healthy)
+}
+
+func TestHistoryIsBounded(t *testing.T) {
+	require := require.New(t)
+
+	var shouldCheckErr utils.Atomic[bool]
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		if shouldCheckErr.Get() {
+			return nil, errUnhealthy
+		}
+		return nil, nil
+	})
+
+	w, err := newWorker(logging.NoLog{}, "health", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(w.RegisterCheck("check", check))
+
+	ctx := context.Background()
+	for i := 0; i < 2*maxTransitionsPerCheck; i++ {
+		shouldCheckErr.Set(i%2 == 1)
+		w.runChecks(ctx)
+	}
+
+	transitions := w.History(time.Time{}, time.Time{})
+	require.Len(transitions, maxTransitionsPerCheck)
+	// Only the newest transitions are kept.
+	require.False(transitions[len(transitions)-1].Healthy)
+}
@@ -3,7 +3,10 @@
 
 package health
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // notYetRunResult is the result that is returned when a HealthCheck hasn't been
 // run yet.
@@ -36,3 +39,31 @@ type Result struct {
 	// TimeOfFirstFailure of the HealthCheck,
 	TimeOfFirstFailure *time.Time `json:"timeOfFirstFailure,omitempty"`
 }
+
+// Transition is a change of a check between passing and failing.
+type Transition struct {
+	// Namespace of the check, which is either "readiness", "health" or
+	// "liveness".
+	Namespace string `json:"namespace"`
+
+	// Name of the check.
+	Name string `json:"name"`
+
+	// Healthy is true if the check started passing, and false if it started
+	// failing.
+	Healthy bool `json:"healthy"`
+
+	// Error is the string representation of the error returned by the check
+	// when it started failing. The value is nil if the check started passing.
+	Error *string `json:"error,omitempty"`
+
+	// Timestamp of the HealthCheck that caused the transition.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// sortTransitions sorts [transitions] from oldest to newest.
+func sortTransitions(transitions []Transition) {
+	slices.SortStableFunc(transitions, func(a, b Transition) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+}
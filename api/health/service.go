@@ -5,6 +5,7 @@ package health
 
 import (
 	"net/http"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -60,3 +61,34 @@ func (s *Service) Liveness(_ *http.Request, args *APIArgs, reply *APIReply) erro
 	reply.Checks, reply.Healthy = s.health.Liveness(args.Tags...)
 	return nil
 }
+
+// HistoryArgs are the arguments for History.
+type HistoryArgs struct {
+	Tags []string `json:"tags"`
+	// StartTime is the earliest transition to return. If zero, the oldest
+	// kept transitions are returned.
+	StartTime time.Time `json:"startTime"`
+	// EndTime is the latest transition to return. If zero, the newest
+	// transitions are returned.
+	EndTime time.Time `json:"endTime"`
+}
+
+// HistoryReply is the response for History.
+type HistoryReply struct {
+	// Transitions of the readiness, health and liveness checks from oldest to
+	// newest.
+	Transitions []Transition `json:"transitions"`
+}
+
+// History returns when the checks of the node started passing or failing
+func (s *Service) History(_ *http.Request, args *HistoryArgs, reply *HistoryReply) error {
+	s.log.Debug("API called",
+		zap.String("service", "health"),
+		zap.String("method", "history"),
+		zap.Strings("tags", args.Tags),
+		zap.Time("startTime", args.StartTime),
+		zap.Time("endTime", args.EndTime),
+	)
+	reply.Transitions = s.health.History(args.StartTime, args.EndTime, args.Tags...)
+	return nil
+}
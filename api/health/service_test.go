@@ -222,3 +222,50 @@ func TestServiceTagResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceHistory(t *testing.T) {
+	require := require.New(t)
+
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		return "", nil
+	})
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(h.RegisterReadinessCheck("check", check))
+	require.NoError(h.RegisterHealthCheck("check", check))
+	require.NoError(h.RegisterLivenessCheck("check", check))
+
+	s := &Service{
+		log:    logging.NoLog{},
+		health: h,
+	}
+
+	h.Start(context.Background(), checkFreq)
+	defer h.Stop()
+
+	awaitReadiness(t, h, true)
+	awaitHealthy(t, h, true)
+	awaitLiveness(t, h, true)
+
+	reply := HistoryReply{}
+	require.NoError(s.History(nil, &HistoryArgs{}, &reply))
+
+	// Each check was registered as failing and then started passing.
+	require.Len(reply.Transitions, 6)
+	namespaces := make(map[string][]bool)
+	for i, transition := range reply.Transitions {
+		if i > 0 {
+			require.False(transition.Timestamp.Before(reply.Transitions[i-1].Timestamp))
+		}
+		namespaces[transition.Namespace] = append(namespaces[transition.Namespace], transition.Healthy)
+	}
+	require.Equal(
+		map[string][]bool{
+			"readiness": {false, true},
+			"health":    {false, true},
+			"liveness":  {false, true},
+		},
+		namespaces,
+	)
+}
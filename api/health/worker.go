@@ -16,10 +16,15 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/buffer"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
+// maxTransitionsPerCheck is the number of state transitions that are kept
+// for each check. Older transitions are dropped.
+const maxTransitionsPerCheck = 256
+
 var (
 	allTags = []string{AllTag}
 
@@ -38,6 +43,7 @@ type worker struct {
 	results                     map[string]Result
 	numFailingApplicationChecks int
 	tags                        map[string]set.Set[string] // tag -> set of check names
+	history                     map[string]buffer.Queue[Transition]
 
 	startOnce sync.Once
 	closeOnce sync.Once
@@ -65,6 +71,7 @@ func newWorker(
 		results:   make(map[string]Result),
 		closer:    make(chan struct{}),
 		tags:      make(map[string]set.Set[string]),
+		history:   make(map[string]buffer.Queue[Transition]),
 	}, err
 }
 
@@ -82,6 +89,11 @@ func (w *worker) RegisterCheck(name string, check Checker, tags ...string) error
 		return fmt.Errorf("%w: %q", errDuplicateCheck, name)
 	}
 
+	history, err := buffer.NewBoundedQueue[Transition](maxTransitionsPerCheck, nil)
+	if err != nil {
+		return err
+	}
+
 	w.resultsLock.Lock()
 	defer w.resultsLock.Unlock()
 
@@ -104,6 +116,8 @@ func (w *worker) RegisterCheck(name string, check Checker, tags ...string) error
 	}
 	w.checks[name] = tc
 	w.results[name] = notYetRunResult
+	w.history[name] = history
+	w.recordTransition(name, notYetRunResult.Error, time.Now())
 
 	// Whenever a new check is added - it is failing
 	w.log.Info("registered new check and initialized its state to failing",
@@ -138,6 +152,47 @@ func (w *worker) Results(tags ...string) (map[string]Result, bool) {
 	w.resultsLock.RLock()
 	defer w.resultsLock.RUnlock()
 
+	names := w.names(tags)
+	results := make(map[string]Result, names.Len())
+	healthy := true
+	for name := range names {
+		if result, ok := w.results[name]; ok {
+			results[name] = result
+			healthy = healthy && result.Error == nil
+		}
+	}
+	return results, healthy
+}
+
+// History returns the state transitions of the checks with [tags] that
+// happened in [start, end], from oldest to newest. A zero [end] isn't
+// bounded.
+func (w *worker) History(start, end time.Time, tags ...string) []Transition {
+	w.resultsLock.RLock()
+	defer w.resultsLock.RUnlock()
+
+	var transitions []Transition
+	for name := range w.names(tags) {
+		history, ok := w.history[name]
+		if !ok {
+			continue
+		}
+		for _, transition := range history.List() {
+			if transition.Timestamp.Before(start) || (!end.IsZero() && transition.Timestamp.After(end)) {
+				continue
+			}
+			transitions = append(transitions, transition)
+		}
+	}
+	sortTransitions(transitions)
+	return transitions
+}
+
+// names returns the names of the checks with [tags], including the
+// application-wide checks. If [tags] is empty, all checks are returned.
+//
+// Assumes [w.resultsLock] is held.
+func (w *worker) names(tags []string) set.Set[string] {
 	// if no tags are specified, return all checks
 	if len(tags) == 0 {
 		tags = allTags
@@ -151,16 +206,7 @@ func (w *worker) Results(tags ...string) (map[string]Result, bool) {
 			names.Union(set)
 		}
 	}
-
-	results := make(map[string]Result, names.Len())
-	healthy := true
-	for name := range names {
-		if result, ok := w.results[name]; ok {
-			results[name] = result
-			healthy = healthy && result.Error == nil
-		}
-	}
-	return results, healthy
+	return names
 }
 
 func (w *worker) Start(ctx context.Context, freq time.Duration) {
@@ -259,9 +305,28 @@ func (w *worker) runCheck(ctx context.Context, wg *sync.WaitGroup, name string,
 		)
 		w.updateMetrics(check, true /*=healthy*/, false /*=register*/)
 	}
+	// The first run of a check is recorded even if the check is still
+	// failing, so that the history includes the reason that it's failing.
+	if prevResult.Timestamp.IsZero() || (result.Error == nil) != (prevResult.Error == nil) {
+		w.recordTransition(name, result.Error, end)
+	}
 	w.results[name] = result
 }
 
+// recordTransition adds a transition of the check [name] to its history. The
+// check started passing if [err] is nil, and started failing otherwise.
+//
+// Assumes [w.resultsLock] is held.
+func (w *worker) recordTransition(name string, err *string, timestamp time.Time) {
+	w.history[name].Push(Transition{
+		Namespace: w.namespace,
+		Name:      name,
+		Healthy:   err == nil,
+		Error:     err,
+		Timestamp: timestamp,
+	})
+}
+
 // updateMetrics updates the metrics for the given check. If [healthy] is true,
 // then the check is considered healthy and the metrics are decremented.
 // Otherwise, the check is considered unhealthy and the metrics are incremented.
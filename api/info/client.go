@@ -20,6 +20,7 @@ type Client interface {
 	GetNodeVersion(context.Context, ...rpc.Option) (*GetNodeVersionReply, error)
 	GetNodeID(context.Context, ...rpc.Option) (ids.NodeID, *signer.ProofOfPossession, error)
 	GetNodeIP(context.Context, ...rpc.Option) (string, error)
+	GetNATStatus(context.Context, ...rpc.Option) (*GetNATStatusReply, error)
 	GetNetworkID(context.Context, ...rpc.Option) (uint32, error)
 	GetNetworkName(context.Context, ...rpc.Option) (string, error)
 	GetBlockchainID(context.Context, string, ...rpc.Option) (ids.ID, error)
@@ -60,6 +61,12 @@ func (c *client) GetNodeIP(ctx context.Context, options ...rpc.Option) (string,
 	return res.IP, err
 }
 
+func (c *client) GetNATStatus(ctx context.Context, options ...rpc.Option) (*GetNATStatusReply, error) {
+	res := &GetNATStatusReply{}
+	err := c.requester.SendRequest(ctx, "info.getNATStatus", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) GetNetworkID(ctx context.Context, options ...rpc.Option) (uint32, error) {
 	res := &GetNetworkIDReply{}
 	err := c.requester.SendRequest(ctx, "info.getNetworkID", struct{}{}, res, options...)
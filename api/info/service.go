@@ -13,6 +13,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
@@ -42,6 +43,7 @@ type Info struct {
 	chainManager chains.Manager
 	vmManager    vms.Manager
 	benchlist    benchlist.Manager
+	portMapper   *nat.Mapper
 }
 
 type Parameters struct {
@@ -70,6 +72,7 @@ func NewService(
 	myIP ips.DynamicIPPort,
 	network network.Network,
 	benchlist benchlist.Manager,
+	portMapper *nat.Mapper,
 ) (http.Handler, error) {
 	server := rpc.NewServer()
 	codec := json.NewCodec()
@@ -85,6 +88,7 @@ func NewService(
 			myIP:         myIP,
 			networking:   network,
 			benchlist:    benchlist,
+			portMapper:   portMapper,
 		},
 		"info",
 	)
@@ -158,6 +162,26 @@ func (i *Info) GetNodeIP(_ *http.Request, _ *struct{}, reply *GetNodeIPReply) er
 	return nil
 }
 
+// GetNATStatusReply are the results from calling GetNATStatus
+type GetNATStatusReply struct {
+	nat.Status
+	// IP is the IP and port that this node advertises to its peers
+	IP string `json:"ip"`
+}
+
+// GetNATStatus returns the status of the port mappings and the external IP
+// reported by the router
+func (i *Info) GetNATStatus(_ *http.Request, _ *struct{}, reply *GetNATStatusReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "getNATStatus"),
+	)
+
+	reply.Status = i.portMapper.Status()
+	reply.IP = i.myIP.IPPort().String()
+	return nil
+}
+
 // GetNetworkID returns the network ID this node is running on
 func (i *Info) GetNetworkID(_ *http.Request, _ *struct{}, reply *GetNetworkIDReply) error {
 	i.log.Debug("API called",
@@ -75,3 +75,33 @@ func TestAllowedHostsHandler_ServeHTTP(t *testing.T) {
 		})
 	}
 }
+
+func TestAllowedHostsHandler_SetAllowedHosts(t *testing.T) {
+	require := require.New(t)
+
+	baseHandler := &testHandler{}
+	httpAllowedHostsHandler := filterInvalidHosts(
+		baseHandler,
+		[]string{"www.foobar.com"},
+	)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("", "/", nil)
+	r.Host = "www.example.com"
+	httpAllowedHostsHandler.ServeHTTP(w, r)
+	require.False(baseHandler.called)
+	require.Equal(http.StatusForbidden, w.Code)
+
+	httpAllowedHostsHandler.setAllowedHosts([]string{"WWW.EXAMPLE.COM"})
+
+	w = httptest.NewRecorder()
+	httpAllowedHostsHandler.ServeHTTP(w, r)
+	require.True(baseHandler.called)
+
+	baseHandler.called = false
+	r.Host = "www.foobar.com"
+	w = httptest.NewRecorder()
+	httpAllowedHostsHandler.ServeHTTP(w, r)
+	require.False(baseHandler.called)
+	require.Equal(http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// PublicRole is the role of routes that may be called without
+// authenticating.
+const PublicRole = "public"
+
+const bearerPrefix = "Bearer "
+
+// AuthConfig configures how API callers are authenticated and which roles
+// they need to call each route.
+//
+// Callers authenticate with a bearer token in the Authorization header, with a
+// client TLS certificate that was verified against the server's client CAs, or
+// with both. A caller is granted the union of the roles of its credentials.
+type AuthConfig struct {
+	// Tokens maps bearer tokens to the roles they grant.
+	Tokens map[string][]string `json:"tokens"`
+	// ClientCertificates maps the subject common names of verified client TLS
+	// certificates to the roles they grant.
+	ClientCertificates map[string][]string `json:"clientCertificates"`
+	// Routes maps routes to the role required to call them. A route is the
+	// path after "/ext/", such as "admin", "info" or "bc/X/admin". Chain
+	// routes may use either the chain's ID or its primary alias. A route
+	// also applies to all of the routes beneath it that aren't in [Routes], so
+	// "bc" applies to every chain.
+	//
+	// Routes whose role is [PublicRole] may be called without
+	// authenticating.
+	Routes map[string]string `json:"routes"`
+	// DefaultRole is the role required to call routes that aren't in
+	// [Routes]. If empty, any authenticated caller may call them.
+	DefaultRole string `json:"defaultRole"`
+}
+
+type authenticator struct {
+	// sha256 of token -> roles
+	tokens      map[[sha256.Size]byte]set.Set[string]
+	clientCerts map[string]set.Set[string]
+	routes      map[string]string
+	defaultRole string
+}
+
+// newAuthenticator returns nil if [config] is nil, which disables
+// authentication.
+func newAuthenticator(config *AuthConfig) *authenticator {
+	if config == nil {
+		return nil
+	}
+
+	a := &authenticator{
+		tokens:      make(map[[sha256.Size]byte]set.Set[string], len(config.Tokens)),
+		clientCerts: make(map[string]set.Set[string], len(config.ClientCertificates)),
+		routes:      make(map[string]string, len(config.Routes)),
+		defaultRole: config.DefaultRole,
+	}
+	// Tokens are looked up by their hash so that the lookup doesn't leak the
+	// tokens through timing.
+	for token, roles := range config.Tokens {
+		a.tokens[sha256.Sum256([]byte(token))] = set.Of(roles...)
+	}
+	for commonName, roles := range config.ClientCertificates {
+		a.clientCerts[commonName] = set.Of(roles...)
+	}
+	for route, role := range config.Routes {
+		a.routes[trimRoute(route)] = role
+	}
+	return a
+}
+
+// requiredRole returns the role required to call the route that is reachable
+// at all of [routes]. Each route is checked, from most to least specific,
+// before its parents are.
+func (a *authenticator) requiredRole(routes ...string) string {
+	for len(routes) > 0 {
+		var parents []string
+		for _, route := range routes {
+			if role, ok := a.routes[route]; ok {
+				return role
+			}
+			if parent := path.Dir(route); parent != "." && parent != "/" {
+				parents = append(parents, parent)
+			}
+		}
+		routes = parents
+	}
+	return a.defaultRole
+}
+
+// knownToken returns the hash of the bearer token of [r]. Returns false if [r]
+// doesn't have a bearer token or if the token is unknown.
+func (a *authenticator) knownToken(r *http.Request) ([sha256.Size]byte, bool) {
+	if a == nil {
+		return [sha256.Size]byte{}, false
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), bearerPrefix)
+	if !ok {
+		return [sha256.Size]byte{}, false
+	}
+	tokenHash := sha256.Sum256([]byte(token))
+	_, ok = a.tokens[tokenHash]
+	return tokenHash, ok
+}
+
+// roles returns the roles granted to the caller of [r]. Returns false if the
+// caller didn't provide valid credentials.
+func (a *authenticator) roles(r *http.Request) (set.Set[string], bool) {
+	var (
+		roles         set.Set[string]
+		authenticated bool
+	)
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		token, ok := strings.CutPrefix(authorization, bearerPrefix)
+		if !ok {
+			return nil, false
+		}
+		tokenRoles, ok := a.tokens[sha256.Sum256([]byte(token))]
+		if !ok {
+			return nil, false
+		}
+		roles.Union(tokenRoles)
+		authenticated = true
+	}
+
+	// Only certificates that were verified against the client CAs are
+	// considered.
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		commonName := r.TLS.VerifiedChains[0][0].Subject.CommonName
+		if certRoles, ok := a.clientCerts[commonName]; ok {
+			roles.Union(certRoles)
+			authenticated = true
+		}
+	}
+	return roles, authenticated
+}
+
+// wrapHandler rejects calls to [handler] from callers that don't have the role
+// required by the route reachable at [routes].
+func (a *authenticator) wrapHandler(handler http.Handler, routes ...string) http.Handler {
+	if a == nil {
+		return handler
+	}
+
+	role := a.requiredRole(routes...)
+	if role == PublicRole {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roles, ok := a.roles(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "API call rejected because the caller isn't authenticated", http.StatusUnauthorized)
+			return
+		}
+		if role != "" && !roles.Contains(role) {
+			http.Error(w, "API call rejected because the caller doesn't have the required role", http.StatusForbidden)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// route returns the route of [endpoint] under [base], as configured in
+// [AuthConfig.Routes].
+func route(base, endpoint string) string {
+	return trimRoute(path.Join(base, endpoint))
+}
+
+func trimRoute(route string) string {
+	return strings.Trim(route, "/")
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticatorRequiredRole(t *testing.T) {
+	a := newAuthenticator(&AuthConfig{
+		Routes: map[string]string{
+			"admin":        "admin",
+			"info":         PublicRole,
+			"bc":           "chains",
+			"bc/P/admin":   "admin",
+			"/bc/X/":       PublicRole,
+			"index/X/tx":   "indexer",
+			"index/X/tx/x": "unused",
+		},
+		DefaultRole: "default",
+	})
+
+	tests := []struct {
+		name         string
+		routes       []string
+		expectedRole string
+	}{
+		{
+			name:         "exact route",
+			routes:       []string{"admin"},
+			expectedRole: "admin",
+		},
+		{
+			name:         "public route",
+			routes:       []string{"info"},
+			expectedRole: PublicRole,
+		},
+		{
+			name:         "parent route",
+			routes:       []string{"index/X/tx/socket"},
+			expectedRole: "indexer",
+		},
+		{
+			name:         "default role",
+			routes:       []string{"health/readiness"},
+			expectedRole: "default",
+		},
+		{
+			name:         "chain route by alias",
+			routes:       []string{"bc/11111111111111111111111111111111LpoYY/admin", "bc/P/admin"},
+			expectedRole: "admin",
+		},
+		{
+			name:         "chain by alias is more specific than all chains",
+			routes:       []string{"bc/2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM", "bc/X"},
+			expectedRole: PublicRole,
+		},
+		{
+			name:         "all chains",
+			routes:       []string{"bc/2q9e4r6Mu3U68nU1fYjgbR6JvwrRx36CohpAX5UQxse55x1Q5/rpc", "bc/C/rpc"},
+			expectedRole: "chains",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedRole, a.requiredRole(test.routes...))
+		})
+	}
+}
+
+func TestAuthenticatorWrapHandler(t *testing.T) {
+	a := newAuthenticator(&AuthConfig{
+		Tokens: map[string][]string{
+			"admin-token": {"admin"},
+			"user-token":  {"user"},
+		},
+		ClientCertificates: map[string][]string{
+			"admin-client": {"admin"},
+		},
+		Routes: map[string]string{
+			"admin": "admin",
+			"info":  PublicRole,
+		},
+	})
+
+	verifiedCert := func(commonName string) *tls.ConnectionState {
+		return &tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{{
+				Subject: pkix.Name{CommonName: commonName},
+			}}},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		route              string
+		authorization      string
+		tls                *tls.ConnectionState
+		expectedStatusCode int
+	}{
+		{
+			name:               "public route",
+			route:              "info",
+			expectedStatusCode: http.StatusTeapot,
+		},
+		{
+			name:               "no credentials",
+			route:              "admin",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "unknown token",
+			route:              "admin",
+			authorization:      "Bearer unknown-token",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "not a bearer token",
+			route:              "admin",
+			authorization:      "Basic admin-token",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "token without role",
+			route:              "admin",
+			authorization:      "Bearer user-token",
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "token with role",
+			route:              "admin",
+			authorization:      "Bearer admin-token",
+			expectedStatusCode: http.StatusTeapot,
+		},
+		{
+			name:               "any authenticated caller",
+			route:              "health",
+			authorization:      "Bearer user-token",
+			expectedStatusCode: http.StatusTeapot,
+		},
+		{
+			name:               "client certificate with role",
+			route:              "admin",
+			tls:                verifiedCert("admin-client"),
+			expectedStatusCode: http.StatusTeapot,
+		},
+		{
+			name:               "unknown client certificate",
+			route:              "admin",
+			tls:                verifiedCert("unknown-client"),
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:  "unverified client certificate",
+			route: "admin",
+			tls: &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{{
+					Subject: pkix.Name{CommonName: "admin-client"},
+				}},
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "roles of token and client certificate are combined",
+			route:              "admin",
+			authorization:      "Bearer user-token",
+			tls:                verifiedCert("admin-client"),
+			expectedStatusCode: http.StatusTeapot,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			handler := a.wrapHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusTeapot)
+				}),
+				test.route,
+			)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			if test.authorization != "" {
+				r.Header.Set("Authorization", test.authorization)
+			}
+			r.TLS = test.tls
+
+			handler.ServeHTTP(w, r)
+			require.Equal(test.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestAuthenticatorDisabled(t *testing.T) {
+	a := newAuthenticator(nil)
+	handler := a.wrapHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}),
+		"admin",
+	)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	require.Equal(t, http.StatusTeapot, w.Code)
+}
@@ -13,9 +13,10 @@ import (
 )
 
 type metrics struct {
-	numProcessing *prometheus.GaugeVec
-	numCalls      *prometheus.CounterVec
-	totalDuration *prometheus.GaugeVec
+	numProcessing  *prometheus.GaugeVec
+	numCalls       *prometheus.CounterVec
+	numRateLimited *prometheus.CounterVec
+	totalDuration  *prometheus.GaugeVec
 }
 
 func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
@@ -36,6 +37,14 @@ func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 			},
 			[]string{"base"},
 		),
+		numRateLimited: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "calls_rate_limited",
+				Help:      "The number of calls this API has rejected because the caller exceeded its rate limit",
+			},
+			[]string{"base"},
+		),
 		totalDuration: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: namespace,
@@ -49,6 +58,7 @@ func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 	err := utils.Err(
 		registerer.Register(m.numProcessing),
 		registerer.Register(m.numCalls),
+		registerer.Register(m.numRateLimited),
 		registerer.Register(m.totalDuration),
 	)
 	return m, err
@@ -0,0 +1,347 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// maxRateLimiters is the maximum number of (caller, route, method) budgets
+	// that are tracked at once. The least recently used budgets are dropped
+	// once this is exceeded.
+	maxRateLimiters = 64 * 1024
+
+	// rateLimitedErrorCode is the JSON-RPC error code of rate limited calls.
+	// It's the "limit exceeded" code used by EIP-1474.
+	rateLimitedErrorCode    = -32005
+	rateLimitedErrorMessage = "rate limit exceeded"
+
+	// DefaultMaxBodySize is the default maximum size of the body of a call
+	// to a route that is rate limited.
+	DefaultMaxBodySize = 4 * units.MiB
+)
+
+// RateLimit is the budget of a caller.
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which the budget refills. If 0, calls
+	// are never rate limited.
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	// Burst is the maximum number of calls that may be made at once. Values
+	// below 1 are treated as 1.
+	Burst int `json:"burst"`
+}
+
+// RateLimitConfig configures the budget of each caller.
+//
+// Callers are identified by their bearer token, if it's a token that the
+// server's [AuthConfig] knows, and by their IP otherwise. Each caller has a
+// separate budget for each route and JSON-RPC method.
+type RateLimitConfig struct {
+	// Default is the budget of routes and methods that aren't in [Routes].
+	Default RateLimit `json:"default"`
+	// Routes maps routes, or routes and methods in the form
+	// "<route>:<method>", to their budget. For example, "bc/X" or
+	// "bc/X:avm.getUTXOs". Like [AuthConfig.Routes], chain routes may use
+	// either the chain's ID or its primary alias, and a route also applies
+	// to all of the routes beneath it that aren't in [Routes].
+	Routes map[string]RateLimit `json:"routes"`
+	// MaxBodySize is the maximum size, in bytes, of the body of a call to a
+	// route that is rate limited, or that has methods that are rate limited.
+	// The body of these calls is read to find their JSON-RPC methods before
+	// they are rate limited, so calls with larger bodies are rejected. Calls
+	// to other routes aren't limited in size. If 0, [DefaultMaxBodySize] is
+	// used.
+	MaxBodySize int64 `json:"maxBodySize"`
+}
+
+type rateLimiter struct {
+	config RateLimitConfig
+	// Used to identify callers by their bearer token.
+	auth *authenticator
+
+	lock sync.Mutex
+	// caller, route and method -> budget
+	limiters cache.LRU[rateLimiterKey, *rate.Limiter]
+}
+
+type rateLimiterKey struct {
+	caller string
+	route  string
+	method string
+}
+
+// newRateLimiter returns nil if [config] is nil, which disables rate
+// limiting.
+func newRateLimiter(config *RateLimitConfig, auth *authenticator) *rateLimiter {
+	if config == nil {
+		return nil
+	}
+
+	routes := make(map[string]RateLimit, len(config.Routes))
+	for route, limit := range config.Routes {
+		routes[trimRoute(route)] = limit
+	}
+	maxBodySize := config.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+	return &rateLimiter{
+		config: RateLimitConfig{
+			Default:     config.Default,
+			Routes:      routes,
+			MaxBodySize: maxBodySize,
+		},
+		auth: auth,
+		limiters: cache.LRU[rateLimiterKey, *rate.Limiter]{
+			Size: maxRateLimiters,
+		},
+	}
+}
+
+// limit returns the budget of [method] on the route that is reachable at all
+// of [routes]. Each route is checked, from most to least specific, before its
+// parents are.
+func (l *rateLimiter) limit(method string, routes ...string) RateLimit {
+	for len(routes) > 0 {
+		var parents []string
+		for _, route := range routes {
+			if limit, ok := l.config.Routes[route+":"+method]; ok && method != "" {
+				return limit
+			}
+			if limit, ok := l.config.Routes[route]; ok {
+				return limit
+			}
+			if parent := path.Dir(route); parent != "." && parent != "/" {
+				parents = append(parents, parent)
+			}
+		}
+		routes = parents
+	}
+	return l.config.Default
+}
+
+// hasMethodLimits returns true if any method has its own budget on the route
+// that is reachable at all of [routes], or on any of its parents.
+func (l *rateLimiter) hasMethodLimits(routes ...string) bool {
+	var prefixes []string
+	for len(routes) > 0 {
+		var parents []string
+		for _, route := range routes {
+			prefixes = append(prefixes, route+":")
+			if parent := path.Dir(route); parent != "." && parent != "/" {
+				parents = append(parents, parent)
+			}
+		}
+		routes = parents
+	}
+
+	for route := range l.config.Routes {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(route, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allow returns true if the caller of [r] is within its budget for [method]
+// on the route that is reachable at all of [routes]. Consumes one call from
+// the budget if so. Otherwise, returns how long the caller should wait before
+// retrying.
+func (l *rateLimiter) allow(r *http.Request, method string, routes ...string) (time.Duration, bool) {
+	limit := l.limit(method, routes...)
+	if limit.RequestsPerSecond <= 0 {
+		return 0, true
+	}
+
+	key := rateLimiterKey{
+		caller: l.caller(r),
+		route:  routes[0],
+		method: method,
+	}
+
+	l.lock.Lock()
+	limiter, ok := l.limiters.Get(key)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), max(limit.Burst, 1))
+		l.limiters.Put(key, limiter)
+	}
+	l.lock.Unlock()
+
+	if limiter.Allow() {
+		return 0, true
+	}
+	return time.Duration(float64(time.Second) / limit.RequestsPerSecond), false
+}
+
+// caller returns the identity of the caller of [r].
+func (l *rateLimiter) caller(r *http.Request) string {
+	if tokenHash, ok := l.auth.knownToken(r); ok {
+		return "token:" + hex.EncodeToString(tokenHash[:])
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// allowAll returns true if the caller of [r] is within its budget for each of
+// [requests] on the route that is reachable at all of [routes]. If [requests]
+// is empty, the call is charged to the route. Otherwise, returns how long the
+// caller should wait before retrying.
+func (l *rateLimiter) allowAll(r *http.Request, requests []jsonRPCRequest, routes ...string) (time.Duration, bool) {
+	if len(requests) == 0 {
+		return l.allow(r, "", routes...)
+	}
+	for _, request := range requests {
+		if retryAfter, ok := l.allow(r, request.Method, routes...); !ok {
+			return retryAfter, false
+		}
+	}
+	return 0, true
+}
+
+// wrapHandler rejects calls to [handler] from callers that have exceeded their
+// budget on the route reachable at [routes]. Rejected calls are counted by
+// [numRateLimited].
+//
+// Each call in a JSON-RPC batch is charged to the budget of its method, and
+// the batch is rejected if any of them exceed their budget.
+func (l *rateLimiter) wrapHandler(
+	handler http.Handler,
+	numRateLimited prometheus.Counter,
+	routes ...string,
+) http.Handler {
+	// If neither the route nor any of its methods are rate limited, the body
+	// doesn't need to be read.
+	if l == nil || (l.limit("", routes...).RequestsPerSecond <= 0 && !l.hasMethodLimits(routes...)) {
+		return handler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests, batch, err := peekJSONRPCRequests(w, r, l.config.MaxBodySize)
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		case err != nil:
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		retryAfter, ok := l.allowAll(r, requests, routes...)
+		if ok {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		numRateLimited.Inc()
+		retryAfterSeconds := int(math.Ceil(retryAfter.Seconds()))
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+
+		// Calls that aren't JSON-RPC calls can't be sent a JSON-RPC error.
+		if len(requests) == 0 {
+			http.Error(w, rateLimitedErrorMessage, http.StatusTooManyRequests)
+			return
+		}
+
+		responses := make([]jsonRPCErrorResponse, len(requests))
+		for i, request := range requests {
+			id := request.ID
+			if len(id) == 0 {
+				id = json.RawMessage("null")
+			}
+			responses[i] = jsonRPCErrorResponse{
+				Version: "2.0",
+				Error: jsonRPCError{
+					Code:    rateLimitedErrorCode,
+					Message: rateLimitedErrorMessage,
+				},
+				ID: id,
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if batch {
+			_ = json.NewEncoder(w).Encode(responses)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(responses[0])
+	})
+}
+
+type jsonRPCRequest struct {
+	Method string          `json:"method"`
+	ID     json.RawMessage `json:"id"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonRPCErrorResponse struct {
+	Version string          `json:"jsonrpc"`
+	Error   jsonRPCError    `json:"error"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// peekJSONRPCRequests returns the methods and IDs of the JSON-RPC calls in the
+// body of [r], without consuming the body. If the body is a batch, [batch] is
+// true and each call in the batch is returned. Calls in a batch that can't be
+// parsed are returned with an empty method. If [r] isn't a JSON-RPC call, no
+// calls are returned. If the body is larger than [maxBodySize] bytes, an
+// [*http.MaxBytesError] is returned once [maxBodySize] bytes have been read.
+func peekJSONRPCRequests(w http.ResponseWriter, r *http.Request, maxBodySize int64) ([]jsonRPCRequest, bool, error) {
+	if r.Method != http.MethodPost || r.Body == nil {
+		return nil, false, nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		return nil, false, err
+	}
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	// The handler is responsible for rejecting malformed calls.
+	var batch []json.RawMessage
+	if err := json.Unmarshal(body, &batch); err != nil {
+		var request jsonRPCRequest
+		if err := json.Unmarshal(body, &request); err != nil || request.Method == "" {
+			return nil, false, nil
+		}
+		return []jsonRPCRequest{request}, false, nil
+	}
+
+	requests := make([]jsonRPCRequest, len(batch))
+	for i, rawRequest := range batch {
+		// Calls that can't be parsed are charged to the route.
+		_ = json.Unmarshal(rawRequest, &requests[i])
+	}
+	return requests, true, nil
+}
@@ -0,0 +1,339 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterLimit(t *testing.T) {
+	var (
+		defaultLimit = RateLimit{RequestsPerSecond: 1}
+		chainsLimit  = RateLimit{RequestsPerSecond: 2}
+		xLimit       = RateLimit{RequestsPerSecond: 3}
+		utxosLimit   = RateLimit{RequestsPerSecond: 4}
+		infoLimit    = RateLimit{RequestsPerSecond: 5}
+	)
+	l := newRateLimiter(&RateLimitConfig{
+		Default: defaultLimit,
+		Routes: map[string]RateLimit{
+			"bc":                  chainsLimit,
+			"/bc/X":               xLimit,
+			"bc/X:avm.getUTXOs":   utxosLimit,
+			"info:info.getNodeID": infoLimit,
+		},
+	}, nil)
+
+	tests := []struct {
+		name          string
+		method        string
+		routes        []string
+		expectedLimit RateLimit
+	}{
+		{
+			name:          "default",
+			method:        "health.health",
+			routes:        []string{"health"},
+			expectedLimit: defaultLimit,
+		},
+		{
+			name:          "method",
+			method:        "info.getNodeID",
+			routes:        []string{"info"},
+			expectedLimit: infoLimit,
+		},
+		{
+			name:          "other method",
+			method:        "info.getNodeIP",
+			routes:        []string{"info"},
+			expectedLimit: defaultLimit,
+		},
+		{
+			name:          "chain method by alias",
+			method:        "avm.getUTXOs",
+			routes:        []string{"bc/2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM", "bc/X"},
+			expectedLimit: utxosLimit,
+		},
+		{
+			name:          "chain by alias",
+			method:        "avm.getTx",
+			routes:        []string{"bc/2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM", "bc/X"},
+			expectedLimit: xLimit,
+		},
+		{
+			name:          "all chains",
+			method:        "platform.getCurrentValidators",
+			routes:        []string{"bc/11111111111111111111111111111111LpoYY", "bc/P"},
+			expectedLimit: chainsLimit,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expectedLimit, l.limit(test.method, test.routes...))
+		})
+	}
+}
+
+func TestRateLimiterWrapHandler(t *testing.T) {
+	require := require.New(t)
+
+	l := newRateLimiter(
+		&RateLimitConfig{
+			Default: RateLimit{
+				RequestsPerSecond: 0.001,
+				Burst:             2,
+			},
+			Routes: map[string]RateLimit{
+				"info:info.peers": {}, // Not rate limited
+			},
+		},
+		newAuthenticator(&AuthConfig{
+			Tokens: map[string][]string{
+				"token": nil,
+			},
+		}),
+	)
+
+	numRateLimited := prometheus.NewCounter(prometheus.CounterOpts{})
+	handler := l.wrapHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The body should still be readable by the handler.
+			body, err := io.ReadAll(r.Body)
+			require.NoError(err)
+			require.NotEmpty(body)
+			w.WriteHeader(http.StatusTeapot)
+		}),
+		numRateLimited,
+		"info",
+	)
+
+	call := func(remoteAddr, token, method string) *httptest.ResponseRecorder {
+		body := `{"jsonrpc":"2.0","method":"` + method + `","params":{},"id":7}`
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		r.RemoteAddr = remoteAddr
+		if token != "" {
+			r.Header.Set("Authorization", bearerPrefix+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	// The caller can make [Burst] calls before it is rate limited.
+	require.Equal(http.StatusTeapot, call("1.2.3.4:1", "", "info.getNodeID").Code)
+	require.Equal(http.StatusTeapot, call("1.2.3.4:2", "", "info.getNodeID").Code)
+	w := call("1.2.3.4:3", "", "info.getNodeID")
+	require.Equal(http.StatusOK, w.Code)
+	require.Equal("1000", w.Header().Get("Retry-After"))
+
+	var response jsonRPCErrorResponse
+	require.NoError(json.NewDecoder(w.Body).Decode(&response))
+	require.Equal(jsonRPCErrorResponse{
+		Version: "2.0",
+		Error: jsonRPCError{
+			Code:    rateLimitedErrorCode,
+			Message: rateLimitedErrorMessage,
+		},
+		ID: json.RawMessage("7"),
+	}, response)
+	require.Equal(1.0, testutil.ToFloat64(numRateLimited))
+
+	// Each method has a separate budget.
+	require.Equal(http.StatusTeapot, call("1.2.3.4:4", "", "info.getNodeIP").Code)
+
+	// Methods without a limit are never rate limited.
+	for i := 0; i < 5; i++ {
+		require.Equal(http.StatusTeapot, call("1.2.3.4:5", "", "info.peers").Code)
+	}
+
+	// Each IP has a separate budget.
+	require.Equal(http.StatusTeapot, call("5.6.7.8:1", "", "info.getNodeID").Code)
+
+	// Callers with a known token have a separate budget from their IP.
+	require.Equal(http.StatusTeapot, call("1.2.3.4:6", "token", "info.getNodeID").Code)
+	require.Equal(http.StatusTeapot, call("5.6.7.8:2", "token", "info.getNodeID").Code)
+	require.Equal(http.StatusOK, call("9.9.9.9:1", "token", "info.getNodeID").Code)
+
+	// Unknown tokens don't bypass the budget of their IP.
+	require.Equal(http.StatusOK, call("1.2.3.4:7", "unknown", "info.getNodeID").Code)
+	require.Equal(3.0, testutil.ToFloat64(numRateLimited))
+}
+
+func TestRateLimiterWrapHandlerNotJSONRPC(t *testing.T) {
+	require := require.New(t)
+
+	l := newRateLimiter(&RateLimitConfig{
+		Default: RateLimit{
+			RequestsPerSecond: 0.001,
+		},
+	}, nil)
+
+	numRateLimited := prometheus.NewCounter(prometheus.CounterOpts{})
+	handler := l.wrapHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}),
+		numRateLimited,
+		"health",
+	)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(http.StatusTeapot, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(http.StatusTooManyRequests, w.Code)
+	require.Equal(1.0, testutil.ToFloat64(numRateLimited))
+}
+
+func TestRateLimiterWrapHandlerMaxBodySize(t *testing.T) {
+	require := require.New(t)
+
+	l := newRateLimiter(&RateLimitConfig{
+		Default: RateLimit{
+			RequestsPerSecond: 1,
+		},
+		MaxBodySize: 64,
+	}, nil)
+
+	numRateLimited := prometheus.NewCounter(prometheus.CounterOpts{})
+	handler := l.wrapHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}),
+		numRateLimited,
+		"info",
+	)
+
+	// The body is larger than the limit, so it is rejected before being rate
+	// limited.
+	body := `{"jsonrpc":"2.0","method":"info.getNodeID","params":{"padding":"` + strings.Repeat("a", 64) + `"},"id":7}`
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	require.Equal(http.StatusRequestEntityTooLarge, w.Code)
+	require.Zero(testutil.ToFloat64(numRateLimited))
+
+	// Calls within the limit are passed to the handler.
+	body = `{"jsonrpc":"2.0","method":"info.getNodeID","id":7}`
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	require.Equal(http.StatusTeapot, w.Code)
+}
+
+func TestRateLimiterWrapHandlerUnlimitedRoute(t *testing.T) {
+	require := require.New(t)
+
+	l := newRateLimiter(&RateLimitConfig{
+		Default: RateLimit{
+			RequestsPerSecond: 1,
+		},
+		Routes: map[string]RateLimit{
+			"bc/X":                {}, // Not rate limited
+			"bc/P":                {}, // Not rate limited, other than getTx
+			"bc/P:platform.getTx": {RequestsPerSecond: 1},
+		},
+		MaxBodySize: 64,
+	}, nil)
+
+	numRateLimited := prometheus.NewCounter(prometheus.CounterOpts{})
+	wrap := func(route string) http.Handler {
+		return l.wrapHandler(
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			}),
+			numRateLimited,
+			route,
+		)
+	}
+
+	// The body of calls to routes that aren't rate limited isn't read, so it
+	// isn't limited in size.
+	body := `{"jsonrpc":"2.0","method":"avm.issueTx","params":{"tx":"` + strings.Repeat("a", 64) + `"},"id":7}`
+	w := httptest.NewRecorder()
+	wrap("bc/X").ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	require.Equal(http.StatusTeapot, w.Code)
+
+	// The body of calls to routes with rate limited methods must be read.
+	body = `{"jsonrpc":"2.0","method":"platform.issueTx","params":{"tx":"` + strings.Repeat("a", 64) + `"},"id":7}`
+	w = httptest.NewRecorder()
+	wrap("bc/P").ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	require.Equal(http.StatusRequestEntityTooLarge, w.Code)
+	require.Zero(testutil.ToFloat64(numRateLimited))
+}
+
+func TestRateLimiterWrapHandlerBatch(t *testing.T) {
+	require := require.New(t)
+
+	l := newRateLimiter(&RateLimitConfig{
+		Routes: map[string]RateLimit{
+			"info:info.getNodeID": {
+				RequestsPerSecond: 0.001,
+				Burst:             2,
+			},
+		},
+	}, nil)
+
+	numRateLimited := prometheus.NewCounter(prometheus.CounterOpts{})
+	handler := l.wrapHandler(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}),
+		numRateLimited,
+		"info",
+	)
+
+	call := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		return w
+	}
+
+	// Each call in a batch is charged to the budget of its method.
+	require.Equal(http.StatusTeapot, call(`[
+		{"jsonrpc":"2.0","method":"info.getNodeID","id":1},
+		{"jsonrpc":"2.0","method":"info.peers","id":2}
+	]`).Code)
+	w := call(`[
+		{"jsonrpc":"2.0","method":"info.getNodeID","id":3},
+		{"jsonrpc":"2.0","method":"info.getNodeID","id":4}
+	]`)
+	require.Equal(http.StatusOK, w.Code)
+
+	var responses []jsonRPCErrorResponse
+	require.NoError(json.NewDecoder(w.Body).Decode(&responses))
+	require.Equal([]jsonRPCErrorResponse{
+		{
+			Version: "2.0",
+			Error: jsonRPCError{
+				Code:    rateLimitedErrorCode,
+				Message: rateLimitedErrorMessage,
+			},
+			ID: json.RawMessage("3"),
+		},
+		{
+			Version: "2.0",
+			Error: jsonRPCError{
+				Code:    rateLimitedErrorCode,
+				Message: rateLimitedErrorMessage,
+			},
+			ID: json.RawMessage("4"),
+		},
+	}, responses)
+	require.Equal(1.0, testutil.ToFloat64(numRateLimited))
+
+	// Malformed calls in a batch don't hide the other calls.
+	w = call(`[5, {"jsonrpc":"2.0","method":"info.getNodeID","id":6}]`)
+	require.Equal(http.StatusOK, w.Code)
+	require.Equal(2.0, testutil.ToFloat64(numRateLimited))
+}
@@ -65,6 +65,8 @@ type Server interface {
 	// That is, add <route, handler> pairs to server so that API calls can be
 	// made to the VM.
 	RegisterChain(chainName string, ctx *snow.ConsensusContext, vm common.VM)
+	// SetAllowedHosts replaces the hostnames that requests are accepted for.
+	SetAllowedHosts(allowedHosts []string)
 	// Shutdown this server
 	Shutdown() error
 }
@@ -89,8 +91,17 @@ type server struct {
 
 	metrics *metrics
 
+	// Rejects calls from callers without the required role. Nil if
+	// authentication is disabled.
+	auth *authenticator
+	// Rejects calls from callers that exceeded their rate limit. Nil if rate
+	// limiting is disabled.
+	rateLimiter *rateLimiter
+
 	// Maps endpoints to handlers
 	router *router
+	// Rejects requests for hostnames that aren't allowed
+	allowedHosts *allowedHostsHandler
 
 	srv *http.Server
 
@@ -112,6 +123,8 @@ func New(
 	registerer prometheus.Registerer,
 	httpConfig HTTPConfig,
 	allowedHosts []string,
+	authConfig *AuthConfig,
+	rateLimitConfig *RateLimitConfig,
 ) (Server, error) {
 	m, err := newMetrics(namespace, registerer)
 	if err != nil {
@@ -149,8 +162,12 @@ func New(
 
 	log.Info("API created",
 		zap.Strings("allowedOrigins", allowedOrigins),
+		zap.Bool("authEnabled", authConfig != nil),
+		zap.Bool("rateLimitEnabled", rateLimitConfig != nil),
 	)
 
+	auth := newAuthenticator(authConfig)
+
 	return &server{
 		log:             log,
 		factory:         factory,
@@ -158,7 +175,10 @@ func New(
 		tracingEnabled:  tracingEnabled,
 		tracer:          tracer,
 		metrics:         m,
+		auth:            auth,
+		rateLimiter:     newRateLimiter(rateLimitConfig, auth),
 		router:          router,
+		allowedHosts:    allowedHostsHandler,
 		srv:             httpServer,
 		listener:        listener,
 	}, nil
@@ -217,6 +237,16 @@ func (s *server) addChainRoute(chainName string, handler http.Handler, ctx *snow
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	handler = rejectMiddleware(handler, ctx)
+	// Apply middleware to reject calls from callers without the required role.
+	// The route may be configured by either the chain's ID or its name.
+	routes := []string{
+		route(base, endpoint),
+		route(path.Join(constants.ChainAliasPrefix, chainName), endpoint),
+	}
+	handler = s.auth.wrapHandler(handler, routes...)
+	// Apply middleware to reject calls from callers that exceeded their rate
+	// limit.
+	handler = s.rateLimiter.wrapHandler(handler, s.metrics.numRateLimited.WithLabelValues(chainName), routes...)
 	handler = s.metrics.wrapHandler(chainName, handler)
 	return s.router.AddRouter(url, endpoint, handler)
 }
@@ -242,6 +272,8 @@ func (s *server) addRoute(handler http.Handler, base, endpoint string) error {
 		handler = api.TraceHandler(handler, url, s.tracer)
 	}
 
+	handler = s.auth.wrapHandler(handler, route(base, endpoint))
+	handler = s.rateLimiter.wrapHandler(handler, s.metrics.numRateLimited.WithLabelValues(base), route(base, endpoint))
 	handler = s.metrics.wrapHandler(base, handler)
 	return s.router.AddRouter(url, endpoint, handler)
 }
@@ -277,6 +309,13 @@ func (s *server) AddAliasesWithReadLock(endpoint string, aliases ...string) erro
 	return s.AddAliases(endpoint, aliases...)
 }
 
+func (s *server) SetAllowedHosts(allowedHosts []string) {
+	s.allowedHosts.setAllowedHosts(allowedHosts)
+	s.log.Info("updated allowed hosts",
+		zap.Strings("allowedHosts", allowedHosts),
+	)
+}
+
 func (s *server) Shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 	err := s.srv.Shutdown(ctx)
@@ -41,6 +41,11 @@ type App interface {
 	// ExitCode should only be called after [Start] returns with no error. It
 	// should block until the application finishes
 	ExitCode() (int, error)
+
+	// ReloadConfig re-reads the config of the application and applies the
+	// changes that don't require a restart.
+	// ReloadConfig should only be called after [Start].
+	ReloadConfig() error
 }
 
 func New(config node.Config) (App, error) {
@@ -94,6 +99,10 @@ func Run(app App) int {
 	signal.Notify(signals, syscall.SIGINT)
 	signal.Notify(signals, syscall.SIGTERM)
 
+	// register signals to reload the config of the application
+	reloadSignals := make(chan os.Signal, 1)
+	signal.Notify(reloadSignals, syscall.SIGHUP)
+
 	// start up a new go routine to handle attempts to kill the application
 	var eg errgroup.Group
 	eg.Go(func() error {
@@ -103,12 +112,23 @@ func Run(app App) int {
 		return nil
 	})
 
+	// start up a new go routine to handle attempts to reload the config. Reload
+	// failures are reported by the application, and don't stop it.
+	eg.Go(func() error {
+		for range reloadSignals {
+			_ = app.ReloadConfig()
+		}
+		return nil
+	})
+
 	// wait for the app to exit and get the exit code response
 	exitCode, err := app.ExitCode()
 
-	// shut down the signal go routine
+	// shut down the signal go routines
 	signal.Stop(signals)
 	close(signals)
+	signal.Stop(reloadSignals)
+	close(reloadSignals)
 
 	// if there was an error closing or running the application, report that error
 	if eg.Wait() != nil || err != nil {
@@ -170,3 +190,15 @@ func (a *app) ExitCode() (int, error) {
 	a.exitWG.Wait()
 	return a.node.ExitCode(), nil
 }
+
+// ReloadConfig re-reads the config of the node and applies the changes that
+// don't require a restart.
+func (a *app) ReloadConfig() error {
+	if _, err := a.node.ReloadConfig(); err != nil {
+		a.log.Error("failed to reload config",
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
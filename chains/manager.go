@@ -59,6 +59,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/proposervm"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/vms/tracedvm"
 
@@ -686,18 +687,21 @@ func (m *manager) createAvalancheChain(
 
 	// Initialize the ProposerVM and the vm wrapped inside it
 	var (
-		minBlockDelay       = proposervm.DefaultMinBlockDelay
-		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
+		minBlockDelay        = proposervm.DefaultMinBlockDelay
+		numHistoricalBlocks  = proposervm.DefaultNumHistoricalBlocks
+		proposerWindowConfig *proposer.WindowConfig
 	)
 	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		proposerWindowConfig = subnetCfg.ProposerWindowConfig
 	}
 	m.Log.Info("creating proposervm wrapper",
 		zap.Time("activationTime", m.ApricotPhase4Time),
 		zap.Uint64("minPChainHeight", m.ApricotPhase4MinPChainHeight),
 		zap.Duration("minBlockDelay", minBlockDelay),
 		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
+		zap.Reflect("proposerWindowConfig", proposerWindowConfig),
 	)
 
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
@@ -715,13 +719,14 @@ func (m *manager) createAvalancheChain(
 	var vmWrappingProposerVM block.ChainVM = proposervm.New(
 		vmWrappedInsideProposerVM,
 		proposervm.Config{
-			ActivationTime:      m.ApricotPhase4Time,
-			DurangoTime:         version.GetDurangoTime(m.NetworkID),
-			MinimumPChainHeight: m.ApricotPhase4MinPChainHeight,
-			MinBlkDelay:         minBlockDelay,
-			NumHistoricalBlocks: numHistoricalBlocks,
-			StakingLeafSigner:   m.StakingTLSSigner,
-			StakingCertLeaf:     m.StakingTLSCert,
+			ActivationTime:       m.ApricotPhase4Time,
+			DurangoTime:          version.GetDurangoTime(m.NetworkID),
+			MinimumPChainHeight:  m.ApricotPhase4MinPChainHeight,
+			MinBlkDelay:          minBlockDelay,
+			NumHistoricalBlocks:  numHistoricalBlocks,
+			StakingLeafSigner:    m.StakingTLSSigner,
+			StakingCertLeaf:      m.StakingTLSCert,
+			ProposerWindowConfig: proposerWindowConfig,
 		},
 	)
 
@@ -1030,18 +1035,21 @@ func (m *manager) createSnowmanChain(
 	}
 
 	var (
-		minBlockDelay       = proposervm.DefaultMinBlockDelay
-		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
+		minBlockDelay        = proposervm.DefaultMinBlockDelay
+		numHistoricalBlocks  = proposervm.DefaultNumHistoricalBlocks
+		proposerWindowConfig *proposer.WindowConfig
 	)
 	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		proposerWindowConfig = subnetCfg.ProposerWindowConfig
 	}
 	m.Log.Info("creating proposervm wrapper",
 		zap.Time("activationTime", m.ApricotPhase4Time),
 		zap.Uint64("minPChainHeight", m.ApricotPhase4MinPChainHeight),
 		zap.Duration("minBlockDelay", minBlockDelay),
 		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
+		zap.Reflect("proposerWindowConfig", proposerWindowConfig),
 	)
 
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
@@ -1052,13 +1060,14 @@ func (m *manager) createSnowmanChain(
 	vm = proposervm.New(
 		vm,
 		proposervm.Config{
-			ActivationTime:      m.ApricotPhase4Time,
-			DurangoTime:         version.GetDurangoTime(m.NetworkID),
-			MinimumPChainHeight: m.ApricotPhase4MinPChainHeight,
-			MinBlkDelay:         minBlockDelay,
-			NumHistoricalBlocks: numHistoricalBlocks,
-			StakingLeafSigner:   m.StakingTLSSigner,
-			StakingCertLeaf:     m.StakingTLSCert,
+			ActivationTime:       m.ApricotPhase4Time,
+			DurangoTime:          version.GetDurangoTime(m.NetworkID),
+			MinimumPChainHeight:  m.ApricotPhase4MinPChainHeight,
+			MinBlkDelay:          minBlockDelay,
+			NumHistoricalBlocks:  numHistoricalBlocks,
+			StakingLeafSigner:    m.StakingTLSSigner,
+			StakingCertLeaf:      m.StakingTLSCert,
+			ProposerWindowConfig: proposerWindowConfig,
 		},
 	)
 
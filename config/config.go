@@ -88,6 +88,10 @@ var (
 	errCannotReadDirectory                    = errors.New("cannot read directory")
 	errUnmarshalling                          = errors.New("unmarshalling failed")
 	errFileDoesNotExist                       = errors.New("file does not exist")
+	errHTTPSClientCAWithoutHTTPS              = fmt.Errorf("%s set but %s not enabled", HTTPSClientCAFileKey, HTTPSEnabledKey)
+	errAPIAuthConfigUnset                     = fmt.Errorf("%s enabled but neither %s nor %s set", APIAuthEnabledKey, APIAuthConfigFileKey, APIAuthConfigContentKey)
+	errInvalidLogSink                         = errors.New("invalid log sink")
+	errInvalidAPIRateLimitMaxBodySize         = errors.New("API rate limit max body size must be > 0")
 )
 
 func getConsensusConfig(v *viper.Viper) snowball.Parameters {
@@ -135,10 +139,55 @@ func getLoggingConfig(v *viper.Viper) (logging.Config, error) {
 	loggingConfig.MaxFiles = int(v.GetUint(LogRotaterMaxFilesKey))
 	loggingConfig.MaxAge = int(v.GetUint(LogRotaterMaxAgeKey))
 	loggingConfig.Compress = v.GetBool(LogRotaterCompressEnabledKey)
+	if err != nil {
+		return loggingConfig, err
+	}
 
+	loggingConfig.Sinks, err = getLogSinksConfig(v, loggingConfig.LogLevel)
 	return loggingConfig, err
 }
 
+// getLogSinksConfig returns the configured log sinks. Sinks that don't specify
+// a level default to [defaultLevel].
+func getLogSinksConfig(v *viper.Viper, defaultLevel logging.Level) ([]logging.SinkConfig, error) {
+	var (
+		configBytes []byte
+		err         error
+	)
+	switch {
+	case v.IsSet(LogSinksConfigContentKey):
+		rawContent := v.GetString(LogSinksConfigContentKey)
+		configBytes, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(LogSinksConfigFileKey):
+		configFilepath := GetExpandedArg(v, LogSinksConfigFileKey)
+		configBytes, err = os.ReadFile(filepath.Clean(configFilepath))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+
+	var rawSinks []json.RawMessage
+	if err := json.Unmarshal(configBytes, &rawSinks); err != nil {
+		return nil, fmt.Errorf("%w: %w", errUnmarshalling, err)
+	}
+	sinks := make([]logging.SinkConfig, len(rawSinks))
+	for i, rawSink := range rawSinks {
+		sinks[i].Level = defaultLevel
+		if err := json.Unmarshal(rawSink, &sinks[i]); err != nil {
+			return nil, fmt.Errorf("%w: %w", errUnmarshalling, err)
+		}
+		if err := sinks[i].Verify(); err != nil {
+			return nil, fmt.Errorf("%w %d: %w", errInvalidLogSink, i, err)
+		}
+	}
+	return sinks, nil
+}
+
 func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 	var (
 		httpsKey  []byte
@@ -175,6 +224,36 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		}
 	}
 
+	var httpsClientCA []byte
+	switch {
+	case v.IsSet(HTTPSClientCAContentKey):
+		rawContent := v.GetString(HTTPSClientCAContentKey)
+		httpsClientCA, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return node.HTTPConfig{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(HTTPSClientCAFileKey):
+		httpsClientCAFilepath := GetExpandedArg(v, HTTPSClientCAFileKey)
+		httpsClientCA, err = os.ReadFile(filepath.Clean(httpsClientCAFilepath))
+		if err != nil {
+			return node.HTTPConfig{}, err
+		}
+	}
+	httpsEnabled := v.GetBool(HTTPSEnabledKey)
+	if len(httpsClientCA) > 0 && !httpsEnabled {
+		return node.HTTPConfig{}, errHTTPSClientCAWithoutHTTPS
+	}
+
+	apiAuthConfig, err := getAPIAuthConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+
+	apiRateLimitConfig, err := getAPIRateLimitConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+
 	return node.HTTPConfig{
 		HTTPConfig: server.HTTPConfig{
 			ReadTimeout:       v.GetDuration(HTTPReadTimeoutKey),
@@ -184,8 +263,10 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		},
 		APIConfig: node.APIConfig{
 			APIIndexerConfig: node.APIIndexerConfig{
-				IndexAPIEnabled:      v.GetBool(IndexEnabledKey),
-				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
+				IndexAPIEnabled:         v.GetBool(IndexEnabledKey),
+				IndexAllowIncomplete:    v.GetBool(IndexAllowIncompleteKey),
+				IndexStartHeight:        v.GetUint64(IndexStartHeightKey),
+				IndexRetainedContainers: v.GetUint64(IndexRetainedContainersKey),
 			},
 			AdminAPIEnabled:    v.GetBool(AdminAPIEnabledKey),
 			InfoAPIEnabled:     v.GetBool(InfoAPIEnabledKey),
@@ -195,9 +276,12 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		},
 		HTTPHost:           v.GetString(HTTPHostKey),
 		HTTPPort:           uint16(v.GetUint(HTTPPortKey)),
-		HTTPSEnabled:       v.GetBool(HTTPSEnabledKey),
+		HTTPSEnabled:       httpsEnabled,
 		HTTPSKey:           httpsKey,
 		HTTPSCert:          httpsCert,
+		HTTPSClientCA:      httpsClientCA,
+		APIAuthConfig:      apiAuthConfig,
+		APIRateLimitConfig: apiRateLimitConfig,
 		HTTPAllowedOrigins: v.GetStringSlice(HTTPAllowedOrigins),
 		HTTPAllowedHosts:   v.GetStringSlice(HTTPAllowedHostsKey),
 		ShutdownTimeout:    v.GetDuration(HTTPShutdownTimeoutKey),
@@ -205,6 +289,87 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 	}, nil
 }
 
+// getAPIAuthConfig returns nil if API authentication is disabled.
+func getAPIAuthConfig(v *viper.Viper) (*server.AuthConfig, error) {
+	if !v.GetBool(APIAuthEnabledKey) {
+		return nil, nil
+	}
+
+	var (
+		configBytes []byte
+		err         error
+	)
+	switch {
+	case v.IsSet(APIAuthConfigContentKey):
+		rawContent := v.GetString(APIAuthConfigContentKey)
+		configBytes, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(APIAuthConfigFileKey):
+		configFilepath := GetExpandedArg(v, APIAuthConfigFileKey)
+		configBytes, err = os.ReadFile(filepath.Clean(configFilepath))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errAPIAuthConfigUnset
+	}
+
+	config := &server.AuthConfig{}
+	if err := json.Unmarshal(configBytes, config); err != nil {
+		return nil, fmt.Errorf("%w: %w", errUnmarshalling, err)
+	}
+	return config, nil
+}
+
+// getAPIRateLimitConfig returns nil if API rate limiting is disabled.
+func getAPIRateLimitConfig(v *viper.Viper) (*server.RateLimitConfig, error) {
+	if !v.GetBool(APIRateLimitEnabledKey) {
+		return nil, nil
+	}
+
+	config := &server.RateLimitConfig{
+		Default: server.RateLimit{
+			RequestsPerSecond: v.GetFloat64(APIRateLimitRequestsPerSecondKey),
+			Burst:             int(v.GetUint(APIRateLimitBurstKey)),
+		},
+		MaxBodySize: int64(v.GetUint64(APIRateLimitMaxBodySizeKey)),
+	}
+	switch {
+	case config.Default.RequestsPerSecond < 0:
+		return nil, fmt.Errorf("%s must be >= 0", APIRateLimitRequestsPerSecondKey)
+	case config.MaxBodySize <= 0:
+		return nil, errInvalidAPIRateLimitMaxBodySize
+	}
+
+	var (
+		routesBytes []byte
+		err         error
+	)
+	switch {
+	case v.IsSet(APIRateLimitRoutesContentKey):
+		rawContent := v.GetString(APIRateLimitRoutesContentKey)
+		routesBytes, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(APIRateLimitRoutesFileKey):
+		routesFilepath := GetExpandedArg(v, APIRateLimitRoutesFileKey)
+		routesBytes, err = os.ReadFile(filepath.Clean(routesFilepath))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return config, nil
+	}
+
+	if err := json.Unmarshal(routesBytes, &config.Routes); err != nil {
+		return nil, fmt.Errorf("%w: %w", errUnmarshalling, err)
+	}
+	return config, nil
+}
+
 func getRouterHealthConfig(v *viper.Viper, halflife time.Duration) (router.HealthConfig, error) {
 	config := router.HealthConfig{
 		MaxDropRate:            v.GetFloat64(RouterHealthMaxDropRateKey),
@@ -1163,6 +1328,9 @@ func getTraceConfig(v *viper.Viper) (trace.Config, error) {
 	if endpoint == "" {
 		return trace.Config{}, errTracingEndpointEmpty
 	}
+	if exporterType == trace.File {
+		endpoint = GetExpandedArg(v, TracingEndpointKey)
+	}
 
 	return trace.Config{
 		ExporterConfig: trace.ExporterConfig{
@@ -16,10 +16,12 @@ import (
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
 func TestGetChainConfigsFromFiles(t *testing.T) {
@@ -539,6 +541,197 @@ func TestGetSubnetConfigsFromFlags(t *testing.T) {
 	}
 }
 
+func TestGetAPIAuthConfig(t *testing.T) {
+	tests := map[string]struct {
+		enabled     bool
+		givenJSON   string
+		expected    *server.AuthConfig
+		expectedErr error
+	}{
+		"disabled": {
+			givenJSON: `{"defaultRole": "admin"}`,
+			expected:  nil,
+		},
+		"no config": {
+			enabled:     true,
+			expectedErr: errAPIAuthConfigUnset,
+		},
+		"invalid config": {
+			enabled:     true,
+			givenJSON:   `{"tokens": ["admin"]}`,
+			expectedErr: errUnmarshalling,
+		},
+		"config": {
+			enabled: true,
+			givenJSON: `{
+				"tokens": {"token": ["admin"]},
+				"clientCertificates": {"client": ["admin", "user"]},
+				"routes": {"admin": "admin", "info": "public"},
+				"defaultRole": "user"
+			}`,
+			expected: &server.AuthConfig{
+				Tokens: map[string][]string{
+					"token": {"admin"},
+				},
+				ClientCertificates: map[string][]string{
+					"client": {"admin", "user"},
+				},
+				Routes: map[string]string{
+					"admin": "admin",
+					"info":  server.PublicRole,
+				},
+				DefaultRole: "user",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(APIAuthEnabledKey, test.enabled)
+			if test.givenJSON != "" {
+				v.Set(APIAuthConfigContentKey, base64.StdEncoding.EncodeToString([]byte(test.givenJSON)))
+			}
+
+			config, err := getAPIAuthConfig(v)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, config)
+		})
+	}
+}
+
+func TestGetLogSinksConfig(t *testing.T) {
+	tests := map[string]struct {
+		givenJSON   string
+		expected    []logging.SinkConfig
+		expectedErr error
+	}{
+		"no config": {
+			expected: nil,
+		},
+		"invalid config": {
+			givenJSON:   `{"type": "syslog"}`,
+			expectedErr: errUnmarshalling,
+		},
+		"invalid sink": {
+			givenJSON:   `[{"type": "syslog", "network": "http", "address": "127.0.0.1:514"}]`,
+			expectedErr: errInvalidLogSink,
+		},
+		"config": {
+			givenJSON: `[
+				{"type": "syslog", "network": "udp", "address": "127.0.0.1:514", "format": "json", "loggers": ["main", "P"], "facility": 16},
+				{"type": "json-tcp", "address": "127.0.0.1:5170", "level": "debug"}
+			]`,
+			expected: []logging.SinkConfig{
+				{
+					Type:     logging.SyslogSink,
+					Network:  "udp",
+					Address:  "127.0.0.1:514",
+					Level:    logging.Warn,
+					Format:   logging.JSON,
+					Loggers:  []string{"main", "P"},
+					Facility: 16,
+				},
+				{
+					Type:    logging.JSONTCPSink,
+					Address: "127.0.0.1:5170",
+					Level:   logging.Debug,
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			if test.givenJSON != "" {
+				v.Set(LogSinksConfigContentKey, base64.StdEncoding.EncodeToString([]byte(test.givenJSON)))
+			}
+
+			config, err := getLogSinksConfig(v, logging.Warn)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, config)
+		})
+	}
+}
+
+func TestGetAPIRateLimitConfig(t *testing.T) {
+	tests := map[string]struct {
+		enabled     bool
+		maxBodySize uint64
+		givenJSON   string
+		expected    *server.RateLimitConfig
+		expectedErr error
+	}{
+		"disabled": {
+			maxBodySize: server.DefaultMaxBodySize,
+			givenJSON:   `{"bc/X": {"requestsPerSecond": 1}}`,
+			expected:    nil,
+		},
+		"no routes": {
+			enabled:     true,
+			maxBodySize: server.DefaultMaxBodySize,
+			expected: &server.RateLimitConfig{
+				Default: server.RateLimit{
+					RequestsPerSecond: 50,
+					Burst:             100,
+				},
+				MaxBodySize: server.DefaultMaxBodySize,
+			},
+		},
+		"invalid max body size": {
+			enabled:     true,
+			maxBodySize: 0,
+			expectedErr: errInvalidAPIRateLimitMaxBodySize,
+		},
+		"invalid routes": {
+			enabled:     true,
+			maxBodySize: server.DefaultMaxBodySize,
+			givenJSON:   `["bc/X"]`,
+			expectedErr: errUnmarshalling,
+		},
+		"routes": {
+			enabled:     true,
+			maxBodySize: server.DefaultMaxBodySize,
+			givenJSON:   `{"bc/X:avm.getUTXOs": {"requestsPerSecond": 1, "burst": 5}}`,
+			expected: &server.RateLimitConfig{
+				Default: server.RateLimit{
+					RequestsPerSecond: 50,
+					Burst:             100,
+				},
+				Routes: map[string]server.RateLimit{
+					"bc/X:avm.getUTXOs": {
+						RequestsPerSecond: 1,
+						Burst:             5,
+					},
+				},
+				MaxBodySize: server.DefaultMaxBodySize,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(APIRateLimitEnabledKey, test.enabled)
+			v.Set(APIRateLimitMaxBodySizeKey, test.maxBodySize)
+			if test.givenJSON != "" {
+				v.Set(APIRateLimitRoutesContentKey, base64.StdEncoding.EncodeToString([]byte(test.givenJSON)))
+			}
+
+			config, err := getAPIRateLimitConfig(v)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, config)
+		})
+	}
+}
+
 // setups config json file and writes content
 func setupConfigJSON(t *testing.T, rootPath string, value string) string {
 	configFilePath := filepath.Join(rootPath, "config.json")
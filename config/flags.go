@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/pebble"
@@ -125,6 +126,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint(LogRotaterMaxAgeKey, 0, "The maximum number of days to retain old log files based on the timestamp encoded in their filename. 0 means retain all old log files.")
 	fs.Bool(LogRotaterCompressEnabledKey, false, "Enables the compression of rotated log files through gzip.")
 	fs.Bool(LogDisableDisplayPluginLogsKey, false, "Disables displaying plugin logs in stdout.")
+	fs.String(LogSinksConfigFileKey, "", fmt.Sprintf("JSON file that specifies syslog and JSON TCP outputs that logs are shipped to, in addition to the log files. Sinks that don't specify a level use the value of %s. Ignored if %s is specified", LogLevelKey, LogSinksConfigContentKey))
+	fs.String(LogSinksConfigContentKey, "", "Specifies base64 encoded log sinks config")
 
 	// Peer List Gossip
 	fs.Uint(NetworkPeerListNumValidatorIPsKey, constants.DefaultNetworkPeerListNumValidatorIPs, "Number of validator IPs to gossip to other nodes")
@@ -153,7 +156,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkPingTimeoutKey, constants.DefaultPingPongTimeout, "Timeout value for Ping-Pong with a peer")
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
 
-	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type for outbound messages. Must be one of [%s, %s]", compression.TypeZstd, compression.TypeNone))
+	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type for outbound messages. Must be one of [%s, %s, %s, %s]. Peers that don't support it are sent %s compressed messages", compression.TypeZstd, compression.TypeLZ4, compression.TypeSnappy, compression.TypeNone, compression.TypeZstd))
 
 	fs.Duration(NetworkMaxClockDifferenceKey, constants.DefaultNetworkMaxClockDifference, "Max allowed clock difference value between this node and peers")
 	// Note: The default value is set to false here because the default
@@ -207,6 +210,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(HTTPSKeyContentKey, "", "Specifies base64 encoded TLS private key for the HTTPs server")
 	fs.String(HTTPSCertFileKey, "", fmt.Sprintf("TLS certificate file for the HTTPs server. Ignored if %s is specified", HTTPSCertContentKey))
 	fs.String(HTTPSCertContentKey, "", "Specifies base64 encoded TLS certificate for the HTTPs server")
+	fs.String(HTTPSClientCAFileKey, "", fmt.Sprintf("PEM encoded certificate authorities file used to verify client TLS certificates presented to the HTTPs server. Ignored if %s is specified", HTTPSClientCAContentKey))
+	fs.String(HTTPSClientCAContentKey, "", "Specifies base64 encoded PEM certificate authorities used to verify client TLS certificates presented to the HTTPs server")
 	fs.String(HTTPAllowedOrigins, "*", "Origins to allow on the HTTP port. Defaults to * which allows all origins. Example: https://*.avax.network https://*.avax-test.network")
 	fs.StringSlice(HTTPAllowedHostsKey, []string{"localhost"}, "List of acceptable host names in API requests. Provide the wildcard ('*') to accept requests from all hosts. API requests where the Host field is empty or an IP address will always be accepted. An API call whose HTTP Host field isn't acceptable will receive a 403 error code")
 	fs.Duration(HTTPShutdownWaitKey, 0, "Duration to wait after receiving SIGTERM or SIGINT before initiating shutdown. The /health endpoint will return unhealthy during this duration")
@@ -223,6 +228,19 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(MetricsAPIEnabledKey, true, "If true, this node exposes the Metrics API")
 	fs.Bool(HealthAPIEnabledKey, true, "If true, this node exposes the Health API")
 
+	// API Authentication
+	fs.Bool(APIAuthEnabledKey, false, fmt.Sprintf("If true, API calls must be authenticated with a bearer token or a client TLS certificate, and have the role required by the route they call. Roles are configured by %s", APIAuthConfigFileKey))
+	fs.String(APIAuthConfigFileKey, "", fmt.Sprintf("JSON file that specifies the roles granted to bearer tokens and client TLS certificates, and the role required by each route. Ignored if %s is specified", APIAuthConfigContentKey))
+	fs.String(APIAuthConfigContentKey, "", "Specifies base64 encoded API authentication config")
+
+	// API Rate Limiting
+	fs.Bool(APIRateLimitEnabledKey, false, "If true, API calls are rate limited per caller. Callers are identified by their API authentication token if they provide a known one, and by their IP otherwise. Each caller has a separate budget for each route and JSON-RPC method")
+	fs.Float64(APIRateLimitRequestsPerSecondKey, 50, fmt.Sprintf("Rate, in calls per second, at which each caller's budget for a route and method refills, unless overridden by %s. If 0, calls aren't rate limited", APIRateLimitRoutesFileKey))
+	fs.Uint(APIRateLimitBurstKey, 100, fmt.Sprintf("Maximum number of calls each caller may make at once to a route and method, unless overridden by %s", APIRateLimitRoutesFileKey))
+	fs.String(APIRateLimitRoutesFileKey, "", fmt.Sprintf("JSON file that maps routes, or routes and methods such as \"bc/X:avm.getUTXOs\", to the rate limits of their callers. Ignored if %s is specified", APIRateLimitRoutesContentKey))
+	fs.String(APIRateLimitRoutesContentKey, "", "Specifies base64 encoded API rate limit routes")
+	fs.Uint64(APIRateLimitMaxBodySizeKey, server.DefaultMaxBodySize, "Maximum size, in bytes, of the body of an API call to a route that is rate limited, or that has methods that are rate limited. Calls with larger bodies are rejected")
+
 	// Health Checks
 	fs.Duration(HealthCheckFreqKey, 30*time.Second, "Time between health checks")
 	fs.Duration(HealthCheckAveragerHalflifeKey, constants.DefaultHealthCheckAveragerHalflife, "Halflife of averager when calculating a running average in a health check")
@@ -311,6 +329,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Indexer
 	fs.Bool(IndexEnabledKey, false, "If true, index all accepted containers and transactions and expose them via an API")
 	fs.Bool(IndexAllowIncompleteKey, false, "If true, allow running the node in such a way that could cause an index to miss transactions. Ignored if index is disabled")
+	fs.Uint64(IndexStartHeightKey, 0, "Blocks with a height below this height are not stored by the block indexes. Vertex and transaction indexes are not affected. Ignored if index is disabled")
+	fs.Uint64(IndexRetainedContainersKey, 0, "If non-zero, only this many of the most recently accepted containers are stored by each index. Ignored if index is disabled")
 
 	// Config Directories
 	fs.String(ChainConfigDirKey, defaultChainConfigDir, fmt.Sprintf("Chain specific configurations parent directory. Ignored if %s is specified", ChainConfigContentKey))
@@ -357,8 +377,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 
 	// Opentelemetry tracing
 	fs.Bool(TracingEnabledKey, false, "If true, enable opentelemetry tracing")
-	fs.String(TracingExporterTypeKey, trace.GRPC.String(), fmt.Sprintf("Type of exporter to use for tracing. Options are [%s, %s]", trace.GRPC, trace.HTTP))
-	fs.String(TracingEndpointKey, "localhost:4317", "The endpoint to send trace data to")
+	fs.String(TracingExporterTypeKey, trace.GRPC.String(), fmt.Sprintf("Type of exporter to use for tracing. Options are [%s, %s, %s, %s]", trace.GRPC, trace.HTTP, trace.File, trace.Zipkin))
+	fs.String(TracingEndpointKey, "localhost:4317", fmt.Sprintf("The endpoint to send trace data to. If %s is %s, the path of the file that spans are appended to as JSON lines. If %s is %s, either the address of the collector or the URL that spans are posted to", TracingExporterTypeKey, trace.File, TracingExporterTypeKey, trace.Zipkin))
 	fs.Bool(TracingInsecureKey, true, "If true, don't use TLS when sending trace data")
 	fs.Float64(TracingSampleRateKey, 0.1, "The fraction of traces to sample. If >= 1, always sample. If <= 0, never sample")
 	fs.StringToString(TracingHeadersKey, map[string]string{}, "The headers to provide the trace indexer")
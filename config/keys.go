@@ -50,6 +50,8 @@ const (
 	HTTPSKeyContentKey                                 = "http-tls-key-file-content"
 	HTTPSCertFileKey                                   = "http-tls-cert-file"
 	HTTPSCertContentKey                                = "http-tls-cert-file-content"
+	HTTPSClientCAFileKey                               = "http-tls-client-ca-file"
+	HTTPSClientCAContentKey                            = "http-tls-client-ca-file-content"
 	HTTPAllowedOrigins                                 = "http-allowed-origins"
 	HTTPAllowedHostsKey                                = "http-allowed-hosts"
 	HTTPShutdownTimeoutKey                             = "http-shutdown-timeout"
@@ -119,6 +121,8 @@ const (
 	LogRotaterMaxAgeKey                                = "log-rotater-max-age"
 	LogRotaterCompressEnabledKey                       = "log-rotater-compress-enabled"
 	LogDisableDisplayPluginLogsKey                     = "log-disable-display-plugin-logs"
+	LogSinksConfigFileKey                              = "log-sinks-config-file"
+	LogSinksConfigContentKey                           = "log-sinks-config-file-content"
 	SnowSampleSizeKey                                  = "snow-sample-size"
 	SnowQuorumSizeKey                                  = "snow-quorum-size"
 	SnowPreferenceQuorumSizeKey                        = "snow-preference-quorum-size"
@@ -137,6 +141,15 @@ const (
 	KeystoreAPIEnabledKey                              = "api-keystore-enabled"
 	MetricsAPIEnabledKey                               = "api-metrics-enabled"
 	HealthAPIEnabledKey                                = "api-health-enabled"
+	APIAuthEnabledKey                                  = "api-auth-enabled"
+	APIAuthConfigFileKey                               = "api-auth-config-file"
+	APIAuthConfigContentKey                            = "api-auth-config-file-content"
+	APIRateLimitEnabledKey                             = "api-rate-limit-enabled"
+	APIRateLimitRequestsPerSecondKey                   = "api-rate-limit-requests-per-second"
+	APIRateLimitBurstKey                               = "api-rate-limit-burst"
+	APIRateLimitRoutesFileKey                          = "api-rate-limit-routes-file"
+	APIRateLimitRoutesContentKey                       = "api-rate-limit-routes-file-content"
+	APIRateLimitMaxBodySizeKey                         = "api-rate-limit-max-body-size"
 	MeterVMsEnabledKey                                 = "meter-vms-enabled"
 	ConsensusAppConcurrencyKey                         = "consensus-app-concurrency"
 	ConsensusShutdownTimeoutKey                        = "consensus-shutdown-timeout"
@@ -145,6 +158,8 @@ const (
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
+	IndexStartHeightKey                                = "index-start-height"
+	IndexRetainedContainersKey                         = "index-retained-containers"
 	RouterHealthMaxDropRateKey                         = "router-health-max-drop-rate"
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+
+	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	// reloadableKeys can be changed without restarting the node. Changes to
+	// these keys are applied by [node.Node.ReloadConfig].
+	reloadableKeys = set.Of(
+		LogLevelKey,
+		LogDisplayLevelKey,
+		HTTPAllowedHostsKey,
+		NetworkHealthMinPeersKey,
+		NetworkHealthMaxTimeSinceMsgReceivedKey,
+		NetworkHealthMaxTimeSinceMsgSentKey,
+		NetworkHealthMaxSendFailRateKey,
+		InboundThrottlerBandwidthRefillRateKey,
+		InboundThrottlerBandwidthMaxBurstSizeKey,
+	)
+
+	// tracingKeys can be changed without restarting the node, as long as
+	// tracing is enabled both before and after the change. The tracer isn't
+	// reloaded while tracing is disabled.
+	tracingKeys = set.Of(
+		TracingEndpointKey,
+		TracingInsecureKey,
+		TracingSampleRateKey,
+		TracingExporterTypeKey,
+		TracingHeadersKey,
+	)
+)
+
+type configLoader struct {
+	args []string
+
+	lock sync.Mutex
+	// current is the config that the node is running with. Reloaded keys are
+	// overridden with their new value.
+	current *viper.Viper
+}
+
+// NewConfigLoader returns a loader that re-reads the config of a node that was
+// started with the command line arguments [args], whose config was parsed into
+// [v].
+func NewConfigLoader(args []string, v *viper.Viper) node.ConfigLoader {
+	l := &configLoader{
+		args:    args,
+		current: v,
+	}
+	return l.load
+}
+
+func (l *configLoader) load() (node.Config, *node.ConfigReload, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	v, err := BuildViper(BuildFlagSet(), l.args)
+	if err != nil {
+		return node.Config{}, nil, err
+	}
+	config, err := GetNodeConfig(v)
+	if err != nil {
+		return node.Config{}, nil, err
+	}
+
+	var (
+		changes        = diffViper(l.current, v)
+		tracingEnabled = l.current.GetBool(TracingEnabledKey) && v.GetBool(TracingEnabledKey)
+		reload         = &node.ConfigReload{}
+	)
+	for _, change := range changes {
+		if !reloadableKeys.Contains(change.Key) && (!tracingEnabled || !tracingKeys.Contains(change.Key)) {
+			reload.RequiresRestart = append(reload.RequiresRestart, change)
+			continue
+		}
+
+		reload.Applied = append(reload.Applied, change)
+		l.current.Set(change.Key, v.Get(change.Key))
+	}
+	return config, reload, nil
+}
+
+// diffViper returns the top level keys whose values differ between [oldV] and
+// [newV], sorted by key.
+func diffViper(oldV, newV *viper.Viper) []node.ConfigChange {
+	keys := set.NewSet[string](len(oldV.AllKeys()))
+	for _, key := range append(oldV.AllKeys(), newV.AllKeys()...) {
+		// Nested keys, such as the entries of a map, are compared as part of
+		// their top level key.
+		key, _, _ = strings.Cut(key, ".")
+		keys.Add(key)
+	}
+
+	sortedKeys := keys.List()
+	slices.Sort(sortedKeys)
+
+	var changes []node.ConfigChange
+	for _, key := range sortedKeys {
+		// Values are compared by their string representation because values
+		// read from a flag and from a config file may have different types.
+		oldValue := fmt.Sprint(oldV.Get(key))
+		newValue := fmt.Sprint(newV.Get(key))
+		if oldValue == newValue {
+			continue
+		}
+		changes = append(changes, node.ConfigChange{
+			Key: key,
+			Old: oldValue,
+			New: newValue,
+		})
+	}
+	return changes
+}
@@ -0,0 +1,207 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+func TestConfigLoader(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	configFilePath := filepath.Join(dataDir, "config.json")
+	require.NoError(os.WriteFile(configFilePath, []byte(`{
+		"log-level": "info",
+		"http-port": 9650
+	}`), perms.ReadWrite))
+
+	args := newConfigLoaderTestArgs(dataDir, configFilePath)
+	v, err := BuildViper(BuildFlagSet(), args)
+	require.NoError(err)
+	_, err = GetNodeConfig(v)
+	require.NoError(err)
+
+	load := NewConfigLoader(args, v)
+
+	// Nothing changed
+	_, reload, err := load()
+	require.NoError(err)
+	require.Equal(&node.ConfigReload{}, reload)
+
+	require.NoError(os.WriteFile(configFilePath, []byte(`{
+		"log-level": "debug",
+		"http-port": 9652,
+		"network-health-max-time-since-msg-received": "2m"
+	}`), perms.ReadWrite))
+
+	config, reload, err := load()
+	require.NoError(err)
+	require.Equal(logging.Debug, config.LoggingConfig.LogLevel)
+	require.Equal(2*time.Minute, config.NetworkConfig.HealthConfig.MaxTimeSinceMsgReceived)
+	require.Equal(
+		&node.ConfigReload{
+			Applied: []node.ConfigChange{
+				{
+					Key: LogLevelKey,
+					Old: "info",
+					New: "debug",
+				},
+				{
+					Key: NetworkHealthMaxTimeSinceMsgReceivedKey,
+					Old: "1m0s",
+					New: "2m",
+				},
+			},
+			RequiresRestart: []node.ConfigChange{
+				{
+					Key: HTTPPortKey,
+					Old: "9650",
+					New: "9652",
+				},
+			},
+		},
+		reload,
+	)
+
+	// Applied changes aren't reported again, but changes that require a
+	// restart are.
+	_, reload, err = load()
+	require.NoError(err)
+	require.Empty(reload.Applied)
+	require.Len(reload.RequiresRestart, 1)
+
+	// Invalid configs aren't applied
+	require.NoError(os.WriteFile(configFilePath, []byte(`{
+		"log-level": "unknown"
+	}`), perms.ReadWrite))
+
+	_, _, err = load()
+	require.ErrorIs(err, logging.ErrUnknownLevel)
+}
+
+func TestConfigLoaderTracingToggled(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	configFilePath := filepath.Join(dataDir, "config.json")
+	require.NoError(os.WriteFile(configFilePath, []byte(`{}`), perms.ReadWrite))
+
+	args := newConfigLoaderTestArgs(dataDir, configFilePath)
+	v, err := BuildViper(BuildFlagSet(), args)
+	require.NoError(err)
+
+	load := NewConfigLoader(args, v)
+
+	require.NoError(os.WriteFile(configFilePath, []byte(`{
+		"tracing-enabled": true,
+		"tracing-endpoint": "localhost:4318"
+	}`), perms.ReadWrite))
+
+	_, reload, err := load()
+	require.NoError(err)
+	require.Empty(reload.Applied)
+	require.Equal(
+		[]node.ConfigChange{
+			{
+				Key: TracingEnabledKey,
+				Old: "false",
+				New: "true",
+			},
+			{
+				Key: TracingEndpointKey,
+				Old: "localhost:4317",
+				New: "localhost:4318",
+			},
+		},
+		reload.RequiresRestart,
+	)
+}
+
+func TestConfigLoaderTracingKeys(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	configFilePath := filepath.Join(dataDir, "config.json")
+	require.NoError(os.WriteFile(configFilePath, []byte(`{}`), perms.ReadWrite))
+
+	args := newConfigLoaderTestArgs(dataDir, configFilePath)
+	v, err := BuildViper(BuildFlagSet(), args)
+	require.NoError(err)
+
+	load := NewConfigLoader(args, v)
+
+	// The tracer isn't reloaded while tracing is disabled
+	require.NoError(os.WriteFile(configFilePath, []byte(`{
+		"tracing-endpoint": "localhost:4318"
+	}`), perms.ReadWrite))
+
+	_, reload, err := load()
+	require.NoError(err)
+	require.Equal(
+		&node.ConfigReload{
+			RequiresRestart: []node.ConfigChange{
+				{
+					Key: TracingEndpointKey,
+					Old: "localhost:4317",
+					New: "localhost:4318",
+				},
+			},
+		},
+		reload,
+	)
+
+	// The tracer is reloaded while tracing is enabled
+	require.NoError(os.WriteFile(configFilePath, []byte(`{
+		"tracing-enabled": true
+	}`), perms.ReadWrite))
+
+	args = newConfigLoaderTestArgs(dataDir, configFilePath)
+	v, err = BuildViper(BuildFlagSet(), args)
+	require.NoError(err)
+
+	load = NewConfigLoader(args, v)
+
+	require.NoError(os.WriteFile(configFilePath, []byte(`{
+		"tracing-enabled": true,
+		"tracing-endpoint": "localhost:4318"
+	}`), perms.ReadWrite))
+
+	_, reload, err = load()
+	require.NoError(err)
+	require.Equal(
+		&node.ConfigReload{
+			Applied: []node.ConfigChange{
+				{
+					Key: TracingEndpointKey,
+					Old: "localhost:4317",
+					New: "localhost:4318",
+				},
+			},
+		},
+		reload,
+	)
+}
+
+// newConfigLoaderTestArgs returns the arguments of a node that uses the local
+// staking keys, so that keys aren't generated by every test.
+func newConfigLoaderTestArgs(dataDir, configFilePath string) []string {
+	stakingDir := filepath.Join("..", "staking", "local")
+	return []string{
+		"--" + DataDirKey + "=" + dataDir,
+		"--" + ConfigFileKey + "=" + configFilePath,
+		"--" + StakingTLSKeyPathKey + "=" + filepath.Join(stakingDir, "staker1.key"),
+		"--" + StakingCertPathKey + "=" + filepath.Join(stakingDir, "staker1.crt"),
+		"--" + StakingSignerKeyPathKey + "=" + filepath.Join(stakingDir, "signer1.key"),
+	}
+}
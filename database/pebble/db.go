@@ -12,6 +12,7 @@ import (
 	"sync"
 
 	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
@@ -27,6 +28,9 @@ const (
 	// pebbleByteOverHead is the number of bytes of constant overhead that
 	// should be added to a batch size per operation.
 	pebbleByteOverHead = 8
+
+	// numLevels is the number of levels in the LSM tree.
+	numLevels = 7
 )
 
 var (
@@ -71,6 +75,9 @@ type Config struct {
 	MemTableSize                int `json:"memTableSize"`
 	MaxOpenFiles                int `json:"maxOpenFiles"`
 	MaxConcurrentCompactions    int `json:"maxConcurrentCompactions"`
+	L0CompactionThreshold       int `json:"l0CompactionThreshold"` // 0 means the pebble default
+	L0StopWritesThreshold       int `json:"l0StopWritesThreshold"` // 0 means the pebble default
+	BloomFilterBitsPerKey       int `json:"bloomFilterBitsPerKey"` // 0 means no bloom filters
 }
 
 // TODO: Add metrics
@@ -91,8 +98,17 @@ func New(file string, configBytes []byte, log logging.Logger, _ string, _ promet
 		MemTableSize:                cfg.MemTableSize,
 		MaxOpenFiles:                cfg.MaxOpenFiles,
 		MaxConcurrentCompactions:    func() int { return cfg.MaxConcurrentCompactions },
+		L0CompactionThreshold:       cfg.L0CompactionThreshold,
+		L0StopWritesThreshold:       cfg.L0StopWritesThreshold,
 	}
 	opts.Experimental.ReadSamplingMultiplier = -1 // Disable seek compaction
+	if cfg.BloomFilterBitsPerKey > 0 {
+		opts.Levels = make([]pebble.LevelOptions, numLevels)
+		for i := range opts.Levels {
+			opts.Levels[i].FilterPolicy = bloom.FilterPolicy(cfg.BloomFilterBitsPerKey)
+			opts.Levels[i].EnsureDefaults()
+		}
+	}
 
 	log.Info(
 		"opening pebble",
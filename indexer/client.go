@@ -5,7 +5,12 @@ package indexer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/websocket"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -13,7 +18,13 @@ import (
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
 
-var _ Client = (*client)(nil)
+var (
+	errSubscriptionFailed = errors.New("subscription failed")
+	errUnexpectedIndex    = errors.New("unexpected index")
+	errInvalidScheme      = errors.New("invalid scheme")
+
+	_ Client = (*client)(nil)
+)
 
 // Client interface for Avalanche Indexer API Endpoint
 type Client interface {
@@ -32,10 +43,18 @@ type Client interface {
 	IsAccepted(ctx context.Context, containerID ids.ID, options ...rpc.Option) (bool, error)
 	// Get a container and its index by its ID
 	GetContainerByID(ctx context.Context, containerID ids.ID, options ...rpc.Option) (Container, uint64, error)
+	// Subscribe calls [onContainer] with every container, and its index,
+	// accepted at index [startIndex] and after, in order. Subscribe returns
+	// once [ctx] is cancelled, the connection fails or [onContainer] returns an
+	// error. The index of the next container that would have been passed to
+	// [onContainer] is returned so that the subscription can be resumed
+	// without missing any containers.
+	Subscribe(ctx context.Context, startIndex uint64, onContainer func(Container, uint64) error) (uint64, error)
 }
 
 // Client implementation for Avalanche Indexer API Endpoint
 type client struct {
+	uri       string
 	requester rpc.EndpointRequester
 }
 
@@ -47,6 +66,7 @@ type client struct {
 //   - http://1.2.3.4:9650/ext/index/X/tx
 func NewClient(uri string) Client {
 	return &client{
+		uri:       uri,
 		requester: rpc.NewEndpointRequester(uri),
 	}
 }
@@ -154,3 +174,73 @@ func (c *client) GetContainerByID(ctx context.Context, id ids.ID, options ...rpc
 		Bytes:     containerBytes,
 	}, uint64(fc.Index), nil
 }
+
+func (c *client) Subscribe(ctx context.Context, startIndex uint64, onContainer func(Container, uint64) error) (uint64, error) {
+	subscribeURL, err := url.Parse(c.uri)
+	if err != nil {
+		return startIndex, err
+	}
+	switch subscribeURL.Scheme {
+	case "http":
+		subscribeURL.Scheme = "ws"
+	case "https":
+		subscribeURL.Scheme = "wss"
+	default:
+		return startIndex, fmt.Errorf("%w: %q", errInvalidScheme, subscribeURL.Scheme)
+	}
+	subscribeURL.Path += subscribeEndpoint
+	subscribeURL.RawQuery = url.Values{
+		startIndexParam: []string{strconv.FormatUint(startIndex, 10)},
+		encodingParam:   []string{formatting.Hex.String()},
+	}.Encode()
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, subscribeURL.String(), nil)
+	if err != nil {
+		return startIndex, err
+	}
+	_ = resp.Body.Close()
+	defer conn.Close()
+
+	// Reading from the connection doesn't respect [ctx], so the connection is
+	// closed once [ctx] is cancelled.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	nextIndex := startIndex
+	for {
+		var msg subscriptionMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nextIndex, ctxErr
+			}
+			return nextIndex, err
+		}
+		if msg.Error != "" {
+			return nextIndex, fmt.Errorf("%w: %s", errSubscriptionFailed, msg.Error)
+		}
+		if uint64(msg.Index) != nextIndex {
+			return nextIndex, fmt.Errorf("%w: expected %d but got %d", errUnexpectedIndex, nextIndex, msg.Index)
+		}
+
+		containerBytes, err := formatting.Decode(msg.Encoding, msg.Bytes)
+		if err != nil {
+			return nextIndex, fmt.Errorf("couldn't decode container %s: %w", msg.ID, err)
+		}
+		container := Container{
+			ID:        msg.ID,
+			Timestamp: msg.Timestamp.Unix(),
+			Bytes:     containerBytes,
+		}
+		if err := onContainer(container, nextIndex); err != nil {
+			return nextIndex, err
+		}
+		nextIndex++
+	}
+}
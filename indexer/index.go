@@ -24,14 +24,20 @@ import (
 // GetContainerRange
 const MaxFetchedByRange = 1024
 
+// Maximum number of containers that are pruned during a single call to Accept.
+// Bounds the amount of work done when the retention window is reduced.
+const maxPrunedPerAccept = 1024
+
 var (
 	// Maps to the byte representation of the next accepted index
 	nextAcceptedIndexKey   = []byte{0x00}
 	indexToContainerPrefix = []byte{0x01}
 	containerToIDPrefix    = []byte{0x02}
+	firstIndexKey          = []byte{0x03}
 	errNoneAccepted        = errors.New("no containers have been accepted")
 	errNumToFetchInvalid   = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
 	errNoContainerAtIndex  = errors.New("no container at index")
+	errContainerPruned     = errors.New("container has been pruned")
 
 	_ snow.Acceptor = (*index)(nil)
 )
@@ -46,6 +52,17 @@ type index struct {
 	lock  sync.RWMutex
 	// The index of the next accepted transaction
 	nextAcceptedIndex uint64
+	// If non-nil, returns the height of a container. Only set for block
+	// indexes.
+	parseHeight func(containerBytes []byte) (uint64, error)
+	// If [parseHeight] is non-nil, containers with a height below
+	// [startHeight] are not stored
+	startHeight uint64
+	// If non-zero, the number of most recently accepted containers to store
+	retainedContainers uint64
+	// Containers before [firstIndex] have been pruned. Their IDs are still
+	// mapped to their index.
+	firstIndex uint64
 	// When [baseDB] is committed, writes to [baseDB]
 	vDB    *versiondb.Database
 	baseDB database.Database
@@ -55,27 +72,51 @@ type index struct {
 	// Container ID --> Index
 	containerToIndex database.Database
 	log              logging.Logger
+	// Closed, and replaced, every time a container is accepted. Used to notify
+	// subscribers of newly accepted containers.
+	accepted chan struct{}
+	closed   bool
 }
 
 // Create a new thread-safe index.
 //
+// If [parseHeight] is non-nil, containers with a height below [startHeight]
+// are not stored. If [retainedContainers] is non-zero, only the
+// [retainedContainers] most recently accepted containers are stored.
+//
 // Invariant: Closes [baseDB] on close.
 func newIndex(
 	baseDB database.Database,
 	log logging.Logger,
 	clock mockable.Clock,
+	parseHeight func(containerBytes []byte) (uint64, error),
+	startHeight uint64,
+	retainedContainers uint64,
 ) (*index, error) {
 	vDB := versiondb.New(baseDB)
 	indexToContainer := prefixdb.New(indexToContainerPrefix, vDB)
 	containerToIndex := prefixdb.New(containerToIDPrefix, vDB)
 
 	i := &index{
-		clock:            clock,
-		baseDB:           baseDB,
-		vDB:              vDB,
-		indexToContainer: indexToContainer,
-		containerToIndex: containerToIndex,
-		log:              log,
+		clock:              clock,
+		baseDB:             baseDB,
+		vDB:                vDB,
+		indexToContainer:   indexToContainer,
+		containerToIndex:   containerToIndex,
+		log:                log,
+		accepted:           make(chan struct{}),
+		parseHeight:        parseHeight,
+		startHeight:        startHeight,
+		retainedContainers: retainedContainers,
+	}
+
+	// Get first index from db
+	firstIndex, err := database.GetUInt64(i.vDB, firstIndexKey)
+	switch {
+	case err == nil:
+		i.firstIndex = firstIndex
+	case err != database.ErrNotFound:
+		return nil, fmt.Errorf("couldn't get first index from database: %w", err)
 	}
 
 	// Get next accepted index from db
@@ -84,6 +125,7 @@ func newIndex(
 		// Couldn't find it in the database. Must not have accepted any containers in previous runs.
 		i.log.Info("created new index",
 			zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
+			zap.Uint64("firstIndex", i.firstIndex),
 		)
 		return i, nil
 	}
@@ -93,12 +135,20 @@ func newIndex(
 	i.nextAcceptedIndex = nextAcceptedIndex
 	i.log.Info("created new index",
 		zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
+		zap.Uint64("firstIndex", i.firstIndex),
 	)
 	return i, nil
 }
 
 // Close this index
 func (i *index) Close() error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if !i.closed {
+		i.closed = true
+		close(i.accepted)
+	}
 	return utils.Err(
 		i.indexToContainer.Close(),
 		i.containerToIndex.Close(),
@@ -133,18 +183,25 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 		zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
 		zap.Stringer("containerID", containerID),
 	)
-	// Persist index --> Container
-	nextAcceptedIndexBytes := database.PackUInt64(i.nextAcceptedIndex)
-	bytes, err := Codec.Marshal(CodecVersion, Container{
-		ID:        containerID,
-		Bytes:     containerBytes,
-		Timestamp: i.clock.Time().UnixNano(),
-	})
+	beforeStartHeight, err := i.isBeforeStartHeight(containerBytes)
 	if err != nil {
-		return fmt.Errorf("couldn't serialize container %s: %w", containerID, err)
+		return fmt.Errorf("couldn't get height of container %s: %w", containerID, err)
 	}
-	if err := i.indexToContainer.Put(nextAcceptedIndexBytes, bytes); err != nil {
-		return fmt.Errorf("couldn't put accepted container %s into index: %w", containerID, err)
+
+	// Persist index --> Container
+	nextAcceptedIndexBytes := database.PackUInt64(i.nextAcceptedIndex)
+	if !beforeStartHeight {
+		bytes, err := Codec.Marshal(CodecVersion, Container{
+			ID:        containerID,
+			Bytes:     containerBytes,
+			Timestamp: i.clock.Time().UnixNano(),
+		})
+		if err != nil {
+			return fmt.Errorf("couldn't serialize container %s: %w", containerID, err)
+		}
+		if err := i.indexToContainer.Put(nextAcceptedIndexBytes, bytes); err != nil {
+			return fmt.Errorf("couldn't put accepted container %s into index: %w", containerID, err)
+		}
 	}
 
 	// Persist container ID --> index
@@ -158,8 +215,29 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 		return fmt.Errorf("couldn't put accepted container %s into index: %w", containerID, err)
 	}
 
+	// Containers below the start height are never stored, so they are
+	// reported as pruned.
+	if beforeStartHeight {
+		i.firstIndex = i.nextAcceptedIndex
+		if err := database.PutUInt64(i.vDB, firstIndexKey, i.firstIndex); err != nil {
+			return fmt.Errorf("couldn't put first index: %w", err)
+		}
+	}
+
+	// Remove the containers that are no longer retained
+	if err := i.prune(); err != nil {
+		return fmt.Errorf("couldn't prune index: %w", err)
+	}
+
 	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex] to [i.baseDB]
-	return i.vDB.Commit()
+	if err := i.vDB.Commit(); err != nil {
+		return err
+	}
+
+	// Notify subscribers that a new container was accepted
+	close(i.accepted)
+	i.accepted = make(chan struct{})
+	return nil
 }
 
 // Returns the ID of the [index]th accepted container and the container itself.
@@ -179,6 +257,9 @@ func (i *index) getContainerByIndex(index uint64) (Container, error) {
 	if !ok || index > lastAcceptedIndex {
 		return Container{}, fmt.Errorf("%w %d", errNoContainerAtIndex, index)
 	}
+	if err := i.verifyNotPruned(index); err != nil {
+		return Container{}, err
+	}
 	indexBytes := database.PackUInt64(index)
 	return i.getContainerByIndexBytes(indexBytes)
 }
@@ -219,6 +300,9 @@ func (i *index) GetContainerRange(startIndex, numToFetch uint64) ([]Container, e
 	} else if startIndex > lastAcceptedIndex {
 		return nil, fmt.Errorf("start index (%d) > last accepted index (%d)", startIndex, lastAcceptedIndex)
 	}
+	if err := i.verifyNotPruned(startIndex); err != nil {
+		return nil, err
+	}
 
 	// Calculate the last index we will fetch
 	lastIndex := min(startIndex+numToFetch-1, lastAcceptedIndex)
@@ -238,12 +322,18 @@ func (i *index) GetContainerRange(startIndex, numToFetch uint64) ([]Container, e
 	return containers, nil
 }
 
-// Returns database.ErrNotFound if the container is not indexed as accepted
+// Returns database.ErrNotFound if the container is not indexed as accepted.
+// Returns errContainerPruned, along with the index, if the container was
+// accepted but has been pruned.
 func (i *index) GetIndex(id ids.ID) (uint64, error) {
 	i.lock.RLock()
 	defer i.lock.RUnlock()
 
-	return database.GetUInt64(i.containerToIndex, id[:])
+	index, err := database.GetUInt64(i.containerToIndex, id[:])
+	if err != nil {
+		return 0, err
+	}
+	return index, i.verifyNotPruned(index)
 }
 
 func (i *index) GetContainerByID(id ids.ID) (Container, error) {
@@ -251,11 +341,14 @@ func (i *index) GetContainerByID(id ids.ID) (Container, error) {
 	defer i.lock.RUnlock()
 
 	// Read index from database
-	indexBytes, err := i.containerToIndex.Get(id[:])
+	index, err := database.GetUInt64(i.containerToIndex, id[:])
 	if err != nil {
 		return Container{}, err
 	}
-	return i.getContainerByIndexBytes(indexBytes)
+	if err := i.verifyNotPruned(index); err != nil {
+		return Container{}, err
+	}
+	return i.getContainerByIndexBytes(database.PackUInt64(index))
 }
 
 // GetLastAccepted returns the last accepted container.
@@ -271,6 +364,69 @@ func (i *index) GetLastAccepted() (Container, error) {
 	return i.getContainerByIndex(lastAcceptedIndex)
 }
 
+// prune removes the containers that are no longer retained.
+//
+// Assumes [i.lock] is held
+func (i *index) prune() error {
+	if i.retainedContainers == 0 || i.nextAcceptedIndex <= i.retainedContainers {
+		return nil
+	}
+	firstIndex := i.nextAcceptedIndex - i.retainedContainers
+	if firstIndex <= i.firstIndex {
+		return nil
+	}
+
+	firstIndex = min(firstIndex, i.firstIndex+maxPrunedPerAccept)
+	for index := i.firstIndex; index < firstIndex; index++ {
+		if err := i.indexToContainer.Delete(database.PackUInt64(index)); err != nil {
+			return err
+		}
+	}
+	i.firstIndex = firstIndex
+	return database.PutUInt64(i.vDB, firstIndexKey, firstIndex)
+}
+
+// isBeforeStartHeight returns true if [containerBytes] has a height below
+// [i.startHeight].
+//
+// Accepted heights only increase, so once a container has been stored, the
+// height of later containers doesn't need to be parsed.
+//
+// Assumes [i.lock] is held
+func (i *index) isBeforeStartHeight(containerBytes []byte) (bool, error) {
+	if i.parseHeight == nil || i.startHeight == 0 || i.firstIndex < i.nextAcceptedIndex {
+		return false, nil
+	}
+	height, err := i.parseHeight(containerBytes)
+	if err != nil {
+		return false, err
+	}
+	return height < i.startHeight, nil
+}
+
+// Returns errContainerPruned if the container at [index] has been pruned.
+//
+// Assumes [i.lock] is held
+func (i *index) verifyNotPruned(index uint64) error {
+	if index < i.firstIndex {
+		return fmt.Errorf("%w: index %d is before the first available index %d", errContainerPruned, index, i.firstIndex)
+	}
+	return nil
+}
+
+// acceptedSignal returns the index of the next container to be accepted and a
+// channel that will be closed once that container is accepted.
+// Returns database.ErrClosed if the index is closed.
+func (i *index) acceptedSignal() (uint64, <-chan struct{}, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if i.closed {
+		return 0, nil, database.ErrClosed
+	}
+	return i.nextAcceptedIndex, i.accepted, nil
+}
+
 // Assumes i.lock is held
 // Returns:
 //
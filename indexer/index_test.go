@@ -27,7 +27,7 @@ func TestIndex(t *testing.T) {
 	snowCtx := snowtest.Context(t, snowtest.CChainID)
 	ctx := snowtest.ConsensusContext(snowCtx)
 
-	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{})
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, nil, 0, 0)
 	require.NoError(err)
 
 	// Populate "containers" with random IDs/bytes
@@ -79,7 +79,7 @@ func TestIndex(t *testing.T) {
 	require.NoError(db.Commit())
 	require.NoError(idx.Close())
 	db = versiondb.New(baseDB)
-	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{})
+	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{}, nil, 0, 0)
 	require.NoError(err)
 
 	// Get all of the containers
@@ -111,7 +111,7 @@ func TestIndexGetContainerByRangeMaxPageSize(t *testing.T) {
 	db := memdb.New()
 	snowCtx := snowtest.Context(t, snowtest.CChainID)
 	ctx := snowtest.ConsensusContext(snowCtx)
-	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{})
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, nil, 0, 0)
 	require.NoError(err)
 
 	// Insert [MaxFetchedByRange] + 1 containers
@@ -149,7 +149,7 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	db := memdb.New()
 	snowCtx := snowtest.Context(t, snowtest.CChainID)
 	ctx := snowtest.ConsensusContext(snowCtx)
-	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{})
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, nil, 0, 0)
 	require.NoError(err)
 
 	// Accept the same container twice
@@ -162,3 +162,121 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	require.NoError(err)
 	require.Equal([]byte{1, 2, 3}, gotContainer.Bytes)
 }
+
+func TestIndexStartHeight(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+
+	// The first byte of each container is its height
+	numParsed := 0
+	parseHeight := func(containerBytes []byte) (uint64, error) {
+		numParsed++
+		return uint64(containerBytes[0]), nil
+	}
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, parseHeight, 12, 0)
+	require.NoError(err)
+
+	containerIDs := make([]ids.ID, 4)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+		require.NoError(idx.Accept(ctx, containerIDs[i], []byte{byte(10 + i)}))
+	}
+
+	// Heights are only parsed until the first container is stored
+	require.Equal(3, numParsed)
+
+	// Containers below the start height are not stored
+	for i := uint64(0); i < 2; i++ {
+		_, err = idx.GetContainerByIndex(i)
+		require.ErrorIs(err, errContainerPruned)
+
+		_, err = idx.GetContainerByID(containerIDs[i])
+		require.ErrorIs(err, errContainerPruned)
+
+		index, err := idx.GetIndex(containerIDs[i])
+		require.ErrorIs(err, errContainerPruned)
+		require.Equal(i, index)
+	}
+
+	_, err = idx.GetContainerRange(1, 2)
+	require.ErrorIs(err, errContainerPruned)
+
+	containers, err := idx.GetContainerRange(2, 2)
+	require.NoError(err)
+	require.Len(containers, 2)
+	require.Equal(containerIDs[2], containers[0].ID)
+	require.Equal(containerIDs[3], containers[1].ID)
+
+	// Accepting the same container twice is still detected
+	require.NoError(idx.Accept(ctx, containerIDs[0], utils.RandomBytes(32)))
+	require.Equal(uint64(4), idx.nextAcceptedIndex)
+}
+
+func TestIndexRetainedContainers(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, nil, 0, 3)
+	require.NoError(err)
+
+	containerIDs := make([]ids.ID, 5)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+		require.NoError(idx.Accept(ctx, containerIDs[i], utils.RandomBytes(32)))
+	}
+	require.Equal(uint64(2), idx.firstIndex)
+
+	for i, containerID := range containerIDs {
+		_, err := idx.GetContainerByIndex(uint64(i))
+		if i < 2 {
+			require.ErrorIs(err, errContainerPruned)
+		} else {
+			require.NoError(err)
+		}
+
+		_, err = idx.GetContainerByID(containerID)
+		if i < 2 {
+			require.ErrorIs(err, errContainerPruned)
+		} else {
+			require.NoError(err)
+		}
+	}
+
+	lastAccepted, err := idx.GetLastAccepted()
+	require.NoError(err)
+	require.Equal(containerIDs[4], lastAccepted.ID)
+
+	// Reducing the retention window prunes the older containers in batches
+	db = memdb.New()
+	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{}, nil, 0, 0)
+	require.NoError(err)
+	for i := 0; i < 2*maxPrunedPerAccept; i++ {
+		require.NoError(idx.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+	}
+	require.Zero(idx.firstIndex)
+
+	idx.retainedContainers = 1
+	require.NoError(idx.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+	require.Equal(uint64(maxPrunedPerAccept), idx.firstIndex)
+
+	require.NoError(idx.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+	require.Equal(uint64(2*maxPrunedPerAccept), idx.firstIndex)
+
+	require.NoError(idx.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+	require.Equal(uint64(2*maxPrunedPerAccept+2), idx.firstIndex)
+
+	// Pruned containers remain pruned after a restart, even if the retention
+	// window is enlarged.
+	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{}, nil, 0, 0)
+	require.NoError(err)
+	require.Equal(uint64(2*maxPrunedPerAccept+2), idx.firstIndex)
+
+	_, err = idx.GetContainerByIndex(2*maxPrunedPerAccept + 1)
+	require.ErrorIs(err, errContainerPruned)
+
+	_, err = idx.GetContainerByIndex(2*maxPrunedPerAccept + 2)
+	require.NoError(err)
+}
@@ -4,6 +4,7 @@
 package indexer
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"sync"
@@ -25,6 +26,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	proposerblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
 )
 
 const (
@@ -53,6 +56,15 @@ type Config struct {
 	VertexAcceptorGroup  snow.AcceptorGroup
 	APIServer            server.PathAdder
 	ShutdownF            func()
+
+	// Blocks with a height below StartHeight are not stored by the block
+	// indexes. Their IDs are still mapped to their index, but they are
+	// reported as pruned. Vertex and transaction indexes store every
+	// container.
+	StartHeight uint64
+	// If non-zero, only the RetainedContainers most recently accepted
+	// containers of each index are stored. Older containers are pruned.
+	RetainedContainers uint64
 }
 
 // Indexer causes accepted containers for a given chain
@@ -72,6 +84,8 @@ func NewIndexer(config Config) (Indexer, error) {
 		db:                   config.DB,
 		allowIncompleteIndex: config.AllowIncompleteIndex,
 		indexingEnabled:      config.IndexingEnabled,
+		startHeight:          config.StartHeight,
+		retainedContainers:   config.RetainedContainers,
 		blockAcceptorGroup:   config.BlockAcceptorGroup,
 		txAcceptorGroup:      config.TxAcceptorGroup,
 		vertexAcceptorGroup:  config.VertexAcceptorGroup,
@@ -113,6 +127,11 @@ type indexer struct {
 	// If false, don't create index for a chain when RegisterChain is called
 	indexingEnabled bool
 
+	// Blocks with a height below [startHeight] are not stored
+	startHeight uint64
+	// If non-zero, the number of most recently accepted containers to store
+	retainedContainers uint64
+
 	// Chain ID --> index of blocks of that chain (if applicable)
 	blockIndices map[ids.ID]*index
 	// Chain ID --> index of vertices of that chain (if applicable)
@@ -243,7 +262,11 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		return
 	}
 
-	index, err := i.registerChainHelper(chainID, blockPrefix, chainName, "block", i.blockAcceptorGroup)
+	var parseHeight func([]byte) (uint64, error)
+	if parser, ok := vm.(block.Parser); ok {
+		parseHeight = blockHeightParser(parser)
+	}
+	index, err := i.registerChainHelper(chainID, blockPrefix, chainName, "block", i.blockAcceptorGroup, parseHeight)
 	if err != nil {
 		i.log.Fatal("failed to create index",
 			zap.String("chainName", chainName),
@@ -261,7 +284,7 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 
 	switch vm.(type) {
 	case vertex.DAGVM:
-		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, chainName, "vtx", i.vertexAcceptorGroup)
+		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, chainName, "vtx", i.vertexAcceptorGroup, nil)
 		if err != nil {
 			i.log.Fatal("couldn't create index",
 				zap.String("chainName", chainName),
@@ -277,7 +300,7 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		}
 		i.vtxIndices[chainID] = vtxIndex
 
-		txIndex, err := i.registerChainHelper(chainID, txPrefix, chainName, "tx", i.txAcceptorGroup)
+		txIndex, err := i.registerChainHelper(chainID, txPrefix, chainName, "tx", i.txAcceptorGroup, nil)
 		if err != nil {
 			i.log.Fatal("couldn't create index",
 				zap.String("chainName", chainName),
@@ -311,12 +334,13 @@ func (i *indexer) registerChainHelper(
 	prefixEnd byte,
 	name, endpoint string,
 	acceptorGroup snow.AcceptorGroup,
+	parseHeight func([]byte) (uint64, error),
 ) (*index, error) {
 	prefix := make([]byte, ids.IDLen+wrappers.ByteLen)
 	copy(prefix, chainID[:])
 	prefix[ids.IDLen] = prefixEnd
 	indexDB := prefixdb.New(prefix, i.db)
-	index, err := newIndex(indexDB, i.log, i.clock)
+	index, err := newIndex(indexDB, i.log, i.clock, parseHeight, i.startHeight, i.retainedContainers)
 	if err != nil {
 		_ = indexDB.Close()
 		return nil, err
@@ -341,6 +365,16 @@ func (i *indexer) registerChainHelper(
 		_ = index.Close()
 		return nil, err
 	}
+
+	// Create a websocket endpoint to stream newly accepted containers
+	subscriptionServer := &subscriptionServer{
+		log:   i.log,
+		index: index,
+	}
+	if err := i.pathAdder.AddRoute(subscriptionServer, "index/"+name, "/"+endpoint+subscribeEndpoint); err != nil {
+		_ = index.Close()
+		return nil, err
+	}
 	return index, nil
 }
 
@@ -425,3 +459,22 @@ func (i *indexer) markHasRun() error {
 func (i *indexer) hasRun() (bool, error) {
 	return i.db.Has(hasRunKey)
 }
+
+// blockHeightParser returns a function that returns the height of a block
+// accepted by a chain running [vm].
+func blockHeightParser(vm block.Parser) func([]byte) (uint64, error) {
+	return func(blkBytes []byte) (uint64, error) {
+		// Blocks built after the proposervm fork are wrapped in a proposervm
+		// block with the same height. Unwrapping them allows the height to be
+		// parsed by VMs that aren't wrapped by the proposervm, like linearized
+		// DAG VMs.
+		if statelessBlk, err := proposerblock.Parse(blkBytes); err == nil {
+			blkBytes = statelessBlk.Block()
+		}
+		blk, err := vm.ParseBlock(context.TODO(), blkBytes)
+		if err != nil {
+			return 0, err
+		}
+		return blk.Height(), nil
+	}
+}
@@ -18,11 +18,14 @@ import (
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/snowtest"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
+
+	proposerblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
 )
 
 var (
@@ -170,9 +173,11 @@ func TestIndexer(t *testing.T) {
 	previouslyIndexed, err = idxr.previouslyIndexed(chain1Ctx.ChainID)
 	require.NoError(err)
 	require.True(previouslyIndexed)
-	require.Equal(1, server.timesCalled)
+	require.Equal(2, server.timesCalled)
 	require.Equal("index/chain1", server.bases[0])
 	require.Equal("/block", server.endpoints[0])
+	require.Equal("index/chain1", server.bases[1])
+	require.Equal("/block/subscribe", server.endpoints[1])
 	require.Len(idxr.blockIndices, 1)
 	require.Empty(idxr.txIndices)
 	require.Empty(idxr.vtxIndices)
@@ -253,8 +258,9 @@ func TestIndexer(t *testing.T) {
 	container, err = blkIdx.GetLastAccepted()
 	require.NoError(err)
 	require.Equal(blkID, container.ID)
-	require.Equal(1, server.timesCalled) // block index for chain
+	require.Equal(2, server.timesCalled) // block index for chain
 	require.Contains(server.endpoints, "/block")
+	require.Contains(server.endpoints, "/block/subscribe")
 
 	// Register a DAG chain
 	snow2Ctx := snowtest.Context(t, snowtest.XChainID)
@@ -268,11 +274,13 @@ func TestIndexer(t *testing.T) {
 	dagVM := vertex.NewMockLinearizableVM(ctrl)
 	idxr.RegisterChain("chain2", chain2Ctx, dagVM)
 	require.NoError(err)
-	require.Equal(4, server.timesCalled) // block index for chain, block index for dag, vtx index, tx index
+	require.Equal(8, server.timesCalled) // block index for chain, block index for dag, vtx index, tx index
 	require.Contains(server.bases, "index/chain2")
 	require.Contains(server.endpoints, "/block")
 	require.Contains(server.endpoints, "/vtx")
 	require.Contains(server.endpoints, "/tx")
+	require.Contains(server.endpoints, "/vtx/subscribe")
+	require.Contains(server.endpoints, "/tx/subscribe")
 	require.Len(idxr.blockIndices, 2)
 	require.Len(idxr.txIndices, 1)
 	require.Len(idxr.vtxIndices, 1)
@@ -510,3 +518,33 @@ func TestIgnoreNonDefaultChains(t *testing.T) {
 	idxr.RegisterChain("chain1", chain1Ctx, chainVM)
 	require.Empty(idxr.blockIndices)
 }
+
+func TestBlockHeightParser(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	innerBlkBytes := utils.RandomBytes(32)
+	innerBlk := snowman.NewMockBlock(ctrl)
+	innerBlk.EXPECT().Height().Return(uint64(5)).Times(2)
+
+	// The VM is only ever given the inner block bytes
+	chainVM := block.NewMockChainVM(ctrl)
+	chainVM.EXPECT().ParseBlock(gomock.Any(), innerBlkBytes).Return(innerBlk, nil).Times(2)
+	parseHeight := blockHeightParser(chainVM)
+
+	height, err := parseHeight(innerBlkBytes)
+	require.NoError(err)
+	require.Equal(uint64(5), height)
+
+	proposerBlk, err := proposerblock.BuildUnsigned(
+		ids.GenerateTestID(),
+		time.Unix(0, 0),
+		1,
+		innerBlkBytes,
+	)
+	require.NoError(err)
+
+	height, err = parseHeight(proposerBlk.Bytes())
+	require.NoError(err)
+	require.Equal(uint64(5), height)
+}
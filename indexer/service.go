@@ -4,6 +4,7 @@
 package indexer
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -135,7 +136,7 @@ type IsAcceptedResponse struct {
 
 func (s *service) IsAccepted(_ *http.Request, args *IsAcceptedArgs, reply *IsAcceptedResponse) error {
 	_, err := s.index.GetIndex(args.ID)
-	if err == nil {
+	if err == nil || errors.Is(err, errContainerPruned) {
 		reply.IsAccepted = true
 		return nil
 	}
@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// Endpoint, relative to the endpoint of an index, that streams accepted
+	// containers over a websocket.
+	subscribeEndpoint = "/subscribe"
+
+	// Query parameter of the index of the first container to stream. If
+	// omitted, only containers accepted after subscribing are streamed.
+	startIndexParam = "startIndex"
+	// Query parameter of the encoding of the streamed containers. If omitted,
+	// the containers are hex encoded.
+	encodingParam = "encoding"
+
+	// Size of the ws read buffer
+	readBufferSize = units.KiB
+
+	// Size of the ws write buffer
+	writeBufferSize = units.KiB
+
+	// Time allowed to write a message to the peer.
+	writeWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the peer.
+	pongWait = 60 * time.Second
+
+	// Send pings to peer with this period. Must be less than pongWait.
+	pingPeriod = (pongWait * 9) / 10
+
+	// Maximum message size allowed from peer. Subscribers aren't expected to
+	// send any messages other than control messages.
+	maxMessageSize = units.KiB
+)
+
+var (
+	errInvalidStartIndex = errors.New("invalid start index")
+	errInvalidEncoding   = errors.New("invalid encoding")
+
+	_ http.Handler = (*subscriptionServer)(nil)
+
+	upgrader = websocket.Upgrader{
+		ReadBufferSize:  readBufferSize,
+		WriteBufferSize: writeBufferSize,
+		CheckOrigin: func(*http.Request) bool {
+			return true
+		},
+	}
+)
+
+type subscriptionError struct {
+	Error string `json:"error"`
+}
+
+// subscriptionMessage is either a FormattedContainer or a subscriptionError.
+type subscriptionMessage struct {
+	FormattedContainer
+	Error string `json:"error"`
+}
+
+// subscriptionServer streams the containers of an index over a websocket as
+// they are accepted.
+//
+// Every container is sent as a FormattedContainer, in the order they were
+// accepted. Because every container includes its index, a subscriber can
+// resume from the index after the last container it received without missing
+// any containers.
+type subscriptionServer struct {
+	log   logging.Logger
+	index *index
+}
+
+func (s *subscriptionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	startIndex, hasStartIndex, encoding, err := parseSubscriptionArgs(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Debug("failed to upgrade",
+			zap.Error(err),
+		)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// Subscribers only send control messages, but the connection must be read
+	// from to process them. Once the connection is closed, reading will fail.
+	go func() {
+		defer cancel()
+
+		conn.SetReadLimit(maxMessageSize)
+		// SetReadDeadline returns an error if the connection is corrupted
+		if err := conn.SetReadDeadline(time.Now().Add(pongWait)); err != nil {
+			return
+		}
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(pongWait))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	if !hasStartIndex {
+		startIndex, _, err = s.index.acceptedSignal()
+	}
+	if err == nil {
+		err = s.stream(ctx, conn, startIndex, encoding)
+	}
+	if err == nil {
+		return
+	}
+
+	s.log.Debug("closing index subscription",
+		zap.Error(err),
+	)
+	// The error is sent on a best effort basis, the connection may already be
+	// closed.
+	_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+	_ = conn.WriteJSON(&subscriptionError{
+		Error: err.Error(),
+	})
+}
+
+// stream writes all the containers starting at [nextIndex] to [conn]. Once all
+// the accepted containers have been written, stream waits for new containers
+// to be accepted.
+//
+// Returns nil once [ctx] is cancelled.
+func (s *subscriptionServer) stream(
+	ctx context.Context,
+	conn *websocket.Conn,
+	nextIndex uint64,
+	encoding formatting.Encoding,
+) error {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		nextAcceptedIndex, accepted, err := s.index.acceptedSignal()
+		if err != nil {
+			return err
+		}
+
+		for nextIndex < nextAcceptedIndex {
+			numToFetch := min(nextAcceptedIndex-nextIndex, MaxFetchedByRange)
+			containers, err := s.index.GetContainerRange(nextIndex, numToFetch)
+			if err != nil {
+				return err
+			}
+
+			for _, container := range containers {
+				fc, err := newFormattedContainer(container, nextIndex, encoding)
+				if err != nil {
+					return err
+				}
+				if err := conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+					return err
+				}
+				if err := conn.WriteJSON(&fc); err != nil {
+					return err
+				}
+				nextIndex++
+			}
+
+			if ctx.Err() != nil {
+				return nil
+			}
+		}
+
+		select {
+		case <-accepted:
+		case <-ticker.C:
+			if err := conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				return err
+			}
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func parseSubscriptionArgs(query url.Values) (uint64, bool, formatting.Encoding, error) {
+	var (
+		startIndex    uint64
+		hasStartIndex bool
+		encoding      = formatting.Hex
+	)
+	if startIndexStr := query.Get(startIndexParam); startIndexStr != "" {
+		var err error
+		startIndex, err = strconv.ParseUint(startIndexStr, 10, 64)
+		if err != nil {
+			return 0, false, 0, fmt.Errorf("%w %q: %w", errInvalidStartIndex, startIndexStr, err)
+		}
+		hasStartIndex = true
+	}
+	if encodingStr := query.Get(encodingParam); encodingStr != "" {
+		if err := encoding.UnmarshalJSON([]byte(strconv.Quote(encodingStr))); err != nil {
+			return 0, false, 0, fmt.Errorf("%w %q: %w", errInvalidEncoding, encodingStr, err)
+		}
+	}
+	return startIndex, hasStartIndex, encoding, nil
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var errStopSubscription = errors.New("stop subscription")
+
+func TestParseSubscriptionArgs(t *testing.T) {
+	tests := []struct {
+		name                  string
+		query                 url.Values
+		expectedStartIndex    uint64
+		expectedHasStartIndex bool
+		expectedEncoding      formatting.Encoding
+		expectedErr           error
+	}{
+		{
+			name:             "defaults",
+			query:            url.Values{},
+			expectedEncoding: formatting.Hex,
+		},
+		{
+			name: "start index and encoding",
+			query: url.Values{
+				startIndexParam: []string{"10"},
+				encodingParam:   []string{"hexc"},
+			},
+			expectedStartIndex:    10,
+			expectedHasStartIndex: true,
+			expectedEncoding:      formatting.HexC,
+		},
+		{
+			name: "invalid start index",
+			query: url.Values{
+				startIndexParam: []string{"-1"},
+			},
+			expectedErr: errInvalidStartIndex,
+		},
+		{
+			name: "invalid encoding",
+			query: url.Values{
+				encodingParam: []string{"cb58"},
+			},
+			expectedErr: errInvalidEncoding,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			startIndex, hasStartIndex, encoding, err := parseSubscriptionArgs(test.query)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expectedStartIndex, startIndex)
+			require.Equal(test.expectedHasStartIndex, hasStartIndex)
+			require.Equal(test.expectedEncoding, encoding)
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{}, nil, 0, 0)
+	require.NoError(err)
+
+	var (
+		containerIDs   []ids.ID
+		containerBytes [][]byte
+	)
+	accept := func() {
+		containerID := ids.GenerateTestID()
+		bytes := utils.RandomBytes(32)
+		require.NoError(idx.Accept(ctx, containerID, bytes))
+		containerIDs = append(containerIDs, containerID)
+		containerBytes = append(containerBytes, bytes)
+	}
+	for i := 0; i < 3; i++ {
+		accept()
+	}
+
+	server := httptest.NewServer(&subscriptionServer{
+		log:   logging.NoLog{},
+		index: idx,
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL)
+
+	// Receive the previously accepted containers, starting from index 1, and
+	// the containers accepted after subscribing.
+	nextIndex, err := client.Subscribe(context.Background(), 1, func(container Container, index uint64) error {
+		require.Equal(containerIDs[index], container.ID)
+		require.Equal(containerBytes[index], container.Bytes)
+
+		switch index {
+		case 2:
+			accept()
+			accept()
+		case 4:
+			return errStopSubscription
+		}
+		return nil
+	})
+	require.ErrorIs(err, errStopSubscription)
+	require.Equal(uint64(4), nextIndex)
+
+	// Resume the subscription without missing any containers.
+	nextIndex, err = client.Subscribe(context.Background(), nextIndex, func(container Container, index uint64) error {
+		require.Equal(containerIDs[index], container.ID)
+		require.Equal(containerBytes[index], container.Bytes)
+		return errStopSubscription
+	})
+	require.ErrorIs(err, errStopSubscription)
+	require.Equal(uint64(4), nextIndex)
+
+	// Cancelling the context stops a subscription that is waiting for new
+	// containers.
+	accept()
+	subscribeCtx, cancel := context.WithCancel(context.Background())
+	nextIndex, err = client.Subscribe(subscribeCtx, 5, func(container Container, index uint64) error {
+		require.Equal(containerIDs[index], container.ID)
+		cancel()
+		return nil
+	})
+	require.ErrorIs(err, context.Canceled)
+	require.Equal(uint64(6), nextIndex)
+}
+
+func TestSubscribeClosedIndex(t *testing.T) {
+	require := require.New(t)
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{}, nil, 0, 0)
+	require.NoError(err)
+
+	server := httptest.NewServer(&subscriptionServer{
+		log:   logging.NoLog{},
+		index: idx,
+	})
+	defer server.Close()
+
+	require.NoError(idx.Close())
+
+	client := NewClient(server.URL)
+	nextIndex, err := client.Subscribe(context.Background(), 0, func(Container, uint64) error {
+		return nil
+	})
+	require.ErrorIs(err, errSubscriptionFailed)
+	require.Zero(nextIndex)
+}
@@ -39,6 +39,7 @@ func main() {
 		fmt.Printf("couldn't load node config: %s\n", err)
 		os.Exit(1)
 	}
+	nodeConfig.ConfigLoader = config.NewConfigLoader(os.Args[1:], v)
 
 	if term.IsTerminal(int(os.Stdout.Fd())) {
 		fmt.Println(app.Header)
@@ -6,6 +6,8 @@ package message
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -18,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/metric"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
@@ -27,8 +30,40 @@ var (
 	_ OutboundMessage = (*outboundMessage)(nil)
 
 	errUnknownCompressionType = errors.New("message is compressed with an unknown compression type")
+
+	// compressionTypes are the compression types, other than no compression,
+	// that messages can be compressed with. Peers advertise the subset of
+	// these types that they support during the handshake.
+	compressionTypes = []compression.Type{
+		compression.TypeZstd,
+		compression.TypeLZ4,
+		compression.TypeSnappy,
+	}
+	compressionTypeToProto = map[compression.Type]p2p.Compression{
+		compression.TypeZstd:   p2p.Compression_COMPRESSION_ZSTD,
+		compression.TypeLZ4:    p2p.Compression_COMPRESSION_LZ4,
+		compression.TypeSnappy: p2p.Compression_COMPRESSION_SNAPPY,
+	}
 )
 
+// SupportedCompressionTypes returns the compression types that a peer that
+// sent [handshake] is able to decompress. Peers that don't advertise their
+// supported compression types only support zstd. Unknown compression types are
+// ignored.
+func SupportedCompressionTypes(handshake *p2p.Handshake) set.Set[compression.Type] {
+	if len(handshake.SupportedCompressions) == 0 {
+		return set.Of(compression.TypeZstd)
+	}
+
+	supported := set.NewSet[compression.Type](len(handshake.SupportedCompressions))
+	for _, compressionType := range compressionTypes {
+		if slices.Contains(handshake.SupportedCompressions, compressionTypeToProto[compressionType]) {
+			supported.Add(compressionType)
+		}
+	}
+	return supported
+}
+
 // InboundMessage represents a set of fields for an inbound message
 type InboundMessage interface {
 	fmt.Stringer
@@ -102,6 +137,9 @@ type OutboundMessage interface {
 	// BytesSavedCompression returns the number of bytes that this message saved
 	// due to being compressed
 	BytesSavedCompression() int
+	// CompressionType returns the compression type that the bytes were
+	// compressed with
+	CompressionType() compression.Type
 }
 
 type outboundMessage struct {
@@ -109,6 +147,15 @@ type outboundMessage struct {
 	op                    Op
 	bytes                 []byte
 	bytesSavedCompression int
+	compressionType       compression.Type
+
+	// recompressedLock protects recompressed
+	recompressedLock sync.Mutex
+	// compression type -> this message compressed with that type
+	//
+	// The message is recompressed at most once per compression type, no
+	// matter how many peers it is sent to.
+	recompressed map[compression.Type]*outboundMessage
 }
 
 func (m *outboundMessage) BypassThrottling() bool {
@@ -127,13 +174,18 @@ func (m *outboundMessage) BytesSavedCompression() int {
 	return m.bytesSavedCompression
 }
 
-// TODO: add other compression algorithms with extended interface
+func (m *outboundMessage) CompressionType() compression.Type {
+	return m.compressionType
+}
+
 type msgBuilder struct {
 	log logging.Logger
 
-	zstdCompressor            compression.Compressor
-	zstdCompressTimeMetrics   map[Op]metric.Averager
-	zstdDecompressTimeMetrics map[Op]metric.Averager
+	compressors map[compression.Type]compression.Compressor
+	// compression type -> op -> time spent compressing
+	compressTimeMetrics map[compression.Type]map[Op]metric.Averager
+	// compression type -> op -> time spent decompressing
+	decompressTimeMetrics map[compression.Type]map[Op]metric.Averager
 
 	maxMessageTimeout time.Duration
 }
@@ -148,33 +200,51 @@ func newMsgBuilder(
 	if err != nil {
 		return nil, err
 	}
+	lz4Compressor, err := compression.NewLZ4Compressor(constants.DefaultMaxMessageSize)
+	if err != nil {
+		return nil, err
+	}
+	snappyCompressor, err := compression.NewSnappyCompressor(constants.DefaultMaxMessageSize)
+	if err != nil {
+		return nil, err
+	}
 
 	mb := &msgBuilder{
 		log: log,
 
-		zstdCompressor:            zstdCompressor,
-		zstdCompressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
-		zstdDecompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
+		compressors: map[compression.Type]compression.Compressor{
+			compression.TypeZstd:   zstdCompressor,
+			compression.TypeLZ4:    lz4Compressor,
+			compression.TypeSnappy: snappyCompressor,
+		},
+		compressTimeMetrics:   make(map[compression.Type]map[Op]metric.Averager, len(compressionTypes)),
+		decompressTimeMetrics: make(map[compression.Type]map[Op]metric.Averager, len(compressionTypes)),
 
 		maxMessageTimeout: maxMessageTimeout,
 	}
 
 	errs := wrappers.Errs{}
-	for _, op := range ExternalOps {
-		mb.zstdCompressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("zstd_%s_compress_time", op),
-			fmt.Sprintf("time (in ns) to compress %s messages with zstd", op),
-			metrics,
-			&errs,
-		)
-		mb.zstdDecompressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("zstd_%s_decompress_time", op),
-			fmt.Sprintf("time (in ns) to decompress %s messages with zstd", op),
-			metrics,
-			&errs,
-		)
+	for _, compressionType := range compressionTypes {
+		compressTimeMetrics := make(map[Op]metric.Averager, len(ExternalOps))
+		decompressTimeMetrics := make(map[Op]metric.Averager, len(ExternalOps))
+		for _, op := range ExternalOps {
+			compressTimeMetrics[op] = metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s_%s_compress_time", compressionType, op),
+				fmt.Sprintf("time (in ns) to compress %s messages with %s", op, compressionType),
+				metrics,
+				&errs,
+			)
+			decompressTimeMetrics[op] = metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s_%s_decompress_time", compressionType, op),
+				fmt.Sprintf("time (in ns) to decompress %s messages with %s", op, compressionType),
+				metrics,
+				&errs,
+			)
+		}
+		mb.compressTimeMetrics[compressionType] = compressTimeMetrics
+		mb.decompressTimeMetrics[compressionType] = decompressTimeMetrics
 	}
 	return mb, errs.Err
 }
@@ -199,27 +269,35 @@ func (mb *msgBuilder) marshal(
 	//
 	// This recursive packing allows us to avoid an extra compression on/off
 	// field in the message.
-	var (
-		startTime               = time.Now()
-		compressedMsg           p2p.Message
-		opToCompressTimeMetrics map[Op]metric.Averager
-	)
-	switch compressionType {
-	case compression.TypeNone:
+	if compressionType == compression.TypeNone {
 		return uncompressedMsgBytes, 0, op, nil
+	}
+
+	compressor, ok := mb.compressors[compressionType]
+	if !ok {
+		return nil, 0, 0, errUnknownCompressionType
+	}
+
+	startTime := time.Now()
+	compressedBytes, err := compressor.Compress(uncompressedMsgBytes)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	var compressedMsg p2p.Message
+	switch compressionType {
 	case compression.TypeZstd:
-		compressedBytes, err := mb.zstdCompressor.Compress(uncompressedMsgBytes)
-		if err != nil {
-			return nil, 0, 0, err
+		compressedMsg.Message = &p2p.Message_CompressedZstd{
+			CompressedZstd: compressedBytes,
 		}
-		compressedMsg = p2p.Message{
-			Message: &p2p.Message_CompressedZstd{
-				CompressedZstd: compressedBytes,
-			},
+	case compression.TypeLZ4:
+		compressedMsg.Message = &p2p.Message_CompressedLz4{
+			CompressedLz4: compressedBytes,
+		}
+	case compression.TypeSnappy:
+		compressedMsg.Message = &p2p.Message_CompressedSnappy{
+			CompressedSnappy: compressedBytes,
 		}
-		opToCompressTimeMetrics = mb.zstdCompressTimeMetrics
-	default:
-		return nil, 0, 0, errUnknownCompressionType
 	}
 
 	compressedMsgBytes, err := proto.Marshal(&compressedMsg)
@@ -228,7 +306,7 @@ func (mb *msgBuilder) marshal(
 	}
 	compressTook := time.Since(startTime)
 
-	if compressTimeMetric, ok := opToCompressTimeMetrics[op]; ok {
+	if compressTimeMetric, ok := mb.compressTimeMetrics[compressionType][op]; ok {
 		compressTimeMetric.Observe(float64(compressTook))
 	} else {
 		// Should never happen
@@ -250,16 +328,22 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 
 	// Figure out what compression type, if any, was used to compress the message.
 	var (
-		opToDecompressTimeMetrics map[Op]metric.Averager
-		compressor                compression.Compressor
-		compressedBytes           []byte
-		zstdCompressed            = m.GetCompressedZstd()
+		compressionType  compression.Type
+		compressedBytes  []byte
+		zstdCompressed   = m.GetCompressedZstd()
+		lz4Compressed    = m.GetCompressedLz4()
+		snappyCompressed = m.GetCompressedSnappy()
 	)
 	switch {
 	case len(zstdCompressed) > 0:
-		opToDecompressTimeMetrics = mb.zstdDecompressTimeMetrics
-		compressor = mb.zstdCompressor
+		compressionType = compression.TypeZstd
 		compressedBytes = zstdCompressed
+	case len(lz4Compressed) > 0:
+		compressionType = compression.TypeLZ4
+		compressedBytes = lz4Compressed
+	case len(snappyCompressed) > 0:
+		compressionType = compression.TypeSnappy
+		compressedBytes = snappyCompressed
 	default:
 		// The message wasn't compressed
 		op, err := ToOp(m)
@@ -268,7 +352,7 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 
 	startTime := time.Now()
 
-	decompressed, err := compressor.Decompress(compressedBytes)
+	decompressed, err := mb.compressors[compressionType].Decompress(compressedBytes)
 	if err != nil {
 		return nil, 0, 0, err
 	}
@@ -284,12 +368,13 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 	if err != nil {
 		return nil, 0, 0, err
 	}
-	if decompressTimeMetric, ok := opToDecompressTimeMetrics[op]; ok {
+	if decompressTimeMetric, ok := mb.decompressTimeMetrics[compressionType][op]; ok {
 		decompressTimeMetric.Observe(float64(decompressTook))
 	} else {
 		// Should never happen
 		mb.log.Warn("no decompression metric found for op",
 			zap.Stringer("op", op),
+			zap.Stringer("compressionType", compressionType),
 		)
 	}
 
@@ -307,9 +392,45 @@ func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression
 		op:                    op,
 		bytes:                 b,
 		bytesSavedCompression: saved,
+		compressionType:       compressionType,
 	}, nil
 }
 
+// recompress returns a copy of [msg] that is compressed with
+// [compressionType]. If [msg] was created by [mb], the copy is cached on [msg]
+// so that it is only built once.
+func (mb *msgBuilder) recompress(msg OutboundMessage, compressionType compression.Type) (OutboundMessage, error) {
+	outboundMsg, ok := msg.(*outboundMessage)
+	if !ok {
+		return mb.createRecompressed(msg, compressionType)
+	}
+
+	outboundMsg.recompressedLock.Lock()
+	defer outboundMsg.recompressedLock.Unlock()
+
+	if recompressedMsg, ok := outboundMsg.recompressed[compressionType]; ok {
+		return recompressedMsg, nil
+	}
+
+	recompressedMsg, err := mb.createRecompressed(msg, compressionType)
+	if err != nil {
+		return nil, err
+	}
+	if outboundMsg.recompressed == nil {
+		outboundMsg.recompressed = make(map[compression.Type]*outboundMessage)
+	}
+	outboundMsg.recompressed[compressionType] = recompressedMsg
+	return recompressedMsg, nil
+}
+
+func (mb *msgBuilder) createRecompressed(msg OutboundMessage, compressionType compression.Type) (*outboundMessage, error) {
+	m, _, _, err := mb.unmarshal(msg.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return mb.createOutbound(m, compressionType, msg.BypassThrottling())
+}
+
 func (mb *msgBuilder) parseInbound(
 	bytes []byte,
 	nodeID ids.NodeID,
@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 func TestMessage(t *testing.T) {
@@ -659,6 +660,36 @@ func TestMessage(t *testing.T) {
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
+		{
+			desc: "app_gossip message with lz4 compression",
+			op:   AppGossipOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_AppGossip{
+					AppGossip: &p2p.AppGossip{
+						ChainId:  testID[:],
+						AppBytes: compressibleContainers[0],
+					},
+				},
+			},
+			compressionType:  compression.TypeLZ4,
+			bypassThrottling: true,
+			bytesSaved:       true,
+		},
+		{
+			desc: "app_gossip message with snappy compression",
+			op:   AppGossipOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_AppGossip{
+					AppGossip: &p2p.AppGossip{
+						ChainId:  testID[:],
+						AppBytes: compressibleContainers[0],
+					},
+				},
+			},
+			compressionType:  compression.TypeSnappy,
+			bypassThrottling: true,
+			bytesSaved:       true,
+		},
 	}
 
 	for _, tv := range tests {
@@ -670,6 +701,7 @@ func TestMessage(t *testing.T) {
 
 			require.Equal(tv.bypassThrottling, encodedMsg.BypassThrottling())
 			require.Equal(tv.op, encodedMsg.Op())
+			require.Equal(tv.compressionType, encodedMsg.CompressionType())
 
 			if bytesSaved := encodedMsg.BytesSavedCompression(); tv.bytesSaved {
 				require.Greater(bytesSaved, 0)
@@ -682,6 +714,47 @@ func TestMessage(t *testing.T) {
 	}
 }
 
+func TestSupportedCompressionTypes(t *testing.T) {
+	tests := []struct {
+		name      string
+		handshake *p2p.Handshake
+		expected  set.Set[compression.Type]
+	}{
+		{
+			name:      "legacy peer",
+			handshake: &p2p.Handshake{},
+			expected:  set.Of(compression.TypeZstd),
+		},
+		{
+			name: "all compression types",
+			handshake: &p2p.Handshake{
+				SupportedCompressions: []p2p.Compression{
+					p2p.Compression_COMPRESSION_ZSTD,
+					p2p.Compression_COMPRESSION_LZ4,
+					p2p.Compression_COMPRESSION_SNAPPY,
+				},
+			},
+			expected: set.Of(compression.TypeZstd, compression.TypeLZ4, compression.TypeSnappy),
+		},
+		{
+			name: "unknown compression types are ignored",
+			handshake: &p2p.Handshake{
+				SupportedCompressions: []p2p.Compression{
+					p2p.Compression_COMPRESSION_UNSPECIFIED,
+					p2p.Compression_COMPRESSION_LZ4,
+					p2p.Compression(100),
+				},
+			},
+			expected: set.Of(compression.TypeLZ4),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, SupportedCompressionTypes(test.handshake))
+		})
+	}
+}
+
 // Tests the Stringer interface on inbound messages
 func TestInboundMessageToString(t *testing.T) {
 	t.Parallel()
@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 var _ OutboundMsgBuilder = (*outMsgBuilder)(nil)
@@ -18,6 +19,18 @@ var _ OutboundMsgBuilder = (*outMsgBuilder)(nil)
 // with a reference count of 1. Once the reference count hits 0, the message
 // bytes should no longer be accessed.
 type OutboundMsgBuilder interface {
+	// Recompress returns [msg] compressed with a compression type in
+	// [supported]. If [msg] is uncompressed or is already compressed with a
+	// type in [supported], [msg] is returned. Otherwise, [msg] is recompressed
+	// with zstd if it is supported and is sent uncompressed if not.
+	//
+	// The recompressed message is cached on [msg], so a message that is sent
+	// to many peers is recompressed at most once per fallback type.
+	Recompress(
+		msg OutboundMessage,
+		supported set.Set[compression.Type],
+	) (OutboundMessage, error)
+
 	Handshake(
 		networkID uint32,
 		myTime uint64,
@@ -162,6 +175,7 @@ type OutboundMsgBuilder interface {
 		requestID uint32,
 		deadline time.Duration,
 		msg []byte,
+		traceContext []byte,
 	) (OutboundMessage, error)
 
 	AppResponse(
@@ -198,6 +212,22 @@ func newOutboundBuilder(compressionType compression.Type, builder *msgBuilder) O
 	}
 }
 
+func (b *outMsgBuilder) Recompress(
+	msg OutboundMessage,
+	supported set.Set[compression.Type],
+) (OutboundMessage, error) {
+	compressionType := msg.CompressionType()
+	if compressionType == compression.TypeNone || supported.Contains(compressionType) {
+		return msg, nil
+	}
+
+	fallbackType := compression.TypeNone
+	if supported.Contains(compression.TypeZstd) {
+		fallbackType = compression.TypeZstd
+	}
+	return b.builder.recompress(msg, fallbackType)
+}
+
 func (b *outMsgBuilder) Ping(
 	primaryUptime uint32,
 	subnetUptimes []*p2p.SubnetUptime,
@@ -253,6 +283,10 @@ func (b *outMsgBuilder) Handshake(
 ) (OutboundMessage, error) {
 	subnetIDBytes := make([][]byte, len(trackedSubnets))
 	encodeIDs(trackedSubnets, subnetIDBytes)
+	supportedCompressions := make([]p2p.Compression, len(compressionTypes))
+	for i, compressionType := range compressionTypes {
+		supportedCompressions[i] = compressionTypeToProto[compressionType]
+	}
 	return b.builder.createOutbound(
 		&p2p.Message{
 			Message: &p2p.Message_Handshake{
@@ -276,7 +310,8 @@ func (b *outMsgBuilder) Handshake(
 						Filter: knownPeersFilter,
 						Salt:   knownPeersSalt,
 					},
-					IpBlsSig: ipBLSSig,
+					IpBlsSig:              ipBLSSig,
+					SupportedCompressions: supportedCompressions,
 				},
 			},
 		},
@@ -663,15 +698,17 @@ func (b *outMsgBuilder) AppRequest(
 	requestID uint32,
 	deadline time.Duration,
 	msg []byte,
+	traceContext []byte,
 ) (OutboundMessage, error) {
 	return b.builder.createOutbound(
 		&p2p.Message{
 			Message: &p2p.Message_AppRequest{
 				AppRequest: &p2p.AppRequest{
-					ChainId:   chainID[:],
-					RequestId: requestID,
-					Deadline:  uint64(deadline),
-					AppBytes:  msg,
+					ChainId:      chainID[:],
+					RequestId:    requestID,
+					Deadline:     uint64(deadline),
+					AppBytes:     msg,
+					TraceContext: traceContext,
 				},
 			},
 		},
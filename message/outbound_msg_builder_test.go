@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 func Test_newOutboundBuilder(t *testing.T) {
@@ -29,6 +30,8 @@ func Test_newOutboundBuilder(t *testing.T) {
 	for _, compressionType := range []compression.Type{
 		compression.TypeNone,
 		compression.TypeZstd,
+		compression.TypeLZ4,
+		compression.TypeSnappy,
 	} {
 		t.Run(compressionType.String(), func(t *testing.T) {
 			builder := newOutboundBuilder(compressionType, mb)
@@ -44,3 +47,70 @@ func Test_newOutboundBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestRecompress(t *testing.T) {
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		10*time.Second,
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                    string
+		compressionType         compression.Type
+		supported               set.Set[compression.Type]
+		expectedCompressionType compression.Type
+	}{
+		{
+			name:                    "uncompressed",
+			compressionType:         compression.TypeNone,
+			supported:               nil,
+			expectedCompressionType: compression.TypeNone,
+		},
+		{
+			name:                    "supported",
+			compressionType:         compression.TypeLZ4,
+			supported:               set.Of(compression.TypeLZ4),
+			expectedCompressionType: compression.TypeLZ4,
+		},
+		{
+			name:                    "unsupported falls back to zstd",
+			compressionType:         compression.TypeSnappy,
+			supported:               set.Of(compression.TypeZstd, compression.TypeLZ4),
+			expectedCompressionType: compression.TypeZstd,
+		},
+		{
+			name:                    "unsupported falls back to no compression",
+			compressionType:         compression.TypeZstd,
+			supported:               nil,
+			expectedCompressionType: compression.TypeNone,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			builder := newOutboundBuilder(test.compressionType, mb)
+			msg, err := builder.AppGossip(ids.GenerateTestID(), make([]byte, 1024))
+			require.NoError(err)
+			require.Equal(test.compressionType, msg.CompressionType())
+
+			recompressedMsg, err := builder.Recompress(msg, test.supported)
+			require.NoError(err)
+			require.Equal(test.expectedCompressionType, recompressedMsg.CompressionType())
+			require.Equal(msg.BypassThrottling(), recompressedMsg.BypassThrottling())
+
+			// Recompressing the message again, as happens when it is sent to
+			// another peer, must return the cached message.
+			cachedMsg, err := builder.Recompress(msg, test.supported)
+			require.NoError(err)
+			require.Same(recompressedMsg, cachedMsg)
+
+			parsedMsg, err := mb.parseInbound(recompressedMsg.Bytes(), ids.EmptyNodeID, func() {})
+			require.NoError(err)
+			require.Equal(AppGossipOp, parsedMsg.Op())
+		})
+	}
+}
@@ -50,6 +50,15 @@ type Mapper struct {
 	r      Router
 	closer chan struct{}
 	wg     sync.WaitGroup
+
+	// statusLock must be held while accessing the fields below. They are only
+	// used to report the status of the mapper.
+	statusLock sync.RWMutex
+	// ports maps an external port to the status of its mapping
+	ports             map[uint16]*PortStatus
+	externalIP        net.IP
+	externalIPUpdated time.Time
+	externalIPErr     error
 }
 
 // NewPortMapper returns an initialized mapper
@@ -58,6 +67,7 @@ func NewPortMapper(log logging.Logger, r Router) *Mapper {
 		log:    log,
 		r:      r,
 		closer: make(chan struct{}),
+		ports:  make(map[uint16]*PortStatus),
 	}
 }
 
@@ -70,6 +80,7 @@ func (m *Mapper) Map(intPort, extPort uint16, desc string, ip ips.DynamicIPPort,
 
 	// we attempt a port map, and log an Error if it fails.
 	err := m.retryMapPort(intPort, extPort, desc, mapTimeout)
+	m.recordMapping(intPort, extPort, desc, err)
 	if err != nil {
 		m.log.Error("NAT traversal failed",
 			zap.Uint16("externalPort", extPort),
@@ -82,6 +93,7 @@ func (m *Mapper) Map(intPort, extPort uint16, desc string, ip ips.DynamicIPPort,
 			zap.Uint16("internalPort", intPort),
 		)
 	}
+	m.updateIP(ip)
 
 	m.wg.Add(1)
 	go m.keepPortMapping(intPort, extPort, desc, ip, updateTime)
@@ -135,6 +147,7 @@ func (m *Mapper) keepPortMapping(intPort, extPort uint16, desc string, ip ips.Dy
 		select {
 		case <-updateTimer.C:
 			err := m.retryMapPort(intPort, extPort, desc, mapTimeout)
+			m.recordMapping(intPort, extPort, desc, err)
 			if err != nil {
 				m.log.Warn("renew NAT traversal failed",
 					zap.Uint16("externalPort", extPort),
@@ -155,6 +168,7 @@ func (m *Mapper) updateIP(ip ips.DynamicIPPort) {
 		return
 	}
 	newIP, err := m.r.ExternalIP()
+	m.recordExternalIP(newIP, err)
 	if err != nil {
 		m.log.Error("failed to get external IP",
 			zap.Error(err),
@@ -163,7 +177,8 @@ func (m *Mapper) updateIP(ip ips.DynamicIPPort) {
 	}
 	oldIP := ip.IPPort().IP
 	ip.SetIP(newIP)
-	if !oldIP.Equal(newIP) {
+	// The IP may not have been updated if it was overridden.
+	if !oldIP.Equal(ip.IPPort().IP) {
 		m.log.Info("external IP updated",
 			zap.Stringer("newIP", newIP),
 		)
@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var (
+	_ Router = (*testRouter)(nil)
+
+	errTest = errors.New("non-nil error")
+)
+
+type testRouter struct {
+	mapErr error
+	ip     net.IP
+	ipErr  error
+}
+
+func (*testRouter) SupportsNAT() bool {
+	return true
+}
+
+func (r *testRouter) MapPort(uint16, uint16, string, time.Duration) error {
+	return r.mapErr
+}
+
+func (*testRouter) UnmapPort(uint16, uint16) error {
+	return nil
+}
+
+func (r *testRouter) ExternalIP() (net.IP, error) {
+	return r.ip, r.ipErr
+}
+
+func TestMapperStatus(t *testing.T) {
+	require := require.New(t)
+
+	router := &testRouter{
+		ip: net.IPv4(1, 2, 3, 4),
+	}
+	mapper := NewPortMapper(logging.NoLog{}, router)
+	defer mapper.UnmapAllPorts()
+
+	ip := ips.NewDynamicIPPort(net.IPv4zero, 9651)
+	mapper.Map(9651, 9651, "staking", ip, time.Hour)
+	mapper.Map(9650, 9650, "http", nil, time.Hour)
+	require.Equal(router.ip, ip.IPPort().IP)
+
+	status, err := mapper.HealthCheck(context.Background())
+	require.NoError(err)
+	require.IsType(Status{}, status)
+
+	mapperStatus := status.(Status)
+	require.Equal(unknownRouterType, mapperStatus.RouterType)
+	require.True(mapperStatus.SupportsNAT)
+	require.Equal(router.ip, mapperStatus.ExternalIP)
+	require.Len(mapperStatus.Ports, 2)
+	require.Equal("http", mapperStatus.Ports[0].Name)
+	require.Equal(json.Uint16(9650), mapperStatus.Ports[0].ExternalPort)
+	require.Equal("staking", mapperStatus.Ports[1].Name)
+	for _, port := range mapperStatus.Ports {
+		require.True(port.Mapped)
+		require.False(port.LastRenewed.IsZero())
+		require.Empty(port.Error)
+	}
+}
+
+func TestMapperHealthCheckFailedMapping(t *testing.T) {
+	require := require.New(t)
+
+	router := &testRouter{
+		mapErr: errTest,
+		ip:     net.IPv4(1, 2, 3, 4),
+	}
+	mapper := NewPortMapper(logging.NoLog{}, router)
+	defer mapper.UnmapAllPorts()
+
+	mapper.Map(9651, 9651, "staking", nil, time.Hour)
+
+	status, err := mapper.HealthCheck(context.Background())
+	require.ErrorIs(err, errPortMappingFailed)
+
+	mapperStatus := status.(Status)
+	require.Len(mapperStatus.Ports, 1)
+	require.False(mapperStatus.Ports[0].Mapped)
+	require.True(mapperStatus.Ports[0].LastRenewed.IsZero())
+	require.Equal(errTest.Error(), mapperStatus.Ports[0].Error)
+}
+
+func TestMapperHealthCheckFailedExternalIP(t *testing.T) {
+	require := require.New(t)
+
+	router := &testRouter{
+		ipErr: errTest,
+	}
+	mapper := NewPortMapper(logging.NoLog{}, router)
+	defer mapper.UnmapAllPorts()
+
+	mapper.Map(9651, 9651, "staking", ips.NewDynamicIPPort(net.IPv4zero, 9651), time.Hour)
+
+	_, err := mapper.HealthCheck(context.Background())
+	require.ErrorIs(err, errExternalIPFailed)
+}
+
+func TestMapperDoesNotReplaceOverriddenIP(t *testing.T) {
+	require := require.New(t)
+
+	router := &testRouter{
+		ip: net.IPv4(1, 2, 3, 4),
+	}
+	mapper := NewPortMapper(logging.NoLog{}, router)
+	defer mapper.UnmapAllPorts()
+
+	ip := ips.NewOverridableIPPort(net.IPv4zero, 9651)
+	override := ips.IPPort{
+		IP:   net.IPv4(5, 6, 7, 8),
+		Port: 9000,
+	}
+	ip.Override(override)
+
+	mapper.Map(9651, 9651, "staking", ip, time.Hour)
+	require.Equal(override, ip.IPPort())
+	require.Equal(router.ip, mapper.Status().ExternalIP)
+}
+
+func TestMapperStatusWithoutNAT(t *testing.T) {
+	require := require.New(t)
+
+	mapper := NewPortMapper(logging.NoLog{}, &noRouter{})
+	defer mapper.UnmapAllPorts()
+
+	mapper.Map(9651, 9651, "staking", nil, time.Hour)
+
+	status, err := mapper.HealthCheck(context.Background())
+	require.NoError(err)
+
+	mapperStatus := status.(Status)
+	require.Equal(NoRouterType, mapperStatus.RouterType)
+	require.False(mapperStatus.SupportsNAT)
+	require.Empty(mapperStatus.Ports)
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+const (
+	UPnPRouterType    = "upnp"
+	NATPMPRouterType  = "nat-pmp"
+	NoRouterType      = "none"
+	unknownRouterType = "unknown"
+)
+
+var (
+	_ health.Checker = (*Mapper)(nil)
+
+	errPortMappingFailed = errors.New("port mapping failed")
+	errExternalIPFailed  = errors.New("failed to get external IP")
+)
+
+// Status describes the port mappings of a Mapper.
+type Status struct {
+	// RouterType is the protocol used to map ports.
+	RouterType  string `json:"routerType"`
+	SupportsNAT bool   `json:"supportsNAT"`
+	// Ports are the mapped ports, sorted by external port.
+	Ports []PortStatus `json:"ports"`
+	// ExternalIP is the last IP that was reported by the router.
+	ExternalIP        net.IP    `json:"externalIP,omitempty"`
+	ExternalIPUpdated time.Time `json:"externalIPUpdated"`
+	ExternalIPError   string    `json:"externalIPError,omitempty"`
+}
+
+// PortStatus describes the mapping of a single port.
+type PortStatus struct {
+	Name         string      `json:"name"`
+	InternalPort json.Uint16 `json:"internalPort"`
+	ExternalPort json.Uint16 `json:"externalPort"`
+	// Mapped is true if the last attempt to map the port succeeded.
+	Mapped bool `json:"mapped"`
+	// LastRenewed is the last time the port was successfully mapped.
+	LastRenewed time.Time `json:"lastRenewed"`
+	// Error is the error of the last attempt to map the port, if it failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Status returns the current state of the port mappings.
+func (m *Mapper) Status() Status {
+	m.statusLock.RLock()
+	defer m.statusLock.RUnlock()
+
+	status := Status{
+		RouterType:        routerType(m.r),
+		SupportsNAT:       m.r.SupportsNAT(),
+		Ports:             make([]PortStatus, 0, len(m.ports)),
+		ExternalIP:        m.externalIP,
+		ExternalIPUpdated: m.externalIPUpdated,
+	}
+	if m.externalIPErr != nil {
+		status.ExternalIPError = m.externalIPErr.Error()
+	}
+	for _, port := range m.ports {
+		status.Ports = append(status.Ports, *port)
+	}
+	slices.SortFunc(status.Ports, func(a, b PortStatus) int {
+		return int(a.ExternalPort) - int(b.ExternalPort)
+	})
+	return status
+}
+
+// HealthCheck returns an error if the last attempt to map any of the ports, or
+// to get the external IP, failed.
+func (m *Mapper) HealthCheck(context.Context) (interface{}, error) {
+	status := m.Status()
+
+	var failedPorts []string
+	for _, port := range status.Ports {
+		if !port.Mapped {
+			failedPorts = append(failedPorts, port.Name)
+		}
+	}
+	if len(failedPorts) > 0 {
+		return status, fmt.Errorf("%w: %s", errPortMappingFailed, strings.Join(failedPorts, ", "))
+	}
+	if status.ExternalIPError != "" {
+		return status, fmt.Errorf("%w: %s", errExternalIPFailed, status.ExternalIPError)
+	}
+	return status, nil
+}
+
+func (m *Mapper) recordMapping(intPort, extPort uint16, desc string, err error) {
+	m.statusLock.Lock()
+	defer m.statusLock.Unlock()
+
+	port, ok := m.ports[extPort]
+	if !ok {
+		port = &PortStatus{
+			Name:         desc,
+			InternalPort: json.Uint16(intPort),
+			ExternalPort: json.Uint16(extPort),
+		}
+		m.ports[extPort] = port
+	}
+
+	port.Mapped = err == nil
+	if err != nil {
+		port.Error = err.Error()
+		return
+	}
+	port.LastRenewed = time.Now()
+	port.Error = ""
+}
+
+func (m *Mapper) recordExternalIP(ip net.IP, err error) {
+	m.statusLock.Lock()
+	defer m.statusLock.Unlock()
+
+	m.externalIPErr = err
+	if err != nil {
+		return
+	}
+	m.externalIP = ip
+	m.externalIPUpdated = time.Now()
+}
+
+func routerType(r Router) string {
+	switch r.(type) {
+	case *upnpRouter:
+		return UPnPRouterType
+	case *pmpRouter:
+		return NATPMPRouterType
+	case *noRouter:
+		return NoRouterType
+	default:
+		return unknownRouterType
+	}
+}
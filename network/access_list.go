@@ -0,0 +1,324 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const (
+	// AllowList contains the peers that this node always attempts to stay
+	// connected to. Allowed nodes may connect even if
+	// [Config.RequireValidatorToConnect] would otherwise prevent it, and
+	// inbound connections from allowed IPs aren't rate limited.
+	AllowList AccessListType = "allow"
+	// DenyList contains the peers that this node never connects to. The deny
+	// list takes precedence over the allow list.
+	DenyList AccessListType = "deny"
+
+	nodeIPSeparator = "@"
+)
+
+var (
+	allowListPrefix = []byte("allow")
+	denyListPrefix  = []byte("deny")
+
+	errUnknownAccessList      = errors.New("unknown access list")
+	errInvalidAccessListEntry = errors.New("invalid access list entry")
+	errDeniedNodeWithIP       = errors.New("deny list entries can't specify the IP of a node")
+)
+
+// AccessListType is the name of an access list.
+type AccessListType string
+
+func (t AccessListType) Valid() error {
+	switch t {
+	case AllowList, DenyList:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", errUnknownAccessList, t)
+	}
+}
+
+// AccessListEntry is either a NodeID, an IP or an IP range.
+type AccessListEntry struct {
+	NodeID ids.NodeID
+	// IP is the address to connect to [NodeID] at. It's only set for allow
+	// list entries, and is optional.
+	IP ips.IPPort
+	// IPs are the IPs matched by the entry. It's nil for NodeID entries.
+	IPs *net.IPNet
+}
+
+// ParseAccessListEntry parses an entry of the form "NodeID-...",
+// "NodeID-...@<ip>:<port>", "<ip>" or "<ip>/<prefix length>".
+func ParseAccessListEntry(s string) (AccessListEntry, error) {
+	if strings.HasPrefix(s, ids.NodeIDPrefix) {
+		nodeIDStr, ipStr, hasIP := strings.Cut(s, nodeIPSeparator)
+		nodeID, err := ids.NodeIDFromString(nodeIDStr)
+		if err != nil {
+			return AccessListEntry{}, fmt.Errorf("%w %q: %w", errInvalidAccessListEntry, s, err)
+		}
+		entry := AccessListEntry{
+			NodeID: nodeID,
+		}
+		if !hasIP {
+			return entry, nil
+		}
+		entry.IP, err = ips.ToIPPort(ipStr)
+		if err != nil {
+			return AccessListEntry{}, fmt.Errorf("%w %q: %w", errInvalidAccessListEntry, s, err)
+		}
+		return entry, nil
+	}
+
+	if strings.Contains(s, "/") {
+		_, ipNet, err := net.ParseCIDR(s)
+		if err != nil {
+			return AccessListEntry{}, fmt.Errorf("%w %q: %w", errInvalidAccessListEntry, s, err)
+		}
+		return AccessListEntry{
+			IPs: ipNet,
+		}, nil
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return AccessListEntry{}, fmt.Errorf("%w %q", errInvalidAccessListEntry, s)
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		ip = ipv4
+	}
+	bits := len(ip) * 8
+	return AccessListEntry{
+		IPs: &net.IPNet{
+			IP:   ip,
+			Mask: net.CIDRMask(bits, bits),
+		},
+	}, nil
+}
+
+// String returns the canonical form of the entry, which is accepted by
+// [ParseAccessListEntry].
+func (e AccessListEntry) String() string {
+	if e.IPs == nil {
+		if e.IP.IP == nil {
+			return e.NodeID.String()
+		}
+		return e.NodeID.String() + nodeIPSeparator + e.IP.String()
+	}
+	if ones, bits := e.IPs.Mask.Size(); ones == bits {
+		return e.IPs.IP.String()
+	}
+	return e.IPs.String()
+}
+
+// accessList is the persisted allow and deny lists of the network.
+type accessList struct {
+	dbs map[AccessListType]database.Database
+
+	lock sync.RWMutex
+	// list -> canonical entry -> entry
+	entries map[AccessListType]map[string]AccessListEntry
+	nodeIDs map[AccessListType]set.Set[ids.NodeID]
+	ips     map[AccessListType][]*net.IPNet
+}
+
+// newAccessList loads the access lists that were previously written to [db].
+func newAccessList(db database.Database) (*accessList, error) {
+	a := &accessList{
+		dbs: map[AccessListType]database.Database{
+			AllowList: prefixdb.New(allowListPrefix, db),
+			DenyList:  prefixdb.New(denyListPrefix, db),
+		},
+		entries: make(map[AccessListType]map[string]AccessListEntry),
+		nodeIDs: make(map[AccessListType]set.Set[ids.NodeID]),
+		ips:     make(map[AccessListType][]*net.IPNet),
+	}
+	for list, db := range a.dbs {
+		entries := make(map[string]AccessListEntry)
+		it := db.NewIterator()
+		for it.Next() {
+			entry, err := ParseAccessListEntry(string(it.Key()))
+			if err != nil {
+				it.Release()
+				return nil, err
+			}
+			entries[entry.String()] = entry
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return nil, err
+		}
+
+		a.entries[list] = entries
+		a.index(list)
+	}
+	return a, nil
+}
+
+// Add persists [entries] to [list].
+func (a *accessList) Add(list AccessListType, entries []AccessListEntry) error {
+	if err := list.Valid(); err != nil {
+		return err
+	}
+	if list == DenyList {
+		for _, entry := range entries {
+			if entry.IP.IP != nil {
+				return fmt.Errorf("%w: %s", errDeniedNodeWithIP, entry)
+			}
+		}
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	batch := a.dbs[list].NewBatch()
+	for _, entry := range entries {
+		if err := batch.Put([]byte(entry.String()), nil); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		a.entries[list][entry.String()] = entry
+	}
+	a.index(list)
+	return nil
+}
+
+// Remove deletes [entries] from [list]. Entries that aren't in [list] are
+// ignored.
+func (a *accessList) Remove(list AccessListType, entries []AccessListEntry) error {
+	if err := list.Valid(); err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	batch := a.dbs[list].NewBatch()
+	for _, entry := range entries {
+		if err := batch.Delete([]byte(entry.String())); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		delete(a.entries[list], entry.String())
+	}
+	a.index(list)
+	return nil
+}
+
+// Entries returns the entries of [list], sorted by their canonical form.
+func (a *accessList) Entries(list AccessListType) ([]AccessListEntry, error) {
+	if err := list.Valid(); err != nil {
+		return nil, err
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	entries := make([]AccessListEntry, 0, len(a.entries[list]))
+	for _, entry := range a.entries[list] {
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b AccessListEntry) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return entries, nil
+}
+
+// AllowsNode returns true if [nodeID] is in the allow list.
+//
+// The allow list doesn't consider the deny list, so callers should check the
+// deny list first.
+func (a *accessList) AllowsNode(nodeID ids.NodeID) bool {
+	return a.containsNode(AllowList, nodeID)
+}
+
+// AllowsIP returns true if [ip] is in the allow list.
+func (a *accessList) AllowsIP(ip net.IP) bool {
+	return a.containsIP(AllowList, ip)
+}
+
+// DeniesNode returns true if [nodeID] is in the deny list.
+func (a *accessList) DeniesNode(nodeID ids.NodeID) bool {
+	return a.containsNode(DenyList, nodeID)
+}
+
+// DeniesIP returns true if [ip] is in the deny list.
+func (a *accessList) DeniesIP(ip net.IP) bool {
+	return a.containsIP(DenyList, ip)
+}
+
+// containsNode returns false if [a] is nil, so that an ipTracker without an
+// access list can be used in tests.
+func (a *accessList) containsNode(list AccessListType, nodeID ids.NodeID) bool {
+	if a == nil {
+		return false
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	nodeIDs := a.nodeIDs[list]
+	return nodeIDs.Contains(nodeID)
+}
+
+// containsIP returns false if [a] is nil, so that an ipTracker without an
+// access list can be used in tests.
+func (a *accessList) containsIP(list AccessListType, ip net.IP) bool {
+	if a == nil {
+		return false
+	}
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	for _, ipNet := range a.ips[list] {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// index rebuilds the lookup tables of [list] from its entries.
+//
+// Assumes [a.lock] is held.
+func (a *accessList) index(list AccessListType) {
+	var (
+		nodeIDs set.Set[ids.NodeID]
+		ipNets  []*net.IPNet
+	)
+	for _, entry := range a.entries[list] {
+		if entry.IPs != nil {
+			ipNets = append(ipNets, entry.IPs)
+		} else {
+			nodeIDs.Add(entry.NodeID)
+		}
+	}
+	a.nodeIDs[list] = nodeIDs
+	a.ips[list] = ipNets
+}
@@ -0,0 +1,199 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/ips"
+)
+
+func TestParseAccessListEntry(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	tests := []struct {
+		name           string
+		entry          string
+		expectedEntry  AccessListEntry
+		expectedString string
+		expectedErr    error
+	}{
+		{
+			name:  "node",
+			entry: nodeID.String(),
+			expectedEntry: AccessListEntry{
+				NodeID: nodeID,
+			},
+			expectedString: nodeID.String(),
+		},
+		{
+			name:  "node with IP",
+			entry: nodeID.String() + "@1.2.3.4:9651",
+			expectedEntry: AccessListEntry{
+				NodeID: nodeID,
+				IP: ips.IPPort{
+					IP:   net.IPv4(1, 2, 3, 4),
+					Port: 9651,
+				},
+			},
+			expectedString: nodeID.String() + "@1.2.3.4:9651",
+		},
+		{
+			name:  "IPv4",
+			entry: "1.2.3.4",
+			expectedEntry: AccessListEntry{
+				IPs: &net.IPNet{
+					IP:   net.IP{1, 2, 3, 4},
+					Mask: net.CIDRMask(32, 32),
+				},
+			},
+			expectedString: "1.2.3.4",
+		},
+		{
+			name:  "IPv6",
+			entry: "::1",
+			expectedEntry: AccessListEntry{
+				IPs: &net.IPNet{
+					IP:   net.IPv6loopback,
+					Mask: net.CIDRMask(128, 128),
+				},
+			},
+			expectedString: "::1",
+		},
+		{
+			name:  "CIDR",
+			entry: "10.1.2.3/8",
+			expectedEntry: AccessListEntry{
+				IPs: &net.IPNet{
+					IP:   net.IP{10, 0, 0, 0},
+					Mask: net.CIDRMask(8, 32),
+				},
+			},
+			expectedString: "10.0.0.0/8",
+		},
+		{
+			name:        "invalid node",
+			entry:       "NodeID-invalid",
+			expectedErr: errInvalidAccessListEntry,
+		},
+		{
+			name:        "invalid node IP",
+			entry:       nodeID.String() + "@1.2.3.4",
+			expectedErr: errInvalidAccessListEntry,
+		},
+		{
+			name:        "invalid IP",
+			entry:       "1.2.3",
+			expectedErr: errInvalidAccessListEntry,
+		},
+		{
+			name:        "invalid CIDR",
+			entry:       "1.2.3.4/33",
+			expectedErr: errInvalidAccessListEntry,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			entry, err := ParseAccessListEntry(test.entry)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expectedEntry, entry)
+			require.Equal(test.expectedString, entry.String())
+		})
+	}
+}
+
+func TestAccessList(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	accessList, err := newAccessList(db)
+	require.NoError(err)
+
+	var (
+		nodeID      = ids.GenerateTestNodeID()
+		otherNodeID = ids.GenerateTestNodeID()
+		ip          = net.IPv4(10, 1, 2, 3)
+		otherIP     = net.IPv4(11, 1, 2, 3)
+	)
+	nodeEntry, err := ParseAccessListEntry(nodeID.String())
+	require.NoError(err)
+	ipsEntry, err := ParseAccessListEntry("10.0.0.0/8")
+	require.NoError(err)
+
+	require.NoError(accessList.Add(AllowList, []AccessListEntry{nodeEntry}))
+	require.NoError(accessList.Add(DenyList, []AccessListEntry{ipsEntry}))
+
+	require.True(accessList.AllowsNode(nodeID))
+	require.False(accessList.AllowsNode(otherNodeID))
+	require.False(accessList.AllowsIP(ip))
+	require.False(accessList.DeniesNode(nodeID))
+	require.True(accessList.DeniesIP(ip))
+	require.False(accessList.DeniesIP(otherIP))
+
+	// The lists should be reloaded from the database.
+	accessList, err = newAccessList(db)
+	require.NoError(err)
+
+	allowed, err := accessList.Entries(AllowList)
+	require.NoError(err)
+	require.Equal([]AccessListEntry{nodeEntry}, allowed)
+
+	denied, err := accessList.Entries(DenyList)
+	require.NoError(err)
+	require.Equal([]AccessListEntry{ipsEntry}, denied)
+
+	require.True(accessList.AllowsNode(nodeID))
+	require.True(accessList.DeniesIP(ip))
+
+	require.NoError(accessList.Remove(DenyList, []AccessListEntry{ipsEntry}))
+	require.False(accessList.DeniesIP(ip))
+
+	accessList, err = newAccessList(db)
+	require.NoError(err)
+	denied, err = accessList.Entries(DenyList)
+	require.NoError(err)
+	require.Empty(denied)
+}
+
+func TestAccessListInvalidAdd(t *testing.T) {
+	nodeEntry, err := ParseAccessListEntry(ids.GenerateTestNodeID().String() + "@1.2.3.4:9651")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		list        AccessListType
+		expectedErr error
+	}{
+		{
+			name:        "unknown list",
+			list:        "unknown",
+			expectedErr: errUnknownAccessList,
+		},
+		{
+			name:        "denied node with IP",
+			list:        DenyList,
+			expectedErr: errDeniedNodeWithIP,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			accessList, err := newAccessList(memdb.New())
+			require.NoError(err)
+
+			err = accessList.Add(test.list, []AccessListEntry{nodeEntry})
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
@@ -8,12 +8,14 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -121,8 +123,9 @@ type Config struct {
 	SupportedACPs set.Set[uint32] `json:"supportedACPs"`
 	ObjectedACPs  set.Set[uint32] `json:"objectedACPs"`
 
-	// The compression type to use when compressing outbound messages.
-	// Assumes all peers support this compression type.
+	// The compression type to use when compressing outbound messages. Messages
+	// sent to peers that don't support this compression type are compressed
+	// with zstd instead.
 	CompressionType compression.Type `json:"compressionType"`
 
 	// TLSKey is this node's TLS key that is used to sign IPs.
@@ -176,4 +179,12 @@ type Config struct {
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
 	DiskTargeter tracker.Targeter `json:"-"`
+
+	// AccessListDB persists the allow and deny lists of peers. If nil, the
+	// lists aren't persisted across restarts.
+	AccessListDB database.Database `json:"-"`
+
+	// Tracer traces the handling of messages that are passed to the router.
+	// If nil, inbound messages aren't traced.
+	Tracer trace.Tracer `json:"-"`
 }
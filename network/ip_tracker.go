@@ -38,6 +38,7 @@ func newIPTracker(
 	log logging.Logger,
 	namespace string,
 	registerer prometheus.Registerer,
+	accessList *accessList,
 ) (*ipTracker, error) {
 	bloomNamespace := metric.AppendNamespace(namespace, "ip_bloom")
 	bloomMetrics, err := bloom.NewMetrics(bloomNamespace, registerer)
@@ -45,7 +46,8 @@ func newIPTracker(
 		return nil, err
 	}
 	tracker := &ipTracker{
-		log: log,
+		log:        log,
+		accessList: accessList,
 		numValidatorIPs: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "validator_ips",
@@ -77,10 +79,16 @@ type ipTracker struct {
 	numValidatorIPs prometheus.Gauge
 	numGossipable   prometheus.Gauge
 	bloomMetrics    *bloom.Metrics
+	// Nodes and IPs in the deny list are never tracked or gossiped.
+	accessList *accessList
 
 	lock sync.RWMutex
 	// Manually tracked nodes are always treated like validators
 	manuallyTracked set.Set[ids.NodeID]
+	// primaryNetworkValidators are the current primary network validators,
+	// including those that are manually tracked. It's used to restore the
+	// validation status of nodes that stop being manually tracked.
+	primaryNetworkValidators set.Set[ids.NodeID]
 	// Connected tracks the currently connected peers, including validators and
 	// non-validators. The IP is not necessarily the same IP as in
 	// mostRecentIPs.
@@ -120,18 +128,34 @@ func (i *ipTracker) ManuallyTrack(nodeID ids.NodeID) {
 	i.manuallyTracked.Add(nodeID)
 }
 
+// StopManuallyTracking reverts [ManuallyTrack]. If [nodeID] isn't a validator,
+// it's no longer treated like one.
+func (i *ipTracker) StopManuallyTracking(nodeID ids.NodeID) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if !i.manuallyTracked.Contains(nodeID) {
+		return
+	}
+
+	i.manuallyTracked.Remove(nodeID)
+	if !i.primaryNetworkValidators.Contains(nodeID) {
+		i.onValidatorRemoved(nodeID)
+	}
+}
+
 func (i *ipTracker) WantsConnection(nodeID ids.NodeID) bool {
 	i.lock.RLock()
 	defer i.lock.RUnlock()
 
-	return i.validators.Contains(nodeID)
+	return i.validators.Contains(nodeID) && !i.accessList.DeniesNode(nodeID)
 }
 
 func (i *ipTracker) ShouldVerifyIP(ip *ips.ClaimedIPPort) bool {
 	i.lock.RLock()
 	defer i.lock.RUnlock()
 
-	if !i.validators.Contains(ip.NodeID) {
+	if !i.validators.Contains(ip.NodeID) || i.isDenied(ip) {
 		return false
 	}
 
@@ -146,7 +170,7 @@ func (i *ipTracker) AddIP(ip *ips.ClaimedIPPort) bool {
 	i.lock.Lock()
 	defer i.lock.Unlock()
 
-	if !i.validators.Contains(ip.NodeID) {
+	if !i.validators.Contains(ip.NodeID) || i.isDenied(ip) {
 		return false
 	}
 
@@ -219,6 +243,7 @@ func (i *ipTracker) OnValidatorAdded(nodeID ids.NodeID, _ *bls.PublicKey, _ ids.
 	i.lock.Lock()
 	defer i.lock.Unlock()
 
+	i.primaryNetworkValidators.Add(nodeID)
 	i.onValidatorAdded(nodeID)
 }
 
@@ -245,6 +270,11 @@ func (i *ipTracker) OnValidatorRemoved(nodeID ids.NodeID, _ uint64) {
 	i.lock.Lock()
 	defer i.lock.Unlock()
 
+	i.primaryNetworkValidators.Remove(nodeID)
+	i.onValidatorRemoved(nodeID)
+}
+
+func (i *ipTracker) onValidatorRemoved(nodeID ids.NodeID) {
 	if i.manuallyTracked.Contains(nodeID) {
 		return
 	}
@@ -256,6 +286,10 @@ func (i *ipTracker) OnValidatorRemoved(nodeID ids.NodeID, _ uint64) {
 	i.removeGossipableIP(nodeID)
 }
 
+func (i *ipTracker) isDenied(ip *ips.ClaimedIPPort) bool {
+	return i.accessList.DeniesNode(ip.NodeID) || i.accessList.DeniesIP(ip.IPPort.IP)
+}
+
 func (i *ipTracker) updateMostRecentValidatorIP(ip *ips.ClaimedIPPort) {
 	i.mostRecentValidatorIPs[ip.NodeID] = ip
 	i.numValidatorIPs.Set(float64(len(i.mostRecentValidatorIPs)))
@@ -338,7 +372,7 @@ func (i *ipTracker) GetGossipableIPs(
 		}
 
 		ip := i.gossipableIPs[index]
-		if ip.NodeID == exceptNodeID {
+		if ip.NodeID == exceptNodeID || i.isDenied(ip) {
 			continue
 		}
 
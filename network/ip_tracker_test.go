@@ -10,6 +10,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/bloom"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -17,7 +18,7 @@ import (
 )
 
 func newTestIPTracker(t *testing.T) *ipTracker {
-	tracker, err := newIPTracker(logging.NoLog{}, "", prometheus.NewRegistry())
+	tracker, err := newIPTracker(logging.NoLog{}, "", prometheus.NewRegistry(), nil)
 	require.NoError(t, err)
 	return tracker
 }
@@ -133,6 +134,69 @@ func TestIPTracker_ManuallyTrack(t *testing.T) {
 	}
 }
 
+func TestIPTracker_StopManuallyTracking(t *testing.T) {
+	tests := []struct {
+		name          string
+		initialState  *ipTracker
+		nodeID        ids.NodeID
+		expectedState *ipTracker
+	}{
+		{
+			name:          "not manually tracked",
+			initialState:  newTestIPTracker(t),
+			nodeID:        ip.NodeID,
+			expectedState: newTestIPTracker(t),
+		},
+		{
+			name: "connected non-validator",
+			initialState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.Connected(ip)
+				tracker.ManuallyTrack(ip.NodeID)
+				return tracker
+			}(),
+			nodeID: ip.NodeID,
+			expectedState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.Connected(ip)
+				tracker.ManuallyTrack(ip.NodeID)
+				tracker.manuallyTracked.Remove(ip.NodeID)
+				delete(tracker.mostRecentValidatorIPs, ip.NodeID)
+				tracker.validators.Remove(ip.NodeID)
+				delete(tracker.gossipableIndicies, ip.NodeID)
+				tracker.gossipableIPs = tracker.gossipableIPs[:0]
+				return tracker
+			}(),
+		},
+		{
+			name: "connected validator",
+			initialState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.Connected(ip)
+				tracker.ManuallyTrack(ip.NodeID)
+				tracker.OnValidatorAdded(ip.NodeID, nil, ids.Empty, 1)
+				return tracker
+			}(),
+			nodeID: ip.NodeID,
+			expectedState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.Connected(ip)
+				tracker.ManuallyTrack(ip.NodeID)
+				tracker.OnValidatorAdded(ip.NodeID, nil, ids.Empty, 1)
+				tracker.manuallyTracked.Remove(ip.NodeID)
+				return tracker
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.initialState.StopManuallyTracking(test.nodeID)
+			requireEqual(t, test.expectedState, test.initialState)
+			requireMetricsConsistent(t, test.initialState)
+		})
+	}
+}
+
 func TestIPTracker_AddIP(t *testing.T) {
 	newerIP := newerTestIP(ip)
 	tests := []struct {
@@ -708,3 +772,46 @@ func TestIPTracker_ShouldVerifyIP(t *testing.T) {
 	require.False(tracker.ShouldVerifyIP(ip))
 	require.True(tracker.ShouldVerifyIP(newerIP))
 }
+
+func TestIPTracker_DenyList(t *testing.T) {
+	require := require.New(t)
+
+	accessList, err := newAccessList(memdb.New())
+	require.NoError(err)
+
+	tracker, err := newIPTracker(logging.NoLog{}, "", prometheus.NewRegistry(), accessList)
+	require.NoError(err)
+	tracker.onValidatorAdded(ip.NodeID)
+	tracker.onValidatorAdded(otherIP.NodeID)
+	tracker.Connected(ip)
+	tracker.Connected(otherIP)
+
+	deniedNode, err := ParseAccessListEntry(ip.NodeID.String())
+	require.NoError(err)
+	require.NoError(accessList.Add(DenyList, []AccessListEntry{deniedNode}))
+
+	require.False(tracker.WantsConnection(ip.NodeID))
+	require.False(tracker.ShouldVerifyIP(newerTestIP(ip)))
+	require.False(tracker.AddIP(newerTestIP(ip)))
+	gossipableIPs := tracker.GetGossipableIPs(ids.EmptyNodeID, bloom.EmptyFilter, nil, 2)
+	require.Equal([]*ips.ClaimedIPPort{otherIP}, gossipableIPs)
+
+	// [ip] and [otherIP] share the same IP, so denying the IP range denies
+	// both of them.
+	deniedIP, err := ParseAccessListEntry("127.0.0.0/8")
+	require.NoError(err)
+	require.NoError(accessList.Add(DenyList, []AccessListEntry{deniedIP}))
+
+	require.True(tracker.WantsConnection(otherIP.NodeID))
+	require.False(tracker.ShouldVerifyIP(newerTestIP(otherIP)))
+	require.False(tracker.AddIP(newerTestIP(otherIP)))
+	gossipableIPs = tracker.GetGossipableIPs(ids.EmptyNodeID, bloom.EmptyFilter, nil, 2)
+	require.Empty(gossipableIPs)
+
+	require.NoError(accessList.Remove(DenyList, []AccessListEntry{deniedNode, deniedIP}))
+
+	require.True(tracker.WantsConnection(ip.NodeID))
+	require.True(tracker.ShouldVerifyIP(newerTestIP(ip)))
+	gossipableIPs = tracker.GetGossipableIPs(ids.EmptyNodeID, bloom.EmptyFilter, nil, 2)
+	require.ElementsMatch([]*ips.ClaimedIPPort{ip, otherIP}, gossipableIPs)
+}
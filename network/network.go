@@ -19,6 +19,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
@@ -29,6 +30,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/bloom"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -87,6 +89,33 @@ type Network interface {
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
+
+	// SetHealthConfig replaces the thresholds of the network health check.
+	// Only the minimum number of connected peers, the maximum time since a
+	// message was received or sent and the maximum send fail rate are
+	// replaced. The other fields of [config] are ignored.
+	SetHealthConfig(config HealthConfig)
+
+	// SetBandwidthThrottlerConfig replaces the inbound bandwidth allocation of
+	// every peer.
+	SetBandwidthThrottlerConfig(config throttling.BandwidthThrottlerConfig)
+
+	AccessLists
+}
+
+// AccessLists manages the persisted allow and deny lists of peers.
+type AccessLists interface {
+	// AddAccessListEntries persists [entries] to [list]. Allowed nodes are
+	// tracked, and connections to denied peers are closed.
+	AddAccessListEntries(list AccessListType, entries []AccessListEntry) error
+
+	// RemoveAccessListEntries removes [entries] from [list]. Nodes that are no
+	// longer allowed are no longer tracked, unless they are validators or were
+	// manually tracked.
+	RemoveAccessListEntries(list AccessListType, entries []AccessListEntry) error
+
+	// AccessListEntries returns the entries of [list].
+	AccessListEntries(list AccessListType) ([]AccessListEntry, error)
 }
 
 type UptimeResult struct {
@@ -139,10 +168,19 @@ type network struct {
 	onCloseCtxCancel context.CancelFunc
 
 	sendFailRateCalculator safemath.Averager
+	// Thresholds of the health check, which can be replaced while the network
+	// is running
+	healthConfig utils.Atomic[HealthConfig]
 
 	// Tracks which peers know about which peers
 	ipTracker *ipTracker
-	peersLock sync.RWMutex
+	// Persisted allow and deny lists of peers
+	accessList *accessList
+	peersLock  sync.RWMutex
+	// manuallyTrackedIDs are the nodes that were tracked with [ManuallyTrack]
+	// or that are default bootstrappers. They remain tracked even if they are
+	// removed from the allow list.
+	manuallyTrackedIDs set.Set[ids.NodeID]
 	// trackedIPs contains the set of IPs that we are currently attempting to
 	// connect to. An entry is added to this set when we first start attempting
 	// to connect to the peer. An entry is deleted from this set once we have
@@ -235,7 +273,16 @@ func NewNetwork(
 		return nil, fmt.Errorf("initializing network metrics failed with: %w", err)
 	}
 
-	ipTracker, err := newIPTracker(log, config.Namespace, metricsRegisterer)
+	accessListDB := config.AccessListDB
+	if accessListDB == nil {
+		accessListDB = memdb.New()
+	}
+	accessList, err := newAccessList(accessListDB)
+	if err != nil {
+		return nil, fmt.Errorf("initializing access list failed with: %w", err)
+	}
+
+	ipTracker, err := newIPTracker(log, config.Namespace, metricsRegisterer, accessList)
 	if err != nil {
 		return nil, fmt.Errorf("initializing ip tracker failed with: %w", err)
 	}
@@ -243,8 +290,10 @@ func NewNetwork(
 
 	// Track all default bootstrappers to ensure their current IPs are gossiped
 	// like validator IPs.
+	var manuallyTrackedIDs set.Set[ids.NodeID]
 	for _, bootstrapper := range genesis.GetBootstrappers(config.NetworkID) {
 		ipTracker.ManuallyTrack(bootstrapper.ID)
+		manuallyTrackedIDs.Add(bootstrapper.ID)
 	}
 
 	peerConfig := &peer.Config{
@@ -270,6 +319,7 @@ func NewNetwork(
 		ResourceTracker:      config.ResourceTracker,
 		UptimeCalculator:     config.UptimeCalculator,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
+		Tracer:               config.Tracer,
 	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
@@ -294,13 +344,24 @@ func NewNetwork(
 			time.Now(),
 		)),
 
-		trackedIPs:      make(map[ids.NodeID]*trackedIP),
-		ipTracker:       ipTracker,
-		connectingPeers: peer.NewSet(),
-		connectedPeers:  peer.NewSet(),
-		router:          router,
+		trackedIPs:         make(map[ids.NodeID]*trackedIP),
+		ipTracker:          ipTracker,
+		accessList:         accessList,
+		manuallyTrackedIDs: manuallyTrackedIDs,
+		connectingPeers:    peer.NewSet(),
+		connectedPeers:     peer.NewSet(),
+		router:             router,
 	}
 	n.peerConfig.Network = n
+	n.healthConfig.Set(config.HealthConfig)
+
+	allowed, err := accessList.Entries(AllowList)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range allowed {
+		n.trackAllowed(entry)
+	}
 	return n, nil
 }
 
@@ -351,9 +412,10 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	n.peersLock.RUnlock()
 
 	sendFailRate := n.sendFailRateCalculator.Read()
+	healthConfig := n.healthConfig.Get()
 
 	// Make sure we're connected to at least the minimum number of peers
-	isConnected := connectedTo >= int(n.config.HealthConfig.MinConnectedPeers)
+	isConnected := connectedTo >= int(healthConfig.MinConnectedPeers)
 	healthy := isConnected
 	details := map[string]interface{}{
 		ConnectedPeersKey: connectedTo,
@@ -367,7 +429,7 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	timeSinceLastMsgReceived := time.Duration(0)
 	if msgReceived {
 		timeSinceLastMsgReceived = now.Sub(lastMsgReceivedAt)
-		wasMsgReceivedRecently = timeSinceLastMsgReceived <= n.config.HealthConfig.MaxTimeSinceMsgReceived
+		wasMsgReceivedRecently = timeSinceLastMsgReceived <= healthConfig.MaxTimeSinceMsgReceived
 		details[TimeSinceLastMsgReceivedKey] = timeSinceLastMsgReceived.String()
 		n.metrics.timeSinceLastMsgReceived.Set(float64(timeSinceLastMsgReceived))
 	}
@@ -379,14 +441,14 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	timeSinceLastMsgSent := time.Duration(0)
 	if msgSent {
 		timeSinceLastMsgSent = now.Sub(lastMsgSentAt)
-		wasMsgSentRecently = timeSinceLastMsgSent <= n.config.HealthConfig.MaxTimeSinceMsgSent
+		wasMsgSentRecently = timeSinceLastMsgSent <= healthConfig.MaxTimeSinceMsgSent
 		details[TimeSinceLastMsgSentKey] = timeSinceLastMsgSent.String()
 		n.metrics.timeSinceLastMsgSent.Set(float64(timeSinceLastMsgSent))
 	}
 	healthy = healthy && wasMsgSentRecently
 
 	// Make sure the message send failed rate isn't too high
-	isMsgFailRate := sendFailRate <= n.config.HealthConfig.MaxSendFailRate
+	isMsgFailRate := sendFailRate <= healthConfig.MaxSendFailRate
 	healthy = healthy && isMsgFailRate
 	details[SendFailRateKey] = sendFailRate
 	n.metrics.sendFailRate.Set(sendFailRate)
@@ -395,31 +457,44 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	n.metrics.updatePeerConnectionLifetimeMetrics()
 
 	// Network layer is healthy
-	if healthy || !n.config.HealthConfig.Enabled {
+	if healthy || !healthConfig.Enabled {
 		return details, nil
 	}
 
 	var errorReasons []string
 	if !isConnected {
-		errorReasons = append(errorReasons, fmt.Sprintf("not connected to a minimum of %d peer(s) only %d", n.config.HealthConfig.MinConnectedPeers, connectedTo))
+		errorReasons = append(errorReasons, fmt.Sprintf("not connected to a minimum of %d peer(s) only %d", healthConfig.MinConnectedPeers, connectedTo))
 	}
 	if !msgReceived {
 		errorReasons = append(errorReasons, "no messages received from network")
 	} else if !wasMsgReceivedRecently {
-		errorReasons = append(errorReasons, fmt.Sprintf("no messages from network received in %s > %s", timeSinceLastMsgReceived, n.config.HealthConfig.MaxTimeSinceMsgReceived))
+		errorReasons = append(errorReasons, fmt.Sprintf("no messages from network received in %s > %s", timeSinceLastMsgReceived, healthConfig.MaxTimeSinceMsgReceived))
 	}
 	if !msgSent {
 		errorReasons = append(errorReasons, "no messages sent to network")
 	} else if !wasMsgSentRecently {
-		errorReasons = append(errorReasons, fmt.Sprintf("no messages from network sent in %s > %s", timeSinceLastMsgSent, n.config.HealthConfig.MaxTimeSinceMsgSent))
+		errorReasons = append(errorReasons, fmt.Sprintf("no messages from network sent in %s > %s", timeSinceLastMsgSent, healthConfig.MaxTimeSinceMsgSent))
 	}
 
 	if !isMsgFailRate {
-		errorReasons = append(errorReasons, fmt.Sprintf("messages failure send rate %g > %g", sendFailRate, n.config.HealthConfig.MaxSendFailRate))
+		errorReasons = append(errorReasons, fmt.Sprintf("messages failure send rate %g > %g", sendFailRate, healthConfig.MaxSendFailRate))
 	}
 	return details, fmt.Errorf("network layer is unhealthy reason: %s", strings.Join(errorReasons, ", "))
 }
 
+func (n *network) SetHealthConfig(config HealthConfig) {
+	healthConfig := n.healthConfig.Get()
+	healthConfig.MinConnectedPeers = config.MinConnectedPeers
+	healthConfig.MaxTimeSinceMsgReceived = config.MaxTimeSinceMsgReceived
+	healthConfig.MaxTimeSinceMsgSent = config.MaxTimeSinceMsgSent
+	healthConfig.MaxSendFailRate = config.MaxSendFailRate
+	n.healthConfig.Set(healthConfig)
+}
+
+func (n *network) SetBandwidthThrottlerConfig(config throttling.BandwidthThrottlerConfig) {
+	n.peerConfig.InboundMsgThrottler.SetBandwidthConfig(config)
+}
+
 // Connected is called after the peer finishes the handshake.
 // Will not be called after [Disconnected] is called with this peer.
 func (n *network) Connected(nodeID ids.NodeID) {
@@ -461,11 +536,15 @@ func (n *network) Connected(nodeID ids.NodeID) {
 }
 
 // AllowConnection returns true if this node should have a connection to the
-// provided nodeID. If the node is attempting to connect to the minimum number
-// of peers, then it should only connect if this node is a validator, or the
-// peer is a validator/beacon.
+// provided nodeID. Denied nodes are never connected to. If the node is
+// attempting to connect to the minimum number of peers, then it should only
+// connect if this node is a validator, or the peer is a validator/beacon or
+// allowed.
 func (n *network) AllowConnection(nodeID ids.NodeID) bool {
-	if !n.config.RequireValidatorToConnect {
+	if n.accessList.DeniesNode(nodeID) {
+		return false
+	}
+	if !n.config.RequireValidatorToConnect || n.accessList.AllowsNode(nodeID) {
 		return true
 	}
 	_, iAmAValidator := n.config.Validators.GetValidator(constants.PrimaryNetworkID, n.config.MyNodeID)
@@ -551,7 +630,17 @@ func (n *network) Dispatch() error {
 				return
 			}
 
-			if !n.inboundConnUpgradeThrottler.ShouldUpgrade(ip) {
+			if n.accessList.DeniesIP(ip.IP) {
+				n.peerConfig.Log.Debug("failed to upgrade connection",
+					zap.String("reason", "denied IP"),
+					zap.Stringer("peerIP", ip),
+				)
+				_ = conn.Close()
+				return
+			}
+
+			// Allowed IPs aren't rate limited.
+			if !n.accessList.AllowsIP(ip.IP) && !n.inboundConnUpgradeThrottler.ShouldUpgrade(ip) {
 				n.peerConfig.Log.Debug("failed to upgrade connection",
 					zap.String("reason", "rate-limiting"),
 					zap.Stringer("peerIP", ip),
@@ -591,6 +680,14 @@ func (n *network) Dispatch() error {
 }
 
 func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
+	n.peersLock.Lock()
+	n.manuallyTrackedIDs.Add(nodeID)
+	n.peersLock.Unlock()
+
+	n.manuallyTrack(nodeID, ip)
+}
+
+func (n *network) manuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 	n.ipTracker.ManuallyTrack(nodeID)
 
 	n.peersLock.Lock()
@@ -903,6 +1000,18 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				continue
 			}
 
+			// Denied IPs are skipped, rather than returning, for the same
+			// reason as private IPs.
+			if n.accessList.DeniesIP(ip.ip.IP) {
+				n.peerConfig.Log.Verbo("skipping connection dial",
+					zap.String("reason", "outbound connections to denied IPs are prohibited"),
+					zap.Stringer("nodeID", nodeID),
+					zap.Stringer("peerIP", ip.ip),
+					zap.Duration("delay", ip.delay),
+				)
+				continue
+			}
+
 			conn, err := n.dialer.Dial(n.onCloseCtx, ip.ip)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
@@ -1049,6 +1158,88 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 	return nil
 }
 
+func (n *network) AddAccessListEntries(list AccessListType, entries []AccessListEntry) error {
+	if err := n.accessList.Add(list, entries); err != nil {
+		return err
+	}
+
+	if list == AllowList {
+		for _, entry := range entries {
+			n.trackAllowed(entry)
+		}
+		return nil
+	}
+
+	n.peersLock.RLock()
+	peers := append(
+		n.connectingPeers.Sample(n.connectingPeers.Len(), peer.NoPrecondition),
+		n.connectedPeers.Sample(n.connectedPeers.Len(), peer.NoPrecondition)...,
+	)
+	n.peersLock.RUnlock()
+
+	for _, p := range peers {
+		denied := n.accessList.DeniesNode(p.ID())
+		// The claimed IP of a peer is only known once it's ready.
+		if p.Ready() {
+			denied = denied || n.accessList.DeniesIP(p.IP().IPPort.IP)
+		}
+		if denied {
+			n.peerConfig.Log.Info("disconnecting from peer",
+				zap.String("reason", "peer was denied"),
+				zap.Stringer("nodeID", p.ID()),
+			)
+			p.StartClose()
+		}
+	}
+	return nil
+}
+
+func (n *network) RemoveAccessListEntries(list AccessListType, entries []AccessListEntry) error {
+	if err := n.accessList.Remove(list, entries); err != nil {
+		return err
+	}
+	if list != AllowList {
+		return nil
+	}
+
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	for _, entry := range entries {
+		// The node may still be allowed by another entry.
+		if entry.IPs != nil || n.manuallyTrackedIDs.Contains(entry.NodeID) || n.accessList.AllowsNode(entry.NodeID) {
+			continue
+		}
+
+		n.ipTracker.StopManuallyTracking(entry.NodeID)
+
+		// Stop dialing the node, unless a connection is still wanted because
+		// it's a validator.
+		if tracked, ok := n.trackedIPs[entry.NodeID]; ok && !n.ipTracker.WantsConnection(entry.NodeID) {
+			tracked.stopTracking()
+			delete(n.trackedIPs, entry.NodeID)
+		}
+	}
+	return nil
+}
+
+func (n *network) AccessListEntries(list AccessListType) ([]AccessListEntry, error) {
+	return n.accessList.Entries(list)
+}
+
+// trackAllowed treats the node of the allow list [entry] like a validator,
+// and attempts to connect to it if the entry has an IP.
+func (n *network) trackAllowed(entry AccessListEntry) {
+	if entry.IPs != nil {
+		return
+	}
+	if entry.IP.IP == nil {
+		n.ipTracker.ManuallyTrack(entry.NodeID)
+		return
+	}
+	n.manuallyTrack(entry.NodeID, entry.IP)
+}
+
 func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	n.peersLock.RLock()
 	defer n.peersLock.RUnlock()
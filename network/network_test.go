@@ -301,6 +301,51 @@ func TestNewNetwork(t *testing.T) {
 	wg.Wait()
 }
 
+func TestSetHealthConfig(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+	net := networks[0]
+
+	healthConfig := defaultHealthConfig
+	healthConfig.Enabled = true
+	net.healthConfig.Set(healthConfig)
+
+	_, err := net.HealthCheck(context.Background())
+	require.NoError(err)
+
+	net.SetHealthConfig(HealthConfig{
+		MinConnectedPeers:       2,
+		MaxTimeSinceMsgReceived: time.Hour,
+		MaxTimeSinceMsgSent:     time.Hour,
+		MaxSendFailRate:         .5,
+		SendFailRateHalflife:    time.Hour,
+	})
+
+	// Only the thresholds are replaced
+	require.Equal(
+		HealthConfig{
+			Enabled:                      true,
+			MinConnectedPeers:            2,
+			MaxTimeSinceMsgReceived:      time.Hour,
+			MaxTimeSinceMsgSent:          time.Hour,
+			MaxPortionSendQueueBytesFull: defaultHealthConfig.MaxPortionSendQueueBytesFull,
+			MaxSendFailRate:              .5,
+			SendFailRateHalflife:         defaultHealthConfig.SendFailRateHalflife,
+		},
+		net.healthConfig.Get(),
+	)
+
+	// The node is only connected to 1 peer
+	_, err = net.HealthCheck(context.Background())
+	require.Error(err) //nolint:forbidigo // the error isn't exported
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
 func TestSend(t *testing.T) {
 	require := require.New(t)
 
@@ -737,3 +782,166 @@ func TestAllowConnectionAsAValidator(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestAllowListConnectsWithoutValidators(t *testing.T) {
+	require := require.New(t)
+
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, 2)
+
+	networks := make([]Network, len(configs))
+	for i, config := range configs {
+		msgCreator := newMessageCreator(t)
+		registry := prometheus.NewRegistry()
+
+		config := config
+
+		config.Beacons = validators.NewManager()
+		config.Validators = validators.NewManager()
+		config.RequireValidatorToConnect = true
+
+		net, err := NewNetwork(
+			config,
+			msgCreator,
+			registry,
+			logging.NoLog{},
+			listeners[i],
+			dialer,
+			&testHandler{
+				InboundHandler: nil,
+				ConnectedF:     nil,
+				DisconnectedF:  nil,
+			},
+		)
+		require.NoError(err)
+		networks[i] = net
+	}
+
+	// Neither node is a validator, so they only connect because they allow
+	// each other.
+	allowNode0, err := ParseAccessListEntry(nodeIDs[0].String() + "@" + configs[0].MyIPPort.IPPort().String())
+	require.NoError(err)
+	require.NoError(networks[1].AddAccessListEntries(AllowList, []AccessListEntry{allowNode0}))
+
+	allowNode1, err := ParseAccessListEntry(nodeIDs[1].String())
+	require.NoError(err)
+	require.NoError(networks[0].AddAccessListEntries(AllowList, []AccessListEntry{allowNode1}))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(networks))
+	for _, net := range networks {
+		go func(net Network) {
+			defer wg.Done()
+
+			require.NoError(net.Dispatch())
+		}(net)
+	}
+
+	network := networks[1].(*network)
+	require.Eventually(
+		func() bool {
+			network.peersLock.RLock()
+			defer network.peersLock.RUnlock()
+
+			_, contains := network.connectedPeers.GetByID(nodeIDs[0])
+			return contains
+		},
+		10*time.Second,
+		50*time.Millisecond,
+	)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestRemoveAllowListEntryStopsDialing(t *testing.T) {
+	require := require.New(t)
+
+	dialer, listeners, _, configs := newTestNetwork(t, 1)
+
+	config := configs[0]
+	config.Beacons = validators.NewManager()
+	config.Validators = validators.NewManager()
+	// Prevent the network from connecting to the allowed node, so that it
+	// keeps dialing it.
+	config.AllowPrivateIPs = false
+
+	net, err := NewNetwork(
+		config,
+		newMessageCreator(t),
+		prometheus.NewRegistry(),
+		logging.NoLog{},
+		listeners[0],
+		dialer,
+		&testHandler{
+			InboundHandler: nil,
+			ConnectedF:     nil,
+			DisconnectedF:  nil,
+		},
+	)
+	require.NoError(err)
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		require.NoError(net.Dispatch())
+	}()
+
+	nodeID := ids.GenerateTestNodeID()
+	entry, err := ParseAccessListEntry(nodeID.String() + "@127.0.0.1:9651")
+	require.NoError(err)
+	require.NoError(net.AddAccessListEntries(AllowList, []AccessListEntry{entry}))
+
+	network := net.(*network)
+	network.peersLock.RLock()
+	require.Contains(network.trackedIPs, nodeID)
+	network.peersLock.RUnlock()
+
+	require.NoError(net.RemoveAccessListEntries(AllowList, []AccessListEntry{entry}))
+
+	network.peersLock.RLock()
+	require.NotContains(network.trackedIPs, nodeID)
+	network.peersLock.RUnlock()
+
+	net.StartClose()
+	wg.Wait()
+}
+
+func TestDenyListDisconnectsPeers(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+
+	network := networks[0]
+	deniedNode, err := ParseAccessListEntry(nodeIDs[1].String())
+	require.NoError(err)
+	require.NoError(network.AddAccessListEntries(DenyList, []AccessListEntry{deniedNode}))
+	require.False(network.AllowConnection(nodeIDs[1]))
+
+	entries, err := network.AccessListEntries(DenyList)
+	require.NoError(err)
+	require.Equal([]AccessListEntry{deniedNode}, entries)
+
+	require.Eventually(
+		func() bool {
+			network.peersLock.RLock()
+			defer network.peersLock.RUnlock()
+
+			_, connected := network.connectedPeers.GetByID(nodeIDs[1])
+			return !connected
+		},
+		10*time.Second,
+		50*time.Millisecond,
+	)
+
+	require.NoError(network.RemoveAccessListEntries(DenyList, []AccessListEntry{deniedNode}))
+	require.True(network.AllowConnection(nodeIDs[1]))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,271 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package acp118
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+var (
+	ErrInvalidQuorum  = errors.New("quorum percentage must be in (0, 100]")
+	ErrFailedToSign   = errors.New("failed to aggregate enough signatures")
+	errRequestTimeout = errors.New("request timed out")
+)
+
+// Aggregator collects BLS signatures over Warp messages from validators and
+// aggregates them into a [warp.BitSetSignature].
+type Aggregator struct {
+	log            logging.Logger
+	client         *p2p.Client
+	pChainState    validators.State
+	requestTimeout time.Duration
+	maxAttempts    int
+}
+
+// NewAggregator returns an Aggregator that requests signatures with [client].
+//
+// A request to a validator that hasn't responded within [requestTimeout] is
+// retried, until the validator has been requested [maxAttempts] times. If a
+// validator registered multiple nodes with the same BLS key, each attempt is
+// sent to the next node.
+func NewAggregator(
+	log logging.Logger,
+	client *p2p.Client,
+	pChainState validators.State,
+	requestTimeout time.Duration,
+	maxAttempts int,
+) *Aggregator {
+	return &Aggregator{
+		log:            log,
+		client:         client,
+		pChainState:    pChainState,
+		requestTimeout: requestTimeout,
+		maxAttempts:    max(maxAttempts, 1),
+	}
+}
+
+type signatureResult struct {
+	validatorIndex int
+	attempt        int
+	nodeID         ids.NodeID
+	signature      *bls.Signature
+	err            error
+}
+
+type validatorStatus struct {
+	attempts int
+	done     bool
+}
+
+// AggregateSignatures returns [unsignedMessage] signed by at least
+// [quorumPercentage] percent of the weight of the validators of the source
+// chain's subnet at [pChainHeight].
+//
+// Signatures are requested from all validators in parallel, and this returns
+// as soon as enough weight has signed. Validators that respond after that are
+// not included in the signature. [justification] is sent to the validators
+// along with the message.
+func (a *Aggregator) AggregateSignatures(
+	ctx context.Context,
+	unsignedMessage *warp.UnsignedMessage,
+	justification []byte,
+	pChainHeight uint64,
+	quorumPercentage uint64,
+) (*warp.Message, error) {
+	if quorumPercentage == 0 || quorumPercentage > 100 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidQuorum, quorumPercentage)
+	}
+
+	subnetID, err := a.pChainState.GetSubnetID(ctx, unsignedMessage.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subnet of %s: %w", unsignedMessage.SourceChainID, err)
+	}
+
+	vdrs, totalWeight, err := warp.GetCanonicalValidatorSet(ctx, a.pChainState, pChainHeight, subnetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator set: %w", err)
+	}
+	if len(vdrs) == 0 {
+		return nil, fmt.Errorf("%w: no validators with a BLS key", ErrFailedToSign)
+	}
+
+	// Validators without a BLS key can't sign, so fail early if the quorum
+	// can't be reached.
+	availableWeight, err := warp.SumWeight(vdrs)
+	if err != nil {
+		return nil, err
+	}
+	if err := warp.VerifyWeight(availableWeight, totalWeight, quorumPercentage, 100); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToSign, err)
+	}
+
+	request, err := proto.Marshal(&sdk.SignatureRequest{
+		Message:       unsignedMessage.Bytes(),
+		Justification: justification,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signature request: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		// Each attempt results in a response or a failure, and possibly a
+		// timeout, so the buffer is large enough that callbacks never block.
+		results  = make(chan signatureResult, 2*len(vdrs)*a.maxAttempts)
+		statuses = make([]validatorStatus, len(vdrs))
+		pending  = len(vdrs)
+
+		signers      = set.NewBits()
+		signatures   = make([]*bls.Signature, 0, len(vdrs))
+		signedWeight uint64
+	)
+	sendRequest := func(i int) {
+		status := &statuses[i]
+		attempt := status.attempts
+		status.attempts++
+
+		vdr := vdrs[i]
+		nodeID := vdr.NodeIDs[attempt%len(vdr.NodeIDs)]
+		onResponse := func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+			result := signatureResult{
+				validatorIndex: i,
+				attempt:        attempt,
+				nodeID:         nodeID,
+				err:            err,
+			}
+			if err == nil {
+				result.signature, result.err = parseSignature(vdr, unsignedMessage, responseBytes)
+			}
+			results <- result
+		}
+		if err := a.client.AppRequest(ctx, set.Of(nodeID), request, onResponse); err != nil {
+			results <- signatureResult{
+				validatorIndex: i,
+				attempt:        attempt,
+				nodeID:         nodeID,
+				err:            err,
+			}
+			return
+		}
+
+		time.AfterFunc(a.requestTimeout, func() {
+			results <- signatureResult{
+				validatorIndex: i,
+				attempt:        attempt,
+				nodeID:         nodeID,
+				err:            errRequestTimeout,
+			}
+		})
+	}
+
+	for i := range vdrs {
+		sendRequest(i)
+	}
+
+	for pending > 0 {
+		var result signatureResult
+		select {
+		case result = <-results:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ErrFailedToSign, ctx.Err())
+		}
+
+		status := &statuses[result.validatorIndex]
+		if status.done {
+			continue
+		}
+
+		if result.err != nil {
+			// Only the failure of the latest attempt is acted on, so that a
+			// request that timed out and then failed isn't retried twice.
+			if result.attempt != status.attempts-1 {
+				continue
+			}
+
+			a.log.Debug("failed to get signature",
+				zap.Stringer("nodeID", result.nodeID),
+				zap.Int("attempt", result.attempt),
+				zap.Error(result.err),
+			)
+			if status.attempts < a.maxAttempts {
+				sendRequest(result.validatorIndex)
+				continue
+			}
+
+			status.done = true
+			pending--
+			continue
+		}
+
+		// A late response to an earlier attempt is still a valid signature.
+		status.done = true
+		pending--
+
+		signers.Add(result.validatorIndex)
+		signatures = append(signatures, result.signature)
+		// Can't overflow because the sum of all the weights was checked.
+		signedWeight += vdrs[result.validatorIndex].Weight
+
+		if err := warp.VerifyWeight(signedWeight, totalWeight, quorumPercentage, 100); err != nil {
+			continue
+		}
+
+		aggregateSignature, err := bls.AggregateSignatures(signatures)
+		if err != nil {
+			return nil, fmt.Errorf("failed to aggregate signatures: %w", err)
+		}
+
+		signature := &warp.BitSetSignature{
+			Signers: signers.Bytes(),
+		}
+		copy(signature.Signature[:], bls.SignatureToBytes(aggregateSignature))
+		return warp.NewMessage(unsignedMessage, signature)
+	}
+
+	return nil, fmt.Errorf(
+		"%w: %w",
+		ErrFailedToSign,
+		warp.VerifyWeight(signedWeight, totalWeight, quorumPercentage, 100),
+	)
+}
+
+// parseSignature returns the signature in [responseBytes] if it's a valid
+// signature of [unsignedMessage] by [vdr].
+func parseSignature(
+	vdr *warp.Validator,
+	unsignedMessage *warp.UnsignedMessage,
+	responseBytes []byte,
+) (*bls.Signature, error) {
+	response := &sdk.SignatureResponse{}
+	if err := proto.Unmarshal(responseBytes, response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signature response: %w", err)
+	}
+
+	signature, err := bls.SignatureFromBytes(response.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", warp.ErrParseSignature, err)
+	}
+
+	if !bls.Verify(vdr.PublicKey, signature, unsignedMessage.Bytes()) {
+		return nil, warp.ErrInvalidSignature
+	}
+	return signature, nil
+}
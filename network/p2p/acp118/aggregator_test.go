@@ -0,0 +1,263 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package acp118
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+const (
+	testHandlerID    = 1
+	testNetworkID    = 1
+	testPChainHeight = 10
+)
+
+// testNode is how a node responds to signature requests
+type testNode int
+
+const (
+	signs testNode = iota
+	signsWrongKey
+	fails
+	neverResponds
+)
+
+type testValidator struct {
+	sk     *bls.SecretKey
+	weight uint64
+	nodes  []testNode
+}
+
+func TestAggregatorAggregateSignatures(t *testing.T) {
+	tests := []struct {
+		name             string
+		validators       []testValidator
+		quorumPercentage uint64
+		expectedSigners  []int
+		expectedErr      error
+	}{
+		{
+			name: "all validators sign",
+			validators: []testValidator{
+				{weight: 1, nodes: []testNode{signs}},
+				{weight: 1, nodes: []testNode{signs}},
+				{weight: 1, nodes: []testNode{signs}},
+			},
+			quorumPercentage: 100,
+			expectedSigners:  []int{0, 1, 2},
+		},
+		{
+			name: "failed validator is skipped",
+			validators: []testValidator{
+				{weight: 1, nodes: []testNode{signs}},
+				{weight: 1, nodes: []testNode{fails}},
+				{weight: 1, nodes: []testNode{signs}},
+			},
+			quorumPercentage: 66,
+			expectedSigners:  []int{0, 2},
+		},
+		{
+			name: "slow validator is skipped",
+			validators: []testValidator{
+				{weight: 1, nodes: []testNode{signs}},
+				{weight: 1, nodes: []testNode{signs}},
+				{weight: 1, nodes: []testNode{neverResponds}},
+			},
+			quorumPercentage: 66,
+			expectedSigners:  []int{0, 1},
+		},
+		{
+			name: "slow node is retried with another node",
+			validators: []testValidator{
+				{weight: 1, nodes: []testNode{signs}},
+				{weight: 1, nodes: []testNode{neverResponds, signs}},
+			},
+			quorumPercentage: 100,
+			expectedSigners:  []int{0, 1},
+		},
+		{
+			name: "failed node is retried with another node",
+			validators: []testValidator{
+				{weight: 1, nodes: []testNode{signs}},
+				{weight: 1, nodes: []testNode{fails, signs}},
+			},
+			quorumPercentage: 100,
+			expectedSigners:  []int{0, 1},
+		},
+		{
+			name: "invalid signature is not counted",
+			validators: []testValidator{
+				{weight: 1, nodes: []testNode{signs}},
+				{weight: 1, nodes: []testNode{signsWrongKey}},
+			},
+			quorumPercentage: 100,
+			expectedErr:      ErrFailedToSign,
+		},
+		{
+			name: "insufficient weight",
+			validators: []testValidator{
+				{weight: 1, nodes: []testNode{signs}},
+				{weight: 3, nodes: []testNode{fails}},
+			},
+			quorumPercentage: 67,
+			expectedErr:      ErrFailedToSign,
+		},
+		{
+			name: "invalid quorum",
+			validators: []testValidator{
+				{weight: 1, nodes: []testNode{signs}},
+			},
+			quorumPercentage: 101,
+			expectedErr:      ErrInvalidQuorum,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			var (
+				chainID    = ids.GenerateTestID()
+				subnetID   = ids.GenerateTestID()
+				nodes      = make(map[ids.NodeID]testNode)
+				secretKeys = make(map[ids.NodeID]*bls.SecretKey)
+				vdrSet     = make(map[ids.NodeID]*validators.GetValidatorOutput)
+				publicKeys = make([]*bls.PublicKey, len(tt.validators))
+			)
+			for i, vdr := range tt.validators {
+				sk, err := bls.NewSecretKey()
+				require.NoError(err)
+				publicKeys[i] = bls.PublicFromSecretKey(sk)
+
+				for _, node := range vdr.nodes {
+					nodeID := ids.GenerateTestNodeID()
+					nodes[nodeID] = node
+					secretKeys[nodeID] = sk
+					vdrSet[nodeID] = &validators.GetValidatorOutput{
+						NodeID:    nodeID,
+						PublicKey: publicKeys[i],
+						// The weight of the validator is split between its
+						// nodes.
+						Weight: vdr.weight * 100 / uint64(len(vdr.nodes)),
+					}
+				}
+			}
+
+			pChainState := &validators.TestState{
+				GetSubnetIDF: func(context.Context, ids.ID) (ids.ID, error) {
+					return subnetID, nil
+				},
+				GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+					return vdrSet, nil
+				},
+			}
+
+			unsignedMessage, err := warp.NewUnsignedMessage(testNetworkID, chainID, []byte("payload"))
+			require.NoError(err)
+
+			var network *p2p.Network
+			sender := &common.SenderTest{
+				SendAppRequestF: func(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, requestBytes []byte) error {
+					nodeID, _ := nodeIDs.Peek()
+					_, requestBytes, ok := p2p.ParseMessage(requestBytes)
+					require.True(ok)
+
+					request := &sdk.SignatureRequest{}
+					require.NoError(proto.Unmarshal(requestBytes, request))
+					require.Equal(unsignedMessage.Bytes(), request.Message)
+
+					sk := secretKeys[nodeID]
+					switch nodes[nodeID] {
+					case signsWrongKey:
+						sk, err = bls.NewSecretKey()
+						require.NoError(err)
+					case fails:
+						go func() {
+							require.NoError(network.AppRequestFailed(ctx, nodeID, requestID, common.ErrTimeout))
+						}()
+						return nil
+					case neverResponds:
+						return nil
+					}
+
+					responseBytes, err := proto.Marshal(&sdk.SignatureResponse{
+						Signature: bls.SignatureToBytes(bls.Sign(sk, request.Message)),
+					})
+					require.NoError(err)
+
+					// Responses can't be delivered while the request is being
+					// sent.
+					go func() {
+						require.NoError(network.AppResponse(ctx, nodeID, requestID, responseBytes))
+					}()
+					return nil
+				},
+			}
+			network, err = p2p.NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+			require.NoError(err)
+
+			aggregator := NewAggregator(
+				logging.NoLog{},
+				network.NewClient(testHandlerID),
+				pChainState,
+				10*time.Millisecond,
+				2,
+			)
+			msg, err := aggregator.AggregateSignatures(
+				ctx,
+				unsignedMessage,
+				nil,
+				testPChainHeight,
+				tt.quorumPercentage,
+			)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+
+			require.Equal(unsignedMessage, &msg.UnsignedMessage)
+			require.NoError(msg.Signature.Verify(
+				ctx,
+				unsignedMessage,
+				testNetworkID,
+				pChainState,
+				testPChainHeight,
+				tt.quorumPercentage,
+				100,
+			))
+
+			// Signers are indexed by their position in the canonical validator
+			// set.
+			vdrs, _, err := warp.GetCanonicalValidatorSet(ctx, pChainState, testPChainHeight, subnetID)
+			require.NoError(err)
+
+			expectedSigners := set.NewBits()
+			for _, i := range tt.expectedSigners {
+				for j, vdr := range vdrs {
+					if vdr.PublicKey == publicKeys[i] {
+						expectedSigners.Add(j)
+					}
+				}
+			}
+			signature := msg.Signature.(*warp.BitSetSignature)
+			require.Equal(expectedSigners.Bytes(), signature.Signers)
+		})
+	}
+}
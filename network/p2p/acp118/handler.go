@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package acp118
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+// HandlerID is the handler ID that VMs register the signature request handler
+// with, so that signatures can be requested from any VM.
+const HandlerID = 0x12
+
+var _ p2p.Handler = (*Handler)(nil)
+
+// Verifier verifies that a Warp message should be signed
+type Verifier interface {
+	// Verify returns nil if this node should sign [message]. [justification]
+	// is provided by the requester and is opaque to the handler.
+	Verify(
+		ctx context.Context,
+		message *warp.UnsignedMessage,
+		justification []byte,
+	) error
+}
+
+// NewHandler returns a handler that signs the messages approved by [verifier]
+// with [signer].
+func NewHandler(verifier Verifier, signer warp.Signer) *Handler {
+	return NewCachedHandler(&cache.Empty[ids.ID, []byte]{}, verifier, signer)
+}
+
+// NewCachedHandler returns a handler that signs the messages approved by
+// [verifier] with [signer]. Signatures are cached in [cacher] by message ID, so
+// that a message is only verified once.
+func NewCachedHandler(
+	cacher cache.Cacher[ids.ID, []byte],
+	verifier Verifier,
+	signer warp.Signer,
+) *Handler {
+	return &Handler{
+		Handler:        p2p.NoOpHandler{},
+		signatureCache: cacher,
+		verifier:       verifier,
+		signer:         signer,
+	}
+}
+
+// Handler serves Warp signature requests
+type Handler struct {
+	p2p.Handler
+
+	signatureCache cache.Cacher[ids.ID, []byte]
+	verifier       Verifier
+	signer         warp.Signer
+}
+
+func (h *Handler) AppRequest(
+	ctx context.Context,
+	_ ids.NodeID,
+	_ time.Time,
+	requestBytes []byte,
+) ([]byte, error) {
+	request := &sdk.SignatureRequest{}
+	if err := proto.Unmarshal(requestBytes, request); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signature request: %w", err)
+	}
+
+	msg, err := warp.ParseUnsignedMessage(request.Message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse warp message: %w", err)
+	}
+
+	msgID := msg.ID()
+	signature, ok := h.signatureCache.Get(msgID)
+	if !ok {
+		if err := h.verifier.Verify(ctx, msg, request.Justification); err != nil {
+			return nil, fmt.Errorf("failed to verify warp message %s: %w", msgID, err)
+		}
+
+		signature, err = h.signer.Sign(msg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign warp message %s: %w", msgID, err)
+		}
+
+		h.signatureCache.Put(msgID, signature)
+	}
+
+	return proto.Marshal(&sdk.SignatureResponse{
+		Signature: signature,
+	})
+}
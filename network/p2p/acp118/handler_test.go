@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package acp118
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/sdk"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+var (
+	_ Verifier = (*testVerifier)(nil)
+
+	errTest = errors.New("non-nil error")
+)
+
+type testVerifier struct {
+	err   error
+	calls int
+}
+
+func (v *testVerifier) Verify(context.Context, *warp.UnsignedMessage, []byte) error {
+	v.calls++
+	return v.err
+}
+
+func TestHandlerAppRequest(t *testing.T) {
+	chainID := ids.GenerateTestID()
+
+	tests := []struct {
+		name                  string
+		cacher                cache.Cacher[ids.ID, []byte]
+		verifierErr           error
+		sourceChainID         ids.ID
+		expectedErr           error
+		expectedVerifierCalls int
+	}{
+		{
+			name:                  "signs verified message",
+			cacher:                &cache.Empty[ids.ID, []byte]{},
+			sourceChainID:         chainID,
+			expectedVerifierCalls: 2,
+		},
+		{
+			name:                  "caches signature",
+			cacher:                &cache.LRU[ids.ID, []byte]{Size: 1},
+			sourceChainID:         chainID,
+			expectedVerifierCalls: 1,
+		},
+		{
+			name:                  "verification fails",
+			cacher:                &cache.LRU[ids.ID, []byte]{Size: 1},
+			verifierErr:           errTest,
+			sourceChainID:         chainID,
+			expectedErr:           errTest,
+			expectedVerifierCalls: 2,
+		},
+		{
+			name:                  "signing fails",
+			cacher:                &cache.LRU[ids.ID, []byte]{Size: 1},
+			sourceChainID:         ids.GenerateTestID(),
+			expectedErr:           warp.ErrWrongSourceChainID,
+			expectedVerifierCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			sk, err := bls.NewSecretKey()
+			require.NoError(err)
+			pk := bls.PublicFromSecretKey(sk)
+
+			verifier := &testVerifier{
+				err: tt.verifierErr,
+			}
+			handler := NewCachedHandler(
+				tt.cacher,
+				verifier,
+				warp.NewSigner(sk, testNetworkID, chainID),
+			)
+
+			unsignedMessage, err := warp.NewUnsignedMessage(testNetworkID, tt.sourceChainID, []byte("payload"))
+			require.NoError(err)
+
+			requestBytes, err := proto.Marshal(&sdk.SignatureRequest{
+				Message:       unsignedMessage.Bytes(),
+				Justification: []byte("justification"),
+			})
+			require.NoError(err)
+
+			// Request the signature twice to check whether it's cached.
+			for i := 0; i < 2; i++ {
+				responseBytes, err := handler.AppRequest(ctx, ids.GenerateTestNodeID(), time.Time{}, requestBytes)
+				require.ErrorIs(err, tt.expectedErr)
+				if tt.expectedErr != nil {
+					continue
+				}
+
+				response := &sdk.SignatureResponse{}
+				require.NoError(proto.Unmarshal(responseBytes, response))
+
+				signature, err := bls.SignatureFromBytes(response.Signature)
+				require.NoError(err)
+				require.True(bls.Verify(pk, signature, unsignedMessage.Bytes()))
+			}
+			require.Equal(tt.expectedVerifierCalls, verifier.calls)
+		})
+	}
+}
+
+func TestHandlerInvalidRequest(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	verifier := &testVerifier{}
+	handler := NewHandler(verifier, warp.NewSigner(sk, testNetworkID, ids.GenerateTestID()))
+
+	requestBytes, err := proto.Marshal(&sdk.SignatureRequest{
+		Message: []byte("invalid message"),
+	})
+	require.NoError(err)
+
+	_, err = handler.AppRequest(context.Background(), ids.GenerateTestNodeID(), time.Time{}, requestBytes)
+	require.ErrorIs(err, codec.ErrUnknownVersion)
+	require.Zero(verifier.calls)
+}
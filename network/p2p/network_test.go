@@ -10,6 +10,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -17,6 +18,9 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -87,6 +91,40 @@ func TestMessageRouting(t *testing.T) {
 	require.True(crossChainAppRequestCalled)
 }
 
+// Tests that handling an AppRequest continues the trace of the request, and
+// that the response is sent as part of it
+func TestAppRequestTracing(t *testing.T) {
+	require := require.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, parent := tp.Tracer("").Start(context.Background(), "parent")
+
+	var responseSpanContext oteltrace.SpanContext
+	sender := &common.SenderTest{
+		SendAppResponseF: func(ctx context.Context, _ ids.NodeID, _ uint32, _ []byte) error {
+			responseSpanContext = oteltrace.SpanContextFromContext(ctx)
+			return nil
+		},
+	}
+	network, err := NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(network.AddHandler(handlerID, &TestHandler{
+		AppRequestF: func(context.Context, ids.NodeID, time.Time, []byte) ([]byte, error) {
+			return []byte("response"), nil
+		},
+	}))
+
+	require.NoError(network.AppRequest(ctx, ids.GenerateTestNodeID(), 1, time.Time{}, []byte{handlerPrefix}))
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(spans, 2)
+	require.Equal("p2p.router.AppRequest", spans[0].Name())
+	require.Equal(parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Equal(spans[0].SpanContext(), responseSpanContext)
+}
+
 // Tests that the Client prefixes messages with the handler prefix
 func TestClientPrefixesMessages(t *testing.T) {
 	require := require.New(t)
@@ -13,12 +13,16 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -134,6 +138,13 @@ func (r *router) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID ui
 		return nil
 	}
 
+	ctx, span := trace.StartFromContext(ctx, "p2p.router.AppRequest", oteltrace.WithAttributes(
+		attribute.Stringer("nodeID", nodeID),
+		attribute.Int64("requestID", int64(requestID)),
+		attribute.String("handlerID", handlerID),
+	))
+	defer span.End()
+
 	// call the corresponding handler and send back a response to nodeID
 	if err := handler.AppRequest(ctx, nodeID, requestID, deadline, parsedMsg); err != nil {
 		return err
@@ -172,6 +183,13 @@ func (r *router) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, reques
 		return ErrUnrequestedResponse
 	}
 
+	ctx, span := trace.StartFromContext(ctx, "p2p.router.AppRequestFailed", oteltrace.WithAttributes(
+		attribute.Stringer("nodeID", nodeID),
+		attribute.Int64("requestID", int64(requestID)),
+		attribute.String("handlerID", pending.handlerID),
+	))
+	defer span.End()
+
 	pending.callback(ctx, nodeID, nil, appErr)
 
 	labels := prometheus.Labels{
@@ -207,6 +225,13 @@ func (r *router) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID u
 		return ErrUnrequestedResponse
 	}
 
+	ctx, span := trace.StartFromContext(ctx, "p2p.router.AppResponse", oteltrace.WithAttributes(
+		attribute.Stringer("nodeID", nodeID),
+		attribute.Int64("requestID", int64(requestID)),
+		attribute.String("handlerID", pending.handlerID),
+	))
+	defer span.End()
+
 	pending.callback(ctx, nodeID, response, nil)
 
 	labels := prometheus.Labels{
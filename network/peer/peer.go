@@ -14,14 +14,17 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/bloom"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -29,6 +32,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 // maxBloomSaltLen restricts the allowed size of the bloom salt to prevent
@@ -38,6 +43,10 @@ const maxBloomSaltLen = 32
 var (
 	errClosed = errors.New("closed")
 
+	// legacyCompressionTypes are the compression types that peers that don't
+	// advertise their supported compression types are able to decompress.
+	legacyCompressionTypes = message.SupportedCompressionTypes(&p2p.Handshake{})
+
 	_ Peer = (*peer)(nil)
 )
 
@@ -137,6 +146,12 @@ type peer struct {
 	// options of ACPs provided in the Handshake message.
 	supportedACPs set.Set[uint32]
 	objectedACPs  set.Set[uint32]
+	// supportedCompressionTypes are the compression types the peer is able to
+	// decompress, as provided in the Handshake message.
+	//
+	// Invariant: This is only written by the reader goroutine before
+	// [gotHandshake] is set.
+	supportedCompressionTypes set.Set[compression.Type]
 
 	// txIDOfVerifiedBLSKey is the txID that added the BLS key that was most
 	// recently verified to have signed the IP.
@@ -423,11 +438,13 @@ func (p *peer) readMessages() {
 		// exited before calling [Network.Disconnected] to guarantee that there
 		// can't be multiple instances of this goroutine running over different
 		// peer instances.
+		throttleStart := p.Clock.Time()
 		onFinishedHandling := p.InboundMsgThrottler.Acquire(
 			p.onClosingCtx,
 			uint64(msgLen),
 			p.id,
 		)
+		throttleEnd := p.Clock.Time()
 
 		// If the peer is shutting down, there's no need to read the message.
 		if err := p.onClosingCtx.Err(); err != nil {
@@ -492,7 +509,7 @@ func (p *peer) readMessages() {
 
 		// Handle the message. Note that when we are done handling this message,
 		// we must call [msg.OnFinishedHandling()].
-		p.handle(msg)
+		p.handle(msg, throttleStart, throttleEnd)
 		p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
 	}
 }
@@ -581,6 +598,23 @@ func (p *peer) writeMessages() {
 }
 
 func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
+	// Until the peer's Handshake is received, the peer is assumed to only
+	// support the compression types that every peer supports.
+	supportedCompressionTypes := legacyCompressionTypes
+	if p.gotHandshake.Get() {
+		supportedCompressionTypes = p.supportedCompressionTypes
+	}
+	recompressedMsg, err := p.MessageCreator.Recompress(msg, supportedCompressionTypes)
+	if err != nil {
+		p.Log.Error("failed to recompress message",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Error(err),
+		)
+		return
+	}
+	msg = recompressedMsg
+
 	msgBytes := msg.Bytes()
 	p.Log.Verbo("sending message",
 		zap.Stringer("nodeID", p.id),
@@ -735,7 +769,9 @@ func (p *peer) shouldDisconnect() bool {
 	return false
 }
 
-func (p *peer) handle(msg message.InboundMessage) {
+// handle processes [msg], which was read after waiting on the inbound message
+// throttler from [throttleStart] until [throttleEnd].
+func (p *peer) handle(msg message.InboundMessage, throttleStart, throttleEnd time.Time) {
 	switch m := msg.Message().(type) { // Network-related message types
 	case *p2p.Ping:
 		p.handlePing(m)
@@ -770,7 +806,38 @@ func (p *peer) handle(msg message.InboundMessage) {
 	}
 
 	// Consensus and app-level messages
-	p.Router.HandleInbound(context.Background(), msg)
+	ctx := context.Background()
+	if p.Tracer != nil {
+		var span oteltrace.Span
+		ctx, span = p.startSpan(msg, throttleStart, throttleEnd)
+		defer span.End()
+	}
+	p.Router.HandleInbound(ctx, msg)
+}
+
+// startSpan starts the span of [msg] being handled by this peer. The span
+// starts when the peer started waiting on the inbound message throttler, so
+// that the time spent being throttled is part of the trace.
+//
+// If [msg] was sent with the trace context of the requester, the span
+// continues the requester's trace.
+func (p *peer) startSpan(
+	msg message.InboundMessage,
+	throttleStart time.Time,
+	throttleEnd time.Time,
+) (context.Context, oteltrace.Span) {
+	ctx := context.Background()
+	if m, ok := msg.Message().(*p2p.AppRequest); ok {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, m.TraceContext)
+	}
+
+	ctx, span := p.Tracer.Start(ctx, "peer.handle", oteltrace.WithTimestamp(throttleStart), oteltrace.WithAttributes(
+		attribute.Stringer("nodeID", p.id),
+		attribute.Stringer("messageOp", msg.Op()),
+	))
+	_, throttleSpan := p.Tracer.Start(ctx, "peer.throttle", oteltrace.WithTimestamp(throttleStart))
+	throttleSpan.End(oteltrace.WithTimestamp(throttleEnd))
+	return ctx, span
 }
 
 func (p *peer) handlePing(msg *p2p.Ping) {
@@ -976,6 +1043,8 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 		}
 	}
 
+	p.supportedCompressionTypes = message.SupportedCompressionTypes(msg)
+
 	for _, acp := range msg.SupportedAcps {
 		if constants.CurrentACPs.Contains(acp) {
 			p.supportedACPs.Add(acp)
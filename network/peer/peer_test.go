@@ -12,6 +12,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
@@ -22,6 +23,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -30,6 +33,9 @@ import (
 	"github.com/ava-labs/avalanchego/utils/resource"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/version"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 type testPeer struct {
@@ -271,6 +277,117 @@ func TestSend(t *testing.T) {
 	require.NoError(peer1.AwaitClosed(context.Background()))
 }
 
+func TestSendNegotiatesCompression(t *testing.T) {
+	require := require.New(t)
+
+	peer0, peer1 := makeReadyTestPeers(t, set.Set[ids.ID]{})
+	mc, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeLZ4,
+		10*time.Second,
+	)
+	require.NoError(err)
+
+	outboundMsg, err := mc.AppGossip(ids.Empty, make([]byte, 1024))
+	require.NoError(err)
+	require.Equal(compression.TypeLZ4, outboundMsg.CompressionType())
+
+	require.True(peer0.Send(context.Background(), outboundMsg))
+
+	inboundMsg := <-peer1.inboundMsgChan
+	require.Equal(message.AppGossipOp, inboundMsg.Op())
+	require.Positive(inboundMsg.BytesSavedCompression())
+
+	// A peer that doesn't advertise its supported compression types is only
+	// sent zstd compressed messages.
+	recompressedMsg, err := mc.Recompress(outboundMsg, legacyCompressionTypes)
+	require.NoError(err)
+	require.Equal(compression.TypeZstd, recompressedMsg.CompressionType())
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestAppRequestContinuesTrace(t *testing.T) {
+	require := require.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("")
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t, set.Set[ids.ID]{})
+	inboundCtxChan := make(chan context.Context, 1)
+	rawPeer1.config.Tracer = &testTracer{Tracer: tracer}
+	rawPeer1.config.Router = router.InboundHandlerFunc(func(ctx context.Context, msg message.InboundMessage) {
+		if msg.Op() == message.AppRequestOp {
+			inboundCtxChan <- ctx
+		}
+		msg.OnFinishedHandling()
+	})
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		rawPeer1.conn,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	ctx, requestSpan := tracer.Start(context.Background(), "request")
+	mc := newMessageCreator(t)
+	outboundMsg, err := mc.AppRequest(ids.Empty, 1, time.Second, nil, trace.MarshalSpanContext(ctx))
+	require.NoError(err)
+	require.True(peer0.Send(context.Background(), outboundMsg))
+
+	inboundCtx := <-inboundCtxChan
+	inboundSpanContext := oteltrace.SpanContextFromContext(inboundCtx)
+	require.Equal(requestSpan.SpanContext().TraceID(), inboundSpanContext.TraceID())
+	requestSpan.End()
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	require.Contains(spans, "peer.handle")
+	require.Contains(spans, "peer.throttle")
+	require.Equal(requestSpan.SpanContext().SpanID(), spans["peer.handle"].Parent().SpanID())
+	require.Equal(spans["peer.handle"].SpanContext().SpanID(), spans["peer.throttle"].Parent().SpanID())
+}
+
+type testTracer struct {
+	oteltrace.Tracer
+}
+
+func (*testTracer) Close() error {
+	return nil
+}
+
 func TestPingUptimes(t *testing.T) {
 	trackedSubnetID := ids.GenerateTestID()
 	untrackedSubnetID := ids.GenerateTestID()
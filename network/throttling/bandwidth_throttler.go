@@ -47,6 +47,10 @@ type bandwidthThrottler interface {
 	// Must be called when we stop reading messages from [nodeID].
 	// It's safe for multiple goroutines to concurrently call RemoveNode.
 	RemoveNode(nodeID ids.NodeID)
+
+	// Replace the refill rate and the max burst size of every node.
+	// It's safe for multiple goroutines to concurrently call SetConfig.
+	SetConfig(config BandwidthThrottlerConfig)
 }
 
 type BandwidthThrottlerConfig struct {
@@ -162,3 +166,15 @@ func (t *bandwidthThrottlerImpl) RemoveNode(nodeID ids.NodeID) {
 	}
 	delete(t.limiters, nodeID)
 }
+
+// See BandwidthThrottler.
+func (t *bandwidthThrottlerImpl) SetConfig(config BandwidthThrottlerConfig) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.BandwidthThrottlerConfig = config
+	for _, limiter := range t.limiters {
+		limiter.SetLimit(rate.Limit(config.RefillRate))
+		limiter.SetBurst(int(config.MaxBurstSize))
+	}
+}
@@ -59,3 +59,41 @@ func TestBandwidthThrottler(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestBandwidthThrottlerSetConfig(t *testing.T) {
+	require := require.New(t)
+
+	throttlerIntf, err := newBandwidthThrottler(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		BandwidthThrottlerConfig{
+			RefillRate:   8,
+			MaxBurstSize: 10,
+		},
+	)
+	require.NoError(err)
+	throttler := throttlerIntf.(*bandwidthThrottlerImpl)
+
+	nodeID1 := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID1)
+
+	newConfig := BandwidthThrottlerConfig{
+		RefillRate:   16,
+		MaxBurstSize: 20,
+	}
+	throttler.SetConfig(newConfig)
+	require.Equal(newConfig, throttler.BandwidthThrottlerConfig)
+
+	// Existing nodes use the new config
+	limiter1 := throttler.limiters[nodeID1]
+	require.Equal(16, int(limiter1.Limit()))
+	require.Equal(20, limiter1.Burst())
+
+	// New nodes use the new config
+	nodeID2 := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID2)
+	limiter2 := throttler.limiters[nodeID2]
+	require.Equal(16, int(limiter2.Limit()))
+	require.Equal(20, limiter2.Burst())
+}
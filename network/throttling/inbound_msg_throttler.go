@@ -41,6 +41,10 @@ type InboundMsgThrottler interface {
 	// Must be called when we stop reading messages from [nodeID].
 	// It's safe for multiple goroutines to concurrently call RemoveNode.
 	RemoveNode(nodeID ids.NodeID)
+
+	// Replace the bandwidth allocation of every node. Nodes that are
+	// currently blocked in Acquire are subject to the new allocation.
+	SetBandwidthConfig(config BandwidthThrottlerConfig)
 }
 
 type InboundMsgThrottlerConfig struct {
@@ -179,3 +183,8 @@ func (t *inboundMsgThrottler) AddNode(nodeID ids.NodeID) {
 func (t *inboundMsgThrottler) RemoveNode(nodeID ids.NodeID) {
 	t.bandwidthThrottler.RemoveNode(nodeID)
 }
+
+// See BandwidthThrottler.
+func (t *inboundMsgThrottler) SetBandwidthConfig(config BandwidthThrottlerConfig) {
+	t.bandwidthThrottler.SetConfig(config)
+}
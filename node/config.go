@@ -25,9 +25,31 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
 
+// ConfigLoader re-reads the config of the node. It returns the new config and
+// how it differs from the config that the node is running with.
+type ConfigLoader func() (Config, *ConfigReload, error)
+
+// ConfigChange is a config key whose value changed.
+type ConfigChange struct {
+	Key string `json:"key"`
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// ConfigReload describes the changes made to the config of the node.
+type ConfigReload struct {
+	// Applied are the changes that the node applied without restarting.
+	Applied []ConfigChange `json:"applied"`
+	// RequiresRestart are the changes that only take effect once the node is
+	// restarted.
+	RequiresRestart []ConfigChange `json:"requiresRestart"`
+}
+
 type APIIndexerConfig struct {
-	IndexAPIEnabled      bool `json:"indexAPIEnabled"`
-	IndexAllowIncomplete bool `json:"indexAllowIncomplete"`
+	IndexAPIEnabled         bool   `json:"indexAPIEnabled"`
+	IndexAllowIncomplete    bool   `json:"indexAllowIncomplete"`
+	IndexStartHeight        uint64 `json:"indexStartHeight"`
+	IndexRetainedContainers uint64 `json:"indexRetainedContainers"`
 }
 
 type HTTPConfig struct {
@@ -36,9 +58,15 @@ type HTTPConfig struct {
 	HTTPHost  string `json:"httpHost"`
 	HTTPPort  uint16 `json:"httpPort"`
 
-	HTTPSEnabled bool   `json:"httpsEnabled"`
-	HTTPSKey     []byte `json:"-"`
-	HTTPSCert    []byte `json:"-"`
+	HTTPSEnabled  bool   `json:"httpsEnabled"`
+	HTTPSKey      []byte `json:"-"`
+	HTTPSCert     []byte `json:"-"`
+	HTTPSClientCA []byte `json:"-"`
+
+	// APIAuthConfig is nil if API authentication is disabled.
+	APIAuthConfig *server.AuthConfig `json:"-"`
+	// APIRateLimitConfig is nil if API rate limiting is disabled.
+	APIRateLimitConfig *server.RateLimitConfig `json:"apiRateLimitConfig"`
 
 	HTTPAllowedOrigins []string `json:"httpAllowedOrigins"`
 	HTTPAllowedHosts   []string `json:"httpAllowedHosts"`
@@ -215,4 +243,8 @@ type Config struct {
 	// Path to write process context to (including PID, API URI, and
 	// staking address).
 	ProcessContextFilePath string `json:"processContextFilePath"`
+
+	// ConfigLoader is used to reload the config of the running node. If nil,
+	// the config can't be reloaded.
+	ConfigLoader ConfigLoader `json:"-"`
 }
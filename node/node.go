@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -94,11 +95,14 @@ var (
 	genesisHashKey     = []byte("genesisID")
 	ungracefulShutdown = []byte("ungracefulShutdown")
 
-	indexerDBPrefix  = []byte{0x00}
-	keystoreDBPrefix = []byte("keystore")
+	indexerDBPrefix    = []byte{0x00}
+	keystoreDBPrefix   = []byte("keystore")
+	accessListDBPrefix = []byte("access list")
 
-	errInvalidTLSKey = errors.New("invalid TLS key")
-	errShuttingDown  = errors.New("server shutting down")
+	errInvalidTLSKey        = errors.New("invalid TLS key")
+	errInvalidHTTPSClientCA = errors.New("invalid HTTPs client certificate authorities")
+	errShuttingDown         = errors.New("server shutting down")
+	errConfigNotReloadable  = errors.New("config can't be reloaded")
 )
 
 // New returns an instance of Node
@@ -154,7 +158,7 @@ func New(
 	}
 
 	// Set up tracer
-	n.tracer, err = trace.New(n.Config.TraceConfig)
+	n.tracer, err = trace.NewReloadable(n.Config.TraceConfig)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize tracer: %w", err)
 	}
@@ -271,6 +275,8 @@ type Node struct {
 	router     nat.Router
 	portMapper *nat.Mapper
 	ipUpdater  dynamicip.Updater
+	// publicIP is the IP and port that this node advertises to its peers
+	publicIP *ips.OverridableIPPort
 
 	chainRouter router.Router
 
@@ -335,7 +341,10 @@ type Node struct {
 	// This node's configuration
 	Config *Config
 
-	tracer trace.Tracer
+	tracer trace.ReloadableTracer
+
+	// ensures that only one config reload happens at a time
+	reloadLock sync.Mutex
 
 	// ensures that we only close the node once.
 	shutdownOnce sync.Once
@@ -419,7 +428,7 @@ func (n *Node) initNetworking() error {
 		return err
 	}
 
-	var dynamicIP ips.DynamicIPPort
+	var dynamicIP *ips.OverridableIPPort
 	switch {
 	case n.Config.PublicIP != "":
 		// Use the specified public IP.
@@ -427,7 +436,7 @@ func (n *Node) initNetworking() error {
 		if ipPort.IP == nil {
 			return fmt.Errorf("invalid IP Address: %s", n.Config.PublicIP)
 		}
-		dynamicIP = ips.NewDynamicIPPort(ipPort.IP, ipPort.Port)
+		dynamicIP = ips.NewOverridableIPPort(ipPort.IP, ipPort.Port)
 		n.ipUpdater = dynamicip.NewNoUpdater()
 	case n.Config.PublicIPResolutionService != "":
 		// Use dynamic IP resolution.
@@ -443,14 +452,14 @@ func (n *Node) initNetworking() error {
 		if err != nil {
 			return fmt.Errorf("couldn't resolve public IP: %w", err)
 		}
-		dynamicIP = ips.NewDynamicIPPort(ipPort.IP, ipPort.Port)
+		dynamicIP = ips.NewOverridableIPPort(ipPort.IP, ipPort.Port)
 		n.ipUpdater = dynamicip.NewUpdater(dynamicIP, resolver, n.Config.PublicIPResolutionFreq)
 	default:
 		ipPort.IP, err = n.router.ExternalIP()
 		if err != nil {
 			return fmt.Errorf("public IP / IP resolution service not given and failed to resolve IP with NAT: %w", err)
 		}
-		dynamicIP = ips.NewDynamicIPPort(ipPort.IP, ipPort.Port)
+		dynamicIP = ips.NewOverridableIPPort(ipPort.IP, ipPort.Port)
 		n.ipUpdater = dynamicip.NewNoUpdater()
 	}
 
@@ -460,6 +469,8 @@ func (n *Node) initNetworking() error {
 		)
 	}
 
+	n.publicIP = dynamicIP
+
 	// Regularly update our public IP and port mappings.
 	n.portMapper.Map(
 		ipPort.Port,
@@ -514,6 +525,7 @@ func (n *Node) initNetworking() error {
 	n.chainRouter = &router.ChainRouter{}
 	if n.Config.TraceConfig.Enabled {
 		n.chainRouter = router.Trace(n.chainRouter, n.tracer)
+		n.Config.NetworkConfig.Tracer = n.tracer
 	}
 
 	// Configure benchlist
@@ -598,6 +610,7 @@ func (n *Node) initNetworking() error {
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
+	n.Config.NetworkConfig.AccessListDB = prefixdb.New(accessListDBPrefix, n.DB)
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -838,6 +851,8 @@ func (n *Node) initIndexer() error {
 	n.indexer, err = indexer.NewIndexer(indexer.Config{
 		IndexingEnabled:      n.Config.IndexAPIEnabled,
 		AllowIncompleteIndex: n.Config.IndexAllowIncomplete,
+		StartHeight:          n.Config.IndexStartHeight,
+		RetainedContainers:   n.Config.IndexRetainedContainers,
 		DB:                   txIndexerDB,
 		Log:                  n.Log,
 		BlockAcceptorGroup:   n.BlockAcceptorGroup,
@@ -960,6 +975,17 @@ func (n *Node) initAPIServer() error {
 			MinVersion:   tls.VersionTLS12,
 			Certificates: []tls.Certificate{cert},
 		}
+		if len(n.Config.HTTPSClientCA) > 0 {
+			clientCAs := x509.NewCertPool()
+			if !clientCAs.AppendCertsFromPEM(n.Config.HTTPSClientCA) {
+				return errInvalidHTTPSClientCA
+			}
+			// Client certificates are optional, as callers may authenticate
+			// with bearer tokens instead, but any that are provided must be
+			// valid.
+			config.ClientCAs = clientCAs
+			config.ClientAuth = tls.VerifyClientCertIfGiven
+		}
 		listener = tls.NewListener(listener, config)
 
 		protocol = "https"
@@ -979,6 +1005,8 @@ func (n *Node) initAPIServer() error {
 		n.MetricsRegisterer,
 		n.Config.HTTPConfig.HTTPConfig,
 		n.Config.HTTPAllowedHosts,
+		n.Config.APIAuthConfig,
+		n.Config.APIRateLimitConfig,
 	)
 	return err
 }
@@ -1273,6 +1301,11 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.VMManager,
 			VMRegistry:   n.VMRegistry,
+			AccessLists:  n.Net,
+			PublicIP:     n.publicIP,
+			ConfigReloader: func() (interface{}, error) {
+				return n.ReloadConfig()
+			},
 		},
 	)
 	if err != nil {
@@ -1341,6 +1374,7 @@ func (n *Node) initInfoAPI() error {
 		n.Config.NetworkConfig.MyIPPort,
 		n.Net,
 		n.benchlistManager,
+		n.portMapper,
 	)
 	if err != nil {
 		return err
@@ -1377,6 +1411,11 @@ func (n *Node) initHealthAPI() error {
 		return fmt.Errorf("couldn't register router health check: %w", err)
 	}
 
+	err = healthChecker.RegisterHealthCheck("nat", n.portMapper, health.ApplicationTag)
+	if err != nil {
+		return fmt.Errorf("couldn't register nat health check: %w", err)
+	}
+
 	// TODO: add database health to liveness check
 	err = healthChecker.RegisterHealthCheck("database", n.DB, health.ApplicationTag)
 	if err != nil {
@@ -1538,6 +1577,54 @@ func (n *Node) initDiskTargeter(
 	)
 }
 
+// ReloadConfig re-reads the config of the node and applies the changes that
+// can be made without restarting the node:
+//
+//   - The log levels of loggers created after the reload
+//   - The hostnames that API requests are accepted for
+//   - The thresholds of the network health check
+//   - The inbound bandwidth allocation of peers
+//   - The exporter and sample rate of the tracer, if tracing is enabled
+//
+// Other changes are reported, but only take effect once the node is restarted.
+func (n *Node) ReloadConfig() (*ConfigReload, error) {
+	if n.Config.ConfigLoader == nil {
+		return nil, errConfigNotReloadable
+	}
+
+	n.reloadLock.Lock()
+	defer n.reloadLock.Unlock()
+
+	config, reload, err := n.Config.ConfigLoader()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load config: %w", err)
+	}
+
+	n.LogFactory.SetDefaultLogLevel(config.LoggingConfig.LogLevel)
+	n.LogFactory.SetDefaultDisplayLevel(config.LoggingConfig.DisplayLevel)
+	n.APIServer.SetAllowedHosts(config.HTTPAllowedHosts)
+	n.Net.SetHealthConfig(config.NetworkConfig.HealthConfig)
+	n.Net.SetBandwidthThrottlerConfig(config.NetworkConfig.ThrottlerConfig.InboundMsgThrottlerConfig.BandwidthThrottlerConfig)
+
+	// Enabling or disabling tracing requires a restart, because traced
+	// components are only created on startup.
+	if n.Config.TraceConfig.Enabled && config.TraceConfig.Enabled {
+		if err := n.tracer.Reload(config.TraceConfig); err != nil {
+			return nil, fmt.Errorf("couldn't reload tracer: %w", err)
+		}
+	}
+
+	n.Log.Info("reloaded config",
+		zap.Reflect("applied", reload.Applied),
+	)
+	if len(reload.RequiresRestart) > 0 {
+		n.Log.Warn("config changes require a restart",
+			zap.Reflect("requiresRestart", reload.RequiresRestart),
+		)
+	}
+	return reload, nil
+}
+
 // Shutdown this node
 // May be called multiple times
 func (n *Node) Shutdown(exitCode int) {
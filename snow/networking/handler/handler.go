@@ -13,7 +13,6 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
@@ -26,11 +25,13 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 
 	commontracker "github.com/ava-labs/avalanchego/snow/engine/common/tracker"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -459,9 +460,17 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 			zap.Stringer("messageOp", op),
 		)
 	}
+	ctx, span := trace.StartFromContext(ctx, "handler.handleSyncMsg", oteltrace.WithAttributes(
+		attribute.Stringer("nodeID", nodeID),
+		attribute.Stringer("messageOp", op),
+		attribute.Stringer("chainID", h.ctx.ChainID),
+	))
+	defer span.End()
+
 	h.resourceTracker.StartProcessing(nodeID, startTime)
 	h.ctx.Lock.Lock()
 	lockAcquiredTime := h.clock.Time()
+	span.AddEvent("lockAcquired")
 	defer func() {
 		h.ctx.Lock.Unlock()
 
@@ -795,6 +804,13 @@ func (h *handler) executeAsyncMsg(ctx context.Context, msg Message) error {
 			zap.Stringer("messageOp", op),
 		)
 	}
+	ctx, span := trace.StartFromContext(ctx, "handler.executeAsyncMsg", oteltrace.WithAttributes(
+		attribute.Stringer("nodeID", nodeID),
+		attribute.Stringer("messageOp", op),
+		attribute.Stringer("chainID", h.ctx.ChainID),
+	))
+	defer span.End()
+
 	h.resourceTracker.StartProcessing(nodeID, startTime)
 	defer func() {
 		var (
@@ -985,8 +1001,8 @@ func (h *handler) popUnexpiredMsg(
 				zap.Stringer("nodeID", msg.NodeID()),
 				zap.Stringer("messageOp", msg.Op()),
 			)
-			span := trace.SpanFromContext(ctx)
-			span.AddEvent("dropping message", trace.WithAttributes(
+			span := oteltrace.SpanFromContext(ctx)
+			span.AddEvent("dropping message", oteltrace.WithAttributes(
 				attribute.String("reason", "timeout"),
 			))
 			expired.Inc()
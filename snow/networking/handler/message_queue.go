@@ -7,6 +7,7 @@ import (
 	"context"
 	"sync"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -15,8 +16,11 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/buffer"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 var _ MessageQueue = (*messageQueue)(nil)
@@ -100,10 +104,18 @@ func (m *messageQueue) Push(ctx context.Context, msg Message) {
 		return
 	}
 
+	// The span isn't added to the context, so that the spans of handling the
+	// message are siblings of the time spent in the queue.
+	_, queuedSpan := trace.StartFromContext(ctx, "handler.queued", oteltrace.WithAttributes(
+		attribute.Stringer("nodeID", msg.NodeID()),
+		attribute.Stringer("messageOp", msg.Op()),
+	))
+
 	// Add the message to the queue
 	m.msgAndCtxs.PushRight(&msgAndContext{
-		msg: msg,
-		ctx: ctx,
+		msg:        msg,
+		ctx:        ctx,
+		queuedSpan: queuedSpan,
 	})
 	m.nodeToUnprocessedMsgs[msg.NodeID()]++
 
@@ -157,6 +169,7 @@ func (m *messageQueue) Pop() (context.Context, Message, bool) {
 			m.metrics.nodesWithMessages.Set(float64(len(m.nodeToUnprocessedMsgs)))
 			m.metrics.len.Dec()
 			m.metrics.ops[msg.Op()].Dec()
+			msgAndCtx.queuedSpan.End()
 			return ctx, msg, true
 		}
 		// [msg.nodeID] is causing excessive CPU usage.
@@ -182,6 +195,7 @@ func (m *messageQueue) Shutdown() {
 	for m.msgAndCtxs.Len() > 0 {
 		msgAndCtx, _ := m.msgAndCtxs.PopLeft()
 		msgAndCtx.msg.OnFinishedHandling()
+		msgAndCtx.queuedSpan.End()
 	}
 	m.nodeToUnprocessedMsgs = nil
 
@@ -239,4 +253,6 @@ func (m *messageQueue) canPop(msg message.InboundMessage) bool {
 type msgAndContext struct {
 	msg Message
 	ctx context.Context
+	// queuedSpan is ended when the message is removed from the queue
+	queuedSpan oteltrace.Span
 }
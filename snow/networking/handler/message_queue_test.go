@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/mock/gomock"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -17,6 +18,9 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/snowtest"
 	"github.com/ava-labs/avalanchego/snow/validators"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 func TestQueue(t *testing.T) {
@@ -161,3 +165,43 @@ func TestQueue(t *testing.T) {
 	require.Equal(msg3, gotMsg3)
 	require.Zero(u.Len())
 }
+
+func TestQueueTracesQueuedTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	cpuTracker := tracker.NewMockTracker(ctrl)
+	cpuTracker.EXPECT().Usage(gomock.Any(), gomock.Any()).Return(0.0).AnyTimes()
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	queue, err := NewMessageQueue(ctx, validators.NewManager(), cpuTracker, "", message.AsynchronousOps)
+	require.NoError(err)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	spanCtx, parent := tp.Tracer("").Start(context.Background(), "parent")
+	defer parent.End()
+
+	msg := Message{
+		InboundMessage: message.InboundAppRequest(
+			ids.Empty,
+			0,
+			time.Second,
+			nil,
+			ids.GenerateTestNodeID(),
+		),
+		EngineType: p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+	}
+	queue.Push(spanCtx, msg)
+	require.Empty(recorder.Ended())
+
+	poppedCtx, _, ok := queue.Pop()
+	require.True(ok)
+
+	// The popped context still refers to the span that pushed the message.
+	require.Equal(parent.SpanContext(), oteltrace.SpanContextFromContext(poppedCtx))
+
+	spans := recorder.Ended()
+	require.Len(spans, 1)
+	require.Equal("handler.queued", spans[0].Name())
+	require.Equal(parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
@@ -1347,12 +1348,14 @@ func (s *sender) SendAppRequest(ctx context.Context, nodeIDs set.Set[ids.NodeID]
 		}
 	}
 
-	// Create the outbound message.
+	// Create the outbound message. The span that issued the request is
+	// included so that the peer's spans can be connected to it.
 	outMsg, err := s.msgCreator.AppRequest(
 		s.ctx.ChainID,
 		requestID,
 		deadline,
 		appRequestBytes,
+		trace.MarshalSpanContext(ctx),
 	)
 
 	// Send the message over the network.
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 )
 
 var errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
@@ -49,6 +50,15 @@ type Config struct {
 	// TODO: Move this flag once the proposervm is configurable on a per-chain
 	// basis.
 	ProposerNumHistoricalBlocks uint64 `json:"proposerNumHistoricalBlocks" yaml:"proposerNumHistoricalBlocks"`
+	// ProposerWindowConfig overrides the number and duration of the snowman++
+	// proposer windows of this Subnet's chains for blocks whose parent is
+	// timestamped at or after its activation time. If nil, the default
+	// proposer windows are used. Windows must be a whole number of seconds,
+	// as block timestamps only have one second granularity.
+	//
+	// Invariant: Every node validating this Subnet must use the same value, or
+	// they will disagree on the validity of blocks.
+	ProposerWindowConfig *proposer.WindowConfig `json:"proposerWindowConfig" yaml:"proposerWindowConfig"`
 }
 
 func (c *Config) Valid() error {
@@ -58,5 +68,10 @@ func (c *Config) Valid() error {
 	if !c.ValidatorOnly && c.AllowedNodes.Len() > 0 {
 		return errAllowedNodesWhenNotValidatorOnly
 	}
+	if c.ProposerWindowConfig != nil {
+		if err := c.ProposerWindowConfig.Verify(); err != nil {
+			return fmt.Errorf("proposer window %w", err)
+		}
+	}
 	return nil
 }
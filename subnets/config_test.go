@@ -5,12 +5,14 @@ package subnets
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 )
 
 var validParameters = snowball.Parameters{
@@ -50,6 +52,46 @@ func TestValid(t *testing.T) {
 			},
 			expectedErr: errAllowedNodesWhenNotValidatorOnly,
 		},
+		{
+			name: "invalid proposer window count",
+			s: Config{
+				ConsensusParameters: validParameters,
+				ProposerWindowConfig: &proposer.WindowConfig{
+					Params: proposer.Params{
+						MaxWindows:     -1,
+						WindowDuration: time.Second,
+					},
+				},
+			},
+			expectedErr: proposer.ErrInvalidMaxWindows,
+		},
+		{
+			name: "invalid proposer window duration",
+			s: Config{
+				ConsensusParameters: validParameters,
+				ProposerWindowConfig: &proposer.WindowConfig{
+					Params: proposer.Params{
+						MaxWindows:     proposer.MaxVerifyWindows,
+						WindowDuration: 500 * time.Millisecond,
+					},
+				},
+			},
+			expectedErr: proposer.ErrInvalidWindowDuration,
+		},
+		{
+			name: "valid proposer window config",
+			s: Config{
+				ConsensusParameters: validParameters,
+				ProposerWindowConfig: &proposer.WindowConfig{
+					ActivationTime: time.Unix(1_000, 0),
+					Params: proposer.Params{
+						MaxWindows:     2,
+						WindowDuration: time.Second,
+					},
+				},
+			},
+			expectedErr: nil,
+		},
 		{
 			name: "valid",
 			s: Config{
@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// instrumentationName is the name of the tracer used to start spans from
+	// the span of a context.
+	instrumentationName = "github.com/ava-labs/avalanchego"
+
+	traceIDLen     = len(trace.TraceID{})
+	spanIDLen      = len(trace.SpanID{})
+	spanContextLen = traceIDLen + spanIDLen + 1 // trace flags
+)
+
+// StartFromContext starts a span that is a child of the span in [ctx], using
+// the tracer that created that span.
+//
+// This allows code that isn't given a Tracer to extend a trace. If [ctx]
+// doesn't contain a span, the returned span isn't recorded.
+func StartFromContext(
+	ctx context.Context,
+	spanName string,
+	opts ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(instrumentationName)
+	return tracer.Start(ctx, spanName, opts...)
+}
+
+// MarshalSpanContext returns the span context of the span in [ctx], so that it
+// can be sent to a peer. If [ctx] doesn't contain a valid span context, nil is
+// returned.
+//
+// The format is the trace ID, followed by the span ID, followed by the trace
+// flags.
+func MarshalSpanContext(ctx context.Context) []byte {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	traceID := spanContext.TraceID()
+	spanID := spanContext.SpanID()
+	b := make([]byte, 0, spanContextLen)
+	b = append(b, traceID[:]...)
+	b = append(b, spanID[:]...)
+	return append(b, byte(spanContext.TraceFlags()))
+}
+
+// ContextWithRemoteSpanContext returns a copy of [ctx] whose parent span is the
+// span of a peer, as marshalled by [MarshalSpanContext]. If [b] isn't a valid
+// span context, [ctx] is returned.
+func ContextWithRemoteSpanContext(ctx context.Context, b []byte) context.Context {
+	if len(b) != spanContextLen {
+		return ctx
+	}
+
+	var (
+		traceID trace.TraceID
+		spanID  trace.SpanID
+	)
+	copy(traceID[:], b[:traceIDLen])
+	copy(spanID[:], b[traceIDLen:traceIDLen+spanIDLen])
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.TraceFlags(b[spanContextLen-1]) & trace.FlagsSampled,
+		Remote:     true,
+	})
+	if !spanContext.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, spanContext)
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestStartFromContext(t *testing.T) {
+	require := require.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	ctx, parent := tp.Tracer("").Start(context.Background(), "parent")
+	_, child := StartFromContext(ctx, "child")
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(spans, 2)
+	require.Equal("child", spans[0].Name())
+	require.Equal(parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+
+	// Without a span in the context, nothing is recorded.
+	_, span := StartFromContext(context.Background(), "orphan")
+	require.False(span.IsRecording())
+	span.End()
+	require.Len(recorder.Ended(), 2)
+}
+
+func TestSpanContextMarshalling(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(MarshalSpanContext(context.Background()))
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := tp.Tracer("").Start(context.Background(), "request")
+	defer span.End()
+
+	b := MarshalSpanContext(ctx)
+	require.Len(b, spanContextLen)
+
+	remoteCtx := ContextWithRemoteSpanContext(context.Background(), b)
+	remote := trace.SpanContextFromContext(remoteCtx)
+	require.True(remote.IsRemote())
+	require.Equal(span.SpanContext().TraceID(), remote.TraceID())
+	require.Equal(span.SpanContext().SpanID(), remote.SpanID())
+	require.True(remote.IsSampled())
+}
+
+func TestContextWithInvalidRemoteSpanContext(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "wrong length",
+			b:    make([]byte, spanContextLen-1),
+		},
+		{
+			name: "zero ids",
+			b:    make([]byte, spanContextLen),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := ContextWithRemoteSpanContext(context.Background(), test.b)
+			require.False(t, trace.SpanContextFromContext(ctx).IsValid())
+		})
+	}
+}
+
+func TestRemoteSpanContextSampling(t *testing.T) {
+	tests := []struct {
+		name              string
+		sampleRate        float64
+		remoteSampled     bool
+		expectedRecording bool
+	}{
+		{
+			name:              "sampled peer with sample rate 0",
+			sampleRate:        0,
+			remoteSampled:     true,
+			expectedRecording: false,
+		},
+		{
+			name:              "unsampled peer with sample rate 1",
+			sampleRate:        1,
+			remoteSampled:     false,
+			expectedRecording: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var flags trace.TraceFlags
+			if test.remoteSampled {
+				flags = trace.FlagsSampled
+			}
+			peerSpanContext := trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    trace.TraceID{1},
+				SpanID:     trace.SpanID{2},
+				TraceFlags: flags,
+			})
+			b := MarshalSpanContext(trace.ContextWithSpanContext(context.Background(), peerSpanContext))
+
+			recorder := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(
+				sdktrace.WithSpanProcessor(recorder),
+				sdktrace.WithSampler(newSampler(test.sampleRate)),
+			)
+
+			ctx := ContextWithRemoteSpanContext(context.Background(), b)
+			ctx, span := tp.Tracer("").Start(ctx, "AppRequest")
+			require.Equal(test.expectedRecording, span.IsRecording())
+
+			// Local children follow the decision made for the peer's trace.
+			_, child := tp.Tracer("").Start(ctx, "handle")
+			require.Equal(test.expectedRecording, child.IsRecording())
+			child.End()
+			span.End()
+
+			if !test.expectedRecording {
+				require.Empty(recorder.Ended())
+			}
+		})
+	}
+}
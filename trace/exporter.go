@@ -19,7 +19,8 @@ const tracerProviderExportCreationTimeout = 5 * time.Second
 type ExporterConfig struct {
 	Type ExporterType `json:"type"`
 
-	// Endpoint to send metrics to
+	// Endpoint to send metrics to. For the file exporter, this is the path of
+	// the file that spans are appended to.
 	Endpoint string `json:"endpoint"`
 
 	// Headers to send with metrics
@@ -52,6 +53,10 @@ func newExporter(config ExporterConfig) (sdktrace.SpanExporter, error) {
 			opts = append(opts, otlptracehttp.WithInsecure())
 		}
 		client = otlptracehttp.NewClient(opts...)
+	case File:
+		return newFileExporter(config.Endpoint)
+	case Zipkin:
+		return newZipkinExporter(config), nil
 	default:
 		return nil, errUnknownExporterType
 	}
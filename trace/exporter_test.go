@@ -0,0 +1,177 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+func TestExporterTypeFromString(t *testing.T) {
+	for _, exporterType := range []ExporterType{GRPC, HTTP, File, Zipkin} {
+		parsed, err := ExporterTypeFromString(exporterType.String())
+		require.NoError(t, err)
+		require.Equal(t, exporterType, parsed)
+	}
+
+	_, err := ExporterTypeFromString("jaeger")
+	require.ErrorIs(t, err, errUnknownExporterType)
+}
+
+// recordSpans records a parent span with a failed child span using
+// [exporter].
+func recordSpans(t *testing.T, exporter sdktrace.SpanExporter) {
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			attribute.String("version", "v1.2.3"),
+			semconv.ServiceNameKey.String("avalanchego"),
+		)),
+	)
+	tracer := tp.Tracer("avalanchego")
+
+	ctx, parent := tracer.Start(context.Background(), "parent", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := tracer.Start(ctx, "child", trace.WithAttributes(attribute.Int("height", 5)))
+	child.AddEvent("verified")
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	parent.End()
+
+	require.NoError(t, tp.Shutdown(context.Background()))
+}
+
+func requireRecordedSpans(t *testing.T, spans []zipkinSpan) {
+	require := require.New(t)
+
+	require.Len(spans, 2)
+	child, parent := spans[0], spans[1]
+
+	require.Equal("parent", parent.Name)
+	require.Equal("SERVER", parent.Kind)
+	require.Empty(parent.ParentID)
+	require.Equal("avalanchego", parent.LocalEndpoint.ServiceName)
+	require.Equal(map[string]string{"version": "v1.2.3"}, parent.Tags)
+	require.Positive(parent.Duration)
+
+	require.Equal("child", child.Name)
+	require.Empty(child.Kind)
+	require.Equal(parent.TraceID, child.TraceID)
+	require.Equal(parent.ID, child.ParentID)
+	require.Equal(
+		map[string]string{
+			"version": "v1.2.3",
+			"height":  "5",
+			"error":   "failed",
+		},
+		child.Tags,
+	)
+	require.Len(child.Annotations, 1)
+	require.Equal("verified", child.Annotations[0].Value)
+}
+
+func TestFileExporter(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "spans.jsonl")
+	exporter, err := newExporter(ExporterConfig{
+		Type:     File,
+		Endpoint: path,
+	})
+	require.NoError(err)
+
+	recordSpans(t, exporter)
+
+	file, err := os.Open(path)
+	require.NoError(err)
+	defer file.Close()
+
+	var spans []zipkinSpan
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var span zipkinSpan
+		require.NoError(json.Unmarshal(scanner.Bytes(), &span))
+		spans = append(spans, span)
+	}
+	require.NoError(scanner.Err())
+	requireRecordedSpans(t, spans)
+}
+
+func TestZipkinExporter(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		requests []*http.Request
+		spans    []zipkinSpan
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []zipkinSpan
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, r)
+		spans = append(spans, batch...)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	exporter, err := newExporter(ExporterConfig{
+		Type:     Zipkin,
+		Endpoint: server.Listener.Addr().String(),
+		Headers: map[string]string{
+			"Authorization": "secret",
+		},
+		Insecure: true,
+	})
+	require.NoError(err)
+
+	recordSpans(t, exporter)
+	requireRecordedSpans(t, spans)
+
+	for _, r := range requests {
+		require.Equal(http.MethodPost, r.Method)
+		require.Equal(zipkinSpansPath, r.URL.Path)
+		require.Equal("application/json", r.Header.Get("Content-Type"))
+		require.Equal("secret", r.Header.Get("Authorization"))
+	}
+}
+
+func TestZipkinExporterUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	exporter := newZipkinExporter(ExporterConfig{
+		Endpoint: server.URL + zipkinSpansPath,
+	})
+	err := exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{
+		newTestSpan(),
+	})
+	require.ErrorIs(t, err, errUnexpectedZipkinStatus)
+}
+
+func newTestSpan() sdktrace.ReadOnlySpan {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	_, span := tp.Tracer("").Start(context.Background(), "test")
+	span.End()
+	return recorder.Ended()[0]
+}
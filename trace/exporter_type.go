@@ -12,6 +12,8 @@ import (
 const (
 	GRPC ExporterType = iota + 1
 	HTTP
+	File
+	Zipkin
 )
 
 var errUnknownExporterType = errors.New("unknown exporter type")
@@ -22,6 +24,10 @@ func ExporterTypeFromString(exporterTypeStr string) (ExporterType, error) {
 		return GRPC, nil
 	case HTTP.String():
 		return HTTP, nil
+	case File.String():
+		return File, nil
+	case Zipkin.String():
+		return Zipkin, nil
 	default:
 		return 0, fmt.Errorf("%w: %q", errUnknownExporterType, exporterTypeStr)
 	}
@@ -39,6 +45,10 @@ func (t ExporterType) String() string {
 		return "grpc"
 	case HTTP:
 		return "http"
+	case File:
+		return "file"
+	case Zipkin:
+		return "zipkin"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils/perms"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+var _ sdktrace.SpanExporter = (*fileExporter)(nil)
+
+// fileExporter appends spans to a file as JSON lines. Spans are written in the
+// Zipkin v2 format, so the file can be inspected directly or uploaded to a
+// Zipkin collector.
+type fileExporter struct {
+	lock    sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func newFileExporter(path string) (*fileExporter, error) {
+	file, err := os.OpenFile(
+		filepath.Clean(path),
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND,
+		perms.ReadWrite,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &fileExporter{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+func (e *fileExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for _, span := range spans {
+		if err := e.encoder.Encode(newZipkinSpan(span)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *fileExporter) Shutdown(context.Context) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.file.Close()
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+)
+
+var _ ReloadableTracer = (*reloadableTracer)(nil)
+
+// ReloadableTracer is a Tracer whose config can be replaced while it's in use.
+type ReloadableTracer interface {
+	Tracer
+
+	// Reload replaces the tracer with one created from [config]. Spans that
+	// were started before the call are exported by the previous tracer.
+	Reload(config Config) error
+}
+
+type reloadableTracer struct {
+	embedded.Tracer
+
+	lock   sync.RWMutex
+	config Config
+	tracer Tracer
+}
+
+func NewReloadable(config Config) (ReloadableTracer, error) {
+	tracer, err := New(config)
+	if err != nil {
+		return nil, err
+	}
+	return &reloadableTracer{
+		config: config,
+		tracer: tracer,
+	}, nil
+}
+
+func (r *reloadableTracer) Start(
+	ctx context.Context,
+	spanName string,
+	opts ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.tracer.Start(ctx, spanName, opts...)
+}
+
+func (r *reloadableTracer) Reload(config Config) error {
+	r.lock.Lock()
+	if reflect.DeepEqual(r.config, config) {
+		r.lock.Unlock()
+		return nil
+	}
+
+	tracer, err := New(config)
+	if err != nil {
+		r.lock.Unlock()
+		return err
+	}
+	oldTracer := r.tracer
+	r.config = config
+	r.tracer = tracer
+	r.lock.Unlock()
+
+	// Closing the previous tracer flushes its spans, which may take a while,
+	// so it's done without holding the lock.
+	return oldTracer.Close()
+}
+
+func (r *reloadableTracer) Close() error {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.tracer.Close()
+}
@@ -71,7 +71,7 @@ func New(config Config) (Tracer, error) {
 			attribute.String("version", config.Version),
 			semconv.ServiceNameKey.String(config.AppName),
 		)),
-		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(config.TraceSampleRate)),
+		sdktrace.WithSampler(newSampler(config.TraceSampleRate)),
 	}
 
 	tracerProvider := sdktrace.NewTracerProvider(tracerProviderOpts...)
@@ -80,3 +80,18 @@ func New(config Config) (Tracer, error) {
 		tp:     tracerProvider,
 	}, nil
 }
+
+// newSampler returns a sampler that samples [sampleRate] of the traces.
+//
+// Spans with a local parent follow the sampling decision of their parent.
+// Spans continuing the trace of a peer are sampled independently of the
+// peer's decision, so that peers can't force this node to record and export
+// their traces.
+func newSampler(sampleRate float64) sdktrace.Sampler {
+	ratio := sdktrace.TraceIDRatioBased(sampleRate)
+	return sdktrace.ParentBased(
+		ratio,
+		sdktrace.WithRemoteParentSampled(ratio),
+		sdktrace.WithRemoteParentNotSampled(ratio),
+	)
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// zipkinSpansPath is the path of the Zipkin v2 API that spans are reported to
+const zipkinSpansPath = "/api/v2/spans"
+
+var (
+	_ sdktrace.SpanExporter = (*zipkinExporter)(nil)
+
+	errUnexpectedZipkinStatus = errors.New("unexpected zipkin response status")
+)
+
+// zipkinSpan is a span in the Zipkin v2 JSON format.
+//
+// See https://zipkin.io/zipkin-api/#/default/post_spans
+type zipkinSpan struct {
+	TraceID       string             `json:"traceId"`
+	ID            string             `json:"id"`
+	ParentID      string             `json:"parentId,omitempty"`
+	Name          string             `json:"name"`
+	Kind          string             `json:"kind,omitempty"`
+	Timestamp     int64              `json:"timestamp"` // in microseconds since epoch
+	Duration      int64              `json:"duration"`  // in microseconds
+	LocalEndpoint zipkinEndpoint     `json:"localEndpoint"`
+	Annotations   []zipkinAnnotation `json:"annotations,omitempty"`
+	Tags          map[string]string  `json:"tags,omitempty"`
+}
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type zipkinAnnotation struct {
+	Timestamp int64  `json:"timestamp"` // in microseconds since epoch
+	Value     string `json:"value"`
+}
+
+func newZipkinSpan(span sdktrace.ReadOnlySpan) zipkinSpan {
+	spanContext := span.SpanContext()
+	z := zipkinSpan{
+		TraceID:   spanContext.TraceID().String(),
+		ID:        spanContext.SpanID().String(),
+		Name:      span.Name(),
+		Kind:      zipkinKind(span.SpanKind()),
+		Timestamp: span.StartTime().UnixMicro(),
+		// Zipkin treats a duration of 0 as unknown
+		Duration: max(span.EndTime().Sub(span.StartTime()).Microseconds(), 1),
+		Tags:     make(map[string]string),
+	}
+	if parent := span.Parent(); parent.HasSpanID() {
+		z.ParentID = parent.SpanID().String()
+	}
+
+	if resource := span.Resource(); resource != nil {
+		for _, attr := range resource.Attributes() {
+			if attr.Key == semconv.ServiceNameKey {
+				z.LocalEndpoint.ServiceName = attr.Value.Emit()
+				continue
+			}
+			z.Tags[string(attr.Key)] = attr.Value.Emit()
+		}
+	}
+	for _, attr := range span.Attributes() {
+		z.Tags[string(attr.Key)] = attr.Value.Emit()
+	}
+	if status := span.Status(); status.Code == codes.Error {
+		z.Tags["error"] = status.Description
+	}
+	if len(z.Tags) == 0 {
+		z.Tags = nil
+	}
+
+	for _, event := range span.Events() {
+		z.Annotations = append(z.Annotations, zipkinAnnotation{
+			Timestamp: event.Time.UnixMicro(),
+			Value:     event.Name,
+		})
+	}
+	return z
+}
+
+func zipkinKind(kind trace.SpanKind) string {
+	switch kind {
+	case trace.SpanKindClient:
+		return "CLIENT"
+	case trace.SpanKindServer:
+		return "SERVER"
+	case trace.SpanKindProducer:
+		return "PRODUCER"
+	case trace.SpanKindConsumer:
+		return "CONSUMER"
+	default:
+		// Internal spans don't have a kind in Zipkin
+		return ""
+	}
+}
+
+// zipkinExporter reports spans to a Zipkin collector over HTTP.
+type zipkinExporter struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// newZipkinExporter returns an exporter that reports spans to
+// [config.Endpoint]. If the endpoint isn't a URL, it's treated as the address
+// of the collector.
+func newZipkinExporter(config ExporterConfig) *zipkinExporter {
+	url := config.Endpoint
+	if !strings.Contains(url, "://") {
+		scheme := "https"
+		if config.Insecure {
+			scheme = "http"
+		}
+		url = scheme + "://" + url + zipkinSpansPath
+	}
+	return &zipkinExporter{
+		url:     url,
+		headers: config.Headers,
+		client: &http.Client{
+			Timeout: tracerExportTimeout,
+		},
+	}
+}
+
+func (e *zipkinExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	zipkinSpans := make([]zipkinSpan, len(spans))
+	for i, span := range spans {
+		zipkinSpans[i] = newZipkinSpan(span)
+	}
+	body, err := json.Marshal(zipkinSpans)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: %s", errUnexpectedZipkinStatus, resp.Status)
+	}
+	return nil
+}
+
+func (e *zipkinExporter) Shutdown(context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/snappy"
+)
+
+var _ Compressor = (*snappyCompressor)(nil)
+
+func NewSnappyCompressor(maxSize int64) (Compressor, error) {
+	if maxSize == math.MaxInt64 {
+		// Keep the same limits as the other compressors so that they are
+		// interchangeable.
+		return nil, ErrInvalidMaxSizeCompressor
+	}
+
+	return &snappyCompressor{
+		maxSize: maxSize,
+	}, nil
+}
+
+type snappyCompressor struct {
+	maxSize int64
+}
+
+func (s *snappyCompressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > s.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrMsgTooLarge, len(msg), s.maxSize)
+	}
+	return snappy.Encode(nil, msg), nil
+}
+
+func (s *snappyCompressor) Decompress(msg []byte) ([]byte, error) {
+	// The snappy block format is prefixed with the decompressed length, so the
+	// length can be checked before anything is allocated. [snappy.Decode]
+	// verifies that the decompressed payload matches the prefixed length.
+	decompressedLen, err := snappy.DecodedLen(msg)
+	if err != nil {
+		return nil, err
+	}
+	if int64(decompressedLen) > s.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrDecompressedMsgTooLarge, decompressedLen, s.maxSize)
+	}
+	return snappy.Decode(nil, msg)
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ips
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+)
+
+var _ DynamicIPPort = (*OverridableIPPort)(nil)
+
+// OverridableIPPort is a DynamicIPPort whose value can be overridden. Once
+// overridden, calls to SetIP are ignored so that automatic updates, such as
+// from NAT traversal or public IP resolution, don't replace the override.
+//
+// Safe for use by multiple goroutines.
+type OverridableIPPort struct {
+	lock       sync.RWMutex
+	ipPort     IPPort
+	overridden bool
+}
+
+func NewOverridableIPPort(ip net.IP, port uint16) *OverridableIPPort {
+	return &OverridableIPPort{
+		ipPort: IPPort{
+			IP:   ip,
+			Port: port,
+		},
+	}
+}
+
+func (i *OverridableIPPort) IPPort() IPPort {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.ipPort
+}
+
+// SetIP changes the IP, unless the IP has been overridden.
+func (i *OverridableIPPort) SetIP(ip net.IP) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if !i.overridden {
+		i.ipPort.IP = ip
+	}
+}
+
+// Override replaces the IP and port. Later calls to SetIP are ignored.
+func (i *OverridableIPPort) Override(ipPort IPPort) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.ipPort = ipPort
+	i.overridden = true
+}
+
+// Overridden returns true if Override has been called.
+func (i *OverridableIPPort) Overridden() bool {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.overridden
+}
+
+func (i *OverridableIPPort) MarshalJSON() ([]byte, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return json.Marshal(i.ipPort)
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ips
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverridableIPPort(t *testing.T) {
+	require := require.New(t)
+
+	ipPort := NewOverridableIPPort(net.IPv4(1, 2, 3, 4), 9651)
+	require.False(ipPort.Overridden())
+
+	ipPort.SetIP(net.IPv4(5, 6, 7, 8))
+	require.Equal("5.6.7.8:9651", ipPort.IPPort().String())
+
+	ipPort.Override(IPPort{
+		IP:   net.IPv4(9, 9, 9, 9),
+		Port: 9000,
+	})
+	require.True(ipPort.Overridden())
+	require.Equal("9.9.9.9:9000", ipPort.IPPort().String())
+
+	// Automatic updates don't replace the override
+	ipPort.SetIP(net.IPv4(5, 6, 7, 8))
+	require.Equal("9.9.9.9:9000", ipPort.IPPort().String())
+
+	b, err := ipPort.MarshalJSON()
+	require.NoError(err)
+	require.JSONEq(`{"ip":"9.9.9.9","port":9000}`, string(b))
+}
@@ -20,4 +20,8 @@ type Config struct {
 	LogFormat               Format `json:"logFormat"`
 	MsgPrefix               string `json:"-"`
 	LoggerName              string `json:"-"`
+	// Sinks are the remote outputs that loggers write to, in addition to the
+	// log file and stdout. Each sink should be verified before being passed to
+	// a factory.
+	Sinks []SinkConfig `json:"sinks"`
 }
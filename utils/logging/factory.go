@@ -37,6 +37,14 @@ type Factory interface {
 	// GetDisplayLevels returns all log display levels in factory as name, level pairs
 	GetDisplayLevel(name string) (Level, error)
 
+	// SetDefaultLogLevel sets the log level of loggers created after this
+	// call. Existing loggers aren't modified.
+	SetDefaultLogLevel(level Level)
+
+	// SetDefaultDisplayLevel sets the display level of loggers created after
+	// this call. Existing loggers aren't modified.
+	SetDefaultDisplayLevel(level Level)
+
 	// GetLoggerNames returns the names of all logs created by this factory
 	GetLoggerNames() []string
 
@@ -52,7 +60,9 @@ type logWrapper struct {
 
 type factory struct {
 	config Config
-	lock   sync.RWMutex
+	// sinks are shared by all the loggers of the factory
+	sinks []*sink
+	lock  sync.RWMutex
 
 	// For each logger created by this factory:
 	// Logger name --> the logger.
@@ -62,8 +72,13 @@ type factory struct {
 // NewFactory returns a new instance of a Factory producing loggers configured with
 // the values set in the [config] parameter
 func NewFactory(config Config) Factory {
+	sinks := make([]*sink, len(config.Sinks))
+	for i, sinkConfig := range config.Sinks {
+		sinks[i] = newSink(sinkConfig)
+	}
 	return &factory{
 		config:  config,
+		sinks:   sinks,
 		loggers: make(map[string]logWrapper),
 	}
 }
@@ -89,7 +104,14 @@ func (f *factory) makeLogger(config Config) (Logger, error) {
 	fileCore := NewWrappedCore(config.LogLevel, rw, fileEnc)
 	prefix := config.LogFormat.WrapPrefix(config.MsgPrefix)
 
-	l := NewLogger(prefix, consoleCore, fileCore)
+	cores := []WrappedCore{consoleCore, fileCore}
+	for _, s := range f.sinks {
+		if s.config.Includes(config.LoggerName) {
+			cores = append(cores, s.newCore(config.LoggerName))
+		}
+	}
+
+	l := NewLogger(prefix, cores...)
 	f.loggers[config.LoggerName] = logWrapper{
 		logger:       l,
 		displayLevel: consoleCore.AtomicLevel,
@@ -163,6 +185,20 @@ func (f *factory) GetDisplayLevel(name string) (Level, error) {
 	return Level(logger.displayLevel.Level()), nil
 }
 
+func (f *factory) SetDefaultLogLevel(level Level) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.config.LogLevel = level
+}
+
+func (f *factory) SetDefaultDisplayLevel(level Level) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.config.DisplayLevel = level
+}
+
 func (f *factory) GetLoggerNames() []string {
 	f.lock.RLock()
 	defer f.lock.RUnlock()
@@ -177,5 +213,8 @@ func (f *factory) Close() {
 	for _, lw := range f.loggers {
 		lw.logger.Stop()
 	}
+	for _, s := range f.sinks {
+		_ = s.close()
+	}
 	f.loggers = nil
 }
@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactorySetDefaultLevels(t *testing.T) {
+	require := require.New(t)
+
+	f := NewFactory(Config{
+		RotatingWriterConfig: RotatingWriterConfig{
+			Directory: t.TempDir(),
+		},
+		LogLevel:     Info,
+		DisplayLevel: Info,
+	})
+	defer f.Close()
+
+	_, err := f.Make("old")
+	require.NoError(err)
+
+	f.SetDefaultLogLevel(Debug)
+	f.SetDefaultDisplayLevel(Warn)
+
+	_, err = f.Make("new")
+	require.NoError(err)
+
+	// Existing loggers aren't modified
+	logLevel, err := f.GetLogLevel("old")
+	require.NoError(err)
+	require.Equal(Info, logLevel)
+	displayLevel, err := f.GetDisplayLevel("old")
+	require.NoError(err)
+	require.Equal(Info, displayLevel)
+
+	logLevel, err = f.GetLogLevel("new")
+	require.NoError(err)
+	require.Equal(Debug, logLevel)
+	displayLevel, err = f.GetDisplayLevel("new")
+	require.NoError(err)
+	require.Equal(Warn, displayLevel)
+}
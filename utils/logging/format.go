@@ -4,6 +4,7 @@
 package logging
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -81,6 +82,24 @@ func (f Format) MarshalJSON() ([]byte, error) {
 	}
 }
 
+func (f *Format) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return err
+	}
+	switch strings.ToUpper(str) {
+	case "PLAIN":
+		*f = Plain
+	case "COLORS":
+		*f = Colors
+	case "JSON":
+		*f = JSON
+	default:
+		return fmt.Errorf("%w: %q", errUnknownFormat, str)
+	}
+	return nil
+}
+
 func (f Format) WrapPrefix(prefix string) string {
 	if prefix == "" || f == JSON {
 		return prefix
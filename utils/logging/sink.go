@@ -0,0 +1,406 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// SyslogSink ships logs as RFC 5424 syslog messages over UDP, TCP or a
+	// unix socket.
+	SyslogSink SinkType = "syslog"
+	// JSONTCPSink ships logs as newline-delimited JSON over TCP.
+	JSONTCPSink SinkType = "json-tcp"
+
+	defaultSyslogAppName  = "avalanchego"
+	defaultSyslogFacility = 1 // user-level messages
+
+	maxSyslogFacility     = 23
+	maxSyslogAppNameLen   = 48
+	maxSyslogMsgIDLen     = 32
+	syslogTimestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+	sinkDialTimeout  = time.Second
+	sinkWriteTimeout = time.Second
+	sinkRedialDelay  = 5 * time.Second
+	// sinkQueueSize is the number of entries that can be waiting to be sent
+	// to a sink before new entries are dropped.
+	sinkQueueSize = 1024
+)
+
+var (
+	errUnknownSinkType     = errors.New("unknown sink type")
+	errUnknownSinkNetwork  = errors.New("unknown sink network")
+	errMissingSinkAddress  = errors.New("missing sink address")
+	errInvalidSinkFacility = errors.New("invalid syslog facility")
+	errInvalidSinkAppName  = errors.New("invalid syslog app name")
+
+	bufferPool = buffer.NewPool()
+
+	_ io.WriteCloser  = (*netWriter)(nil)
+	_ zapcore.Encoder = (*syslogEncoder)(nil)
+)
+
+// SinkType is the kind of remote output that a sink ships logs to.
+type SinkType string
+
+// SinkConfig defines an output, in addition to the log file and stdout, that
+// loggers write to.
+type SinkConfig struct {
+	Type SinkType `json:"type"`
+	// Network is one of "udp", "tcp", "unix" or "unixgram" for syslog sinks.
+	// JSON sinks always use "tcp".
+	Network string `json:"network"`
+	Address string `json:"address"`
+	// Level is the lowest level of the logs written to the sink.
+	Level Level `json:"level"`
+	// Format is the format of the message of syslog sinks. JSON sinks always
+	// use the JSON format.
+	Format Format `json:"format"`
+	// Loggers are the names of the loggers that write to the sink. If empty,
+	// every logger writes to the sink.
+	Loggers []string `json:"loggers"`
+	// Facility is the syslog facility of the messages. Kernel messages (0)
+	// can't be sent by the node, so 0 defaults to user-level messages.
+	Facility int `json:"facility"`
+	// AppName is the syslog APP-NAME of the messages. Defaults to
+	// "avalanchego".
+	AppName string `json:"appName"`
+}
+
+// Verify returns an error if the sink can't be created.
+func (c *SinkConfig) Verify() error {
+	if c.Address == "" {
+		return errMissingSinkAddress
+	}
+	switch c.Type {
+	case SyslogSink:
+		switch c.Network {
+		case "udp", "tcp", "unix", "unixgram":
+		default:
+			return fmt.Errorf("%w %q for %s sink", errUnknownSinkNetwork, c.Network, c.Type)
+		}
+		if c.Facility < 0 || c.Facility > maxSyslogFacility {
+			return fmt.Errorf("%w: %d", errInvalidSinkFacility, c.Facility)
+		}
+		if len(c.AppName) > maxSyslogAppNameLen || !isPrintableASCII(c.AppName) {
+			return fmt.Errorf("%w: %q", errInvalidSinkAppName, c.AppName)
+		}
+	case JSONTCPSink:
+		if c.Network != "" && c.Network != "tcp" {
+			return fmt.Errorf("%w %q for %s sink", errUnknownSinkNetwork, c.Network, c.Type)
+		}
+	default:
+		return fmt.Errorf("%w: %q", errUnknownSinkType, c.Type)
+	}
+	return nil
+}
+
+// Includes returns true if the logger named [loggerName] writes to the sink.
+func (c *SinkConfig) Includes(loggerName string) bool {
+	return len(c.Loggers) == 0 || slices.Contains(c.Loggers, loggerName)
+}
+
+// sink is a remote output shared by all the loggers of a factory.
+type sink struct {
+	config SinkConfig
+	writer *netWriter
+}
+
+// newSink assumes that [config] has been verified.
+func newSink(config SinkConfig) *sink {
+	network := config.Network
+	if network == "" {
+		network = "tcp"
+	}
+	// Stream connections need to delimit syslog messages, which is done with
+	// octet counting as described in RFC 6587. JSON messages are already
+	// delimited by newlines.
+	frame := config.Type == SyslogSink && (network == "tcp" || network == "unix")
+	writer := newNetWriter(network, config.Address, frame, sinkQueueSize)
+	go writer.run()
+	return &sink{
+		config: config,
+		writer: writer,
+	}
+}
+
+// newCore returns the core of the logger named [loggerName] for this sink.
+//
+// The writer of the returned core is disabled, because raw plugin logs can't
+// be written to the sink without being encoded.
+func (s *sink) newCore(loggerName string) WrappedCore {
+	var encoder zapcore.Encoder
+	switch s.config.Type {
+	case SyslogSink:
+		encoder = newSyslogEncoder(s.config, loggerName)
+	default:
+		encoder = JSON.FileEncoder()
+	}
+	core := NewWrappedCore(s.config.Level, nopCloser{s.writer}, encoder)
+	core.WriterDisabled = true
+	return core
+}
+
+func (s *sink) close() error {
+	return s.writer.Close()
+}
+
+// nopCloser prevents loggers from closing a sink that other loggers still
+// write to. Sinks are closed by the factory.
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// netWriter writes to a remote address, reconnecting as needed.
+//
+// Writes are queued and sent to the remote by [run], so that a slow or
+// unavailable sink never blocks logging. Writes are dropped while the queue is
+// full or the remote is unreachable.
+type netWriter struct {
+	network      string
+	address      string
+	octetCounted bool
+
+	queue     chan []byte
+	closing   chan struct{}
+	closeOnce sync.Once
+	// done is closed once [run] has returned
+	done chan struct{}
+
+	// The following fields are only accessed by [run].
+	conn net.Conn
+	// nextDial is the earliest time that a new connection will be attempted.
+	nextDial time.Time
+}
+
+// newNetWriter returns a writer that queues up to [queueSize] writes. [run]
+// must be called to send the queued writes.
+func newNetWriter(network, address string, octetCounted bool, queueSize int) *netWriter {
+	return &netWriter{
+		network:      network,
+		address:      address,
+		octetCounted: octetCounted,
+		queue:        make(chan []byte, queueSize),
+		closing:      make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+func (w *netWriter) Write(p []byte) (int, error) {
+	select {
+	case <-w.closing:
+		return 0, net.ErrClosed
+	default:
+	}
+
+	// [p] may be reused by the caller once Write returns.
+	select {
+	case w.queue <- slices.Clone(p):
+	default:
+	}
+	return len(p), nil
+}
+
+// run sends the queued writes until the writer is closed. Writes that are
+// still queued when the writer is closed are sent before returning, unless the
+// remote is unavailable.
+func (w *netWriter) run() {
+	defer close(w.done)
+
+	for {
+		select {
+		case p := <-w.queue:
+			w.send(p)
+		case <-w.closing:
+			for {
+				select {
+				case p := <-w.queue:
+					if err := w.send(p); err != nil {
+						return
+					}
+				default:
+					w.reset(time.Now())
+					return
+				}
+			}
+		}
+	}
+}
+
+// send writes [p] to the remote, connecting to it if needed.
+func (w *netWriter) send(p []byte) error {
+	now := time.Now()
+	if w.conn == nil {
+		if now.Before(w.nextDial) {
+			return nil
+		}
+
+		conn, err := net.DialTimeout(w.network, w.address, sinkDialTimeout)
+		if err != nil {
+			w.nextDial = now.Add(sinkRedialDelay)
+			return err
+		}
+		w.conn = conn
+	}
+
+	msg := p
+	if w.octetCounted {
+		msg = make([]byte, 0, len(p)+8)
+		msg = strconv.AppendInt(msg, int64(len(p)), 10)
+		msg = append(msg, ' ')
+		msg = append(msg, p...)
+	}
+
+	if err := w.conn.SetWriteDeadline(now.Add(sinkWriteTimeout)); err != nil {
+		w.reset(now)
+		return err
+	}
+	if _, err := w.conn.Write(msg); err != nil {
+		w.reset(now)
+		return err
+	}
+	return nil
+}
+
+// reset drops the current connection, if any.
+func (w *netWriter) reset(now time.Time) {
+	if w.conn == nil {
+		return
+	}
+	_ = w.conn.Close()
+	w.conn = nil
+	w.nextDial = now.Add(sinkRedialDelay)
+}
+
+// Close stops the writer once the queued writes have been sent.
+//
+// Assumes [run] was called.
+func (w *netWriter) Close() error {
+	w.closeOnce.Do(func() {
+		close(w.closing)
+	})
+	<-w.done
+	return nil
+}
+
+// syslogEncoder encodes entries as RFC 5424 syslog messages, whose MSG is
+// encoded by the wrapped encoder.
+type syslogEncoder struct {
+	zapcore.Encoder
+
+	facility int
+	hostname string
+	appName  string
+	procID   string
+	msgID    string
+}
+
+func newSyslogEncoder(config SinkConfig, loggerName string) *syslogEncoder {
+	facility := config.Facility
+	if facility == 0 {
+		facility = defaultSyslogFacility
+	}
+	appName := config.AppName
+	if appName == "" {
+		appName = defaultSyslogAppName
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	msgID := loggerName
+	if len(msgID) > maxSyslogMsgIDLen {
+		msgID = msgID[:maxSyslogMsgIDLen]
+	}
+	if msgID == "" || !isPrintableASCII(msgID) {
+		msgID = "-"
+	}
+	return &syslogEncoder{
+		Encoder:  config.Format.FileEncoder(),
+		facility: facility,
+		hostname: hostname,
+		appName:  appName,
+		procID:   strconv.Itoa(os.Getpid()),
+		msgID:    msgID,
+	}
+}
+
+func (e *syslogEncoder) Clone() zapcore.Encoder {
+	clone := *e
+	clone.Encoder = e.Encoder.Clone()
+	return &clone
+}
+
+func (e *syslogEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	msg, err := e.Encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return nil, err
+	}
+	defer msg.Free()
+
+	b := bufferPool.Get()
+	b.AppendByte('<')
+	b.AppendInt(int64(e.facility*8 + syslogSeverity(Level(entry.Level))))
+	b.AppendString(">1 ")
+	b.AppendString(entry.Time.UTC().Format(syslogTimestampFormat))
+	b.AppendByte(' ')
+	b.AppendString(e.hostname)
+	b.AppendByte(' ')
+	b.AppendString(e.appName)
+	b.AppendByte(' ')
+	b.AppendString(e.procID)
+	b.AppendByte(' ')
+	b.AppendString(e.msgID)
+	// There is no structured data
+	b.AppendString(" - ")
+
+	body := msg.Bytes()
+	for len(body) > 0 && body[len(body)-1] == '\n' {
+		body = body[:len(body)-1]
+	}
+	_, _ = b.Write(body)
+	return b, nil
+}
+
+// syslogSeverity returns the RFC 5424 severity of [level].
+func syslogSeverity(level Level) int {
+	switch {
+	case level >= Fatal:
+		return 2 // critical
+	case level >= Error:
+		return 3 // error
+	case level >= Warn:
+		return 4 // warning
+	case level >= Info:
+		return 6 // informational
+	default:
+		return 7 // debug
+	}
+}
+
+func isPrintableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 33 || s[i] > 126 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,271 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinkConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      SinkConfig
+		expectedErr error
+	}{
+		{
+			name: "valid syslog",
+			config: SinkConfig{
+				Type:    SyslogSink,
+				Network: "udp",
+				Address: "127.0.0.1:514",
+			},
+		},
+		{
+			name: "valid json",
+			config: SinkConfig{
+				Type:    JSONTCPSink,
+				Address: "127.0.0.1:5170",
+			},
+		},
+		{
+			name: "missing address",
+			config: SinkConfig{
+				Type:    SyslogSink,
+				Network: "udp",
+			},
+			expectedErr: errMissingSinkAddress,
+		},
+		{
+			name: "unknown type",
+			config: SinkConfig{
+				Type:    "journald",
+				Address: "127.0.0.1:514",
+			},
+			expectedErr: errUnknownSinkType,
+		},
+		{
+			name: "unknown syslog network",
+			config: SinkConfig{
+				Type:    SyslogSink,
+				Address: "127.0.0.1:514",
+			},
+			expectedErr: errUnknownSinkNetwork,
+		},
+		{
+			name: "unknown json network",
+			config: SinkConfig{
+				Type:    JSONTCPSink,
+				Network: "udp",
+				Address: "127.0.0.1:5170",
+			},
+			expectedErr: errUnknownSinkNetwork,
+		},
+		{
+			name: "invalid facility",
+			config: SinkConfig{
+				Type:     SyslogSink,
+				Network:  "udp",
+				Address:  "127.0.0.1:514",
+				Facility: maxSyslogFacility + 1,
+			},
+			expectedErr: errInvalidSinkFacility,
+		},
+		{
+			name: "invalid app name",
+			config: SinkConfig{
+				Type:    SyslogSink,
+				Network: "udp",
+				Address: "127.0.0.1:514",
+				AppName: "avalanche go",
+			},
+			expectedErr: errInvalidSinkAppName,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestSinkConfigIncludes(t *testing.T) {
+	require := require.New(t)
+
+	config := SinkConfig{}
+	require.True(config.Includes("main"))
+
+	config.Loggers = []string{"main"}
+	require.True(config.Includes("main"))
+	require.False(config.Includes("P"))
+}
+
+func TestSyslogSinkUDP(t *testing.T) {
+	require := require.New(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+	defer conn.Close()
+
+	f := NewFactory(Config{
+		RotatingWriterConfig: RotatingWriterConfig{
+			Directory: t.TempDir(),
+		},
+		LogLevel:     Off,
+		DisplayLevel: Off,
+		Sinks: []SinkConfig{
+			{
+				Type:     SyslogSink,
+				Network:  "udp",
+				Address:  conn.LocalAddr().String(),
+				Level:    Warn,
+				Facility: 16,
+				AppName:  "test",
+			},
+		},
+	})
+	defer f.Close()
+
+	log, err := f.Make("main")
+	require.NoError(err)
+
+	log.Info("dropped")
+	log.Error("shipped")
+
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(err)
+
+	// local0 (16) * 8 + error (3) = 131
+	pattern := `^<131>1 \S+ \S+ test ` + strconv.Itoa(os.Getpid()) + ` main - .*ERROR.*shipped$`
+	require.Regexp(regexp.MustCompile(pattern), string(buf[:n]))
+}
+
+func TestSyslogSinkTCPFraming(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	s := newSink(SinkConfig{
+		Type:    SyslogSink,
+		Network: "tcp",
+		Address: listener.Addr().String(),
+	})
+	defer s.close()
+
+	_, err = s.writer.Write([]byte("hello"))
+	require.NoError(err)
+
+	conn, err := listener.Accept()
+	require.NoError(err)
+	defer conn.Close()
+
+	buf := make([]byte, len("5 hello"))
+	_, err = conn.Read(buf)
+	require.NoError(err)
+	require.Equal("5 hello", string(buf))
+}
+
+func TestJSONTCPSink(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	f := NewFactory(Config{
+		RotatingWriterConfig: RotatingWriterConfig{
+			Directory: t.TempDir(),
+		},
+		LogLevel:     Off,
+		DisplayLevel: Off,
+		Sinks: []SinkConfig{
+			{
+				Type:    JSONTCPSink,
+				Address: listener.Addr().String(),
+				Level:   Info,
+				Loggers: []string{"P"},
+			},
+		},
+	})
+	defer f.Close()
+
+	mainLog, err := f.Make("main")
+	require.NoError(err)
+	chainLog, err := f.MakeChain("P")
+	require.NoError(err)
+
+	mainLog.Info("not shipped")
+	chainLog.Info("shipped")
+
+	conn, err := listener.Accept()
+	require.NoError(err)
+	defer conn.Close()
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	require.NoError(err)
+
+	var entry map[string]any
+	require.NoError(json.Unmarshal(line, &entry))
+	require.Equal("info", entry["level"])
+	require.Equal("shipped", entry["msg"])
+	require.Equal("<P Chain>", entry["logger"])
+}
+
+func TestSinkUnreachable(t *testing.T) {
+	require := require.New(t)
+
+	s := newSink(SinkConfig{
+		Type:    SyslogSink,
+		Network: "unix",
+		Address: filepath.Join(t.TempDir(), "missing.sock"),
+	})
+
+	// Writes are dropped by the background goroutine, rather than reported to
+	// the logger.
+	for i := 0; i < 2; i++ {
+		n, err := s.writer.Write([]byte("hello"))
+		require.NoError(err)
+		require.Equal(len("hello"), n)
+	}
+
+	require.NoError(s.close())
+
+	_, err := s.writer.Write([]byte("hello"))
+	require.ErrorIs(err, net.ErrClosed)
+}
+
+func TestNetWriterQueueFull(t *testing.T) {
+	require := require.New(t)
+
+	// The queue isn't drained, because run isn't called.
+	w := newNetWriter("tcp", "127.0.0.1:0", false, 1)
+
+	msg := []byte("first")
+	n, err := w.Write(msg)
+	require.NoError(err)
+	require.Equal(len(msg), n)
+
+	// The queued write must not be affected by the caller reusing [msg].
+	copy(msg, "reuse")
+
+	// Writes are dropped, rather than blocking, while the queue is full.
+	n, err = w.Write([]byte("second"))
+	require.NoError(err)
+	require.Equal(len("second"), n)
+
+	require.Len(w.queue, 1)
+	require.Equal([]byte("first"), <-w.queue)
+}
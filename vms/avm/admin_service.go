@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api"
+)
+
+var (
+	errTxNotInMempool = errors.New("tx not in mempool")
+	errRemovedByAdmin = errors.New("removed from the mempool over the admin API")
+)
+
+// AdminService defines the admin API calls that can be made to the asset vm.
+// It is only served if the admin API is enabled in the chain config.
+type AdminService struct {
+	vm *VM
+}
+
+// RemoveMempoolTx removes the tx from the mempool. The tx is marked as dropped
+// so that it isn't re-added to the mempool by gossip.
+func (a *AdminService) RemoveMempoolTx(_ *http.Request, args *api.JSONTxID, _ *api.EmptyReply) error {
+	a.vm.ctx.Log.Info("admin API called",
+		zap.String("service", "avm.admin"),
+		zap.String("method", "removeMempoolTx"),
+		zap.Stringer("txID", args.TxID),
+	)
+
+	a.vm.ctx.Lock.Lock()
+	defer a.vm.ctx.Lock.Unlock()
+
+	if a.vm.mempool == nil {
+		return errNotLinearized
+	}
+
+	tx, ok := a.vm.mempool.Get(args.TxID)
+	if !ok {
+		return fmt.Errorf("%w: %s", errTxNotInMempool, args.TxID)
+	}
+
+	a.vm.mempool.Remove(tx)
+	a.vm.mempool.MarkDropped(args.TxID, errRemovedByAdmin)
+	return nil
+}
@@ -20,9 +20,11 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/avm/block"
 	"github.com/ava-labs/avalanchego/vms/avm/fxs"
@@ -32,6 +34,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/txs/mempool/mempooltest"
 
 	blkexecutor "github.com/ava-labs/avalanchego/vms/avm/block/executor"
 	txexecutor "github.com/ava-labs/avalanchego/vms/avm/txs/executor"
@@ -495,6 +498,200 @@ func TestBuilderBuildBlock(t *testing.T) {
 	}
 }
 
+func TestBuilderBuildBlockFeePriority(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	preferredID := ids.GenerateTestID()
+	preferredHeight := uint64(1337)
+	preferredTimestamp := time.Now()
+	preferredBlock := block.NewMockBlock(ctrl)
+	preferredBlock.EXPECT().Height().Return(preferredHeight)
+	preferredBlock.EXPECT().Timestamp().Return(preferredTimestamp)
+
+	preferredState := state.NewMockChain(ctrl)
+	preferredState.EXPECT().GetLastAccepted().Return(preferredID)
+	preferredState.EXPECT().GetTimestamp().Return(preferredTimestamp)
+
+	feeAssetID := ids.GenerateTestID()
+	mempool, err := mempool.NewWithFeePriority("mempool", prometheus.NewRegistry(), nil, feeAssetID)
+	require.NoError(err)
+
+	// executed is the set of txs that have been executed into the block.
+	var executed set.Set[ids.ID]
+
+	// The child tx pays the highest fee, but must be issued after its parent,
+	// which pays the lowest fee.
+	var (
+		lowFeeTx  = newFeeTx(ctrl, feeAssetID, &executed, ids.Empty, 32, 1)
+		highFeeTx = newFeeTx(ctrl, feeAssetID, &executed, ids.Empty, 32, 2)
+		childTx   = newFeeTx(ctrl, feeAssetID, &executed, lowFeeTx.ID(), 32, 3)
+	)
+	require.NoError(mempool.Add(childTx))
+	require.NoError(mempool.Add(lowFeeTx))
+	require.NoError(mempool.Add(highFeeTx))
+
+	manager := blkexecutor.NewMockManager(ctrl)
+	manager.EXPECT().Preferred().Return(preferredID)
+	manager.EXPECT().GetStatelessBlock(preferredID).Return(preferredBlock, nil)
+	manager.EXPECT().GetState(preferredID).Return(preferredState, true).AnyTimes()
+	manager.EXPECT().VerifyUniqueInputs(preferredID, gomock.Any()).Return(nil).Times(3)
+	// Assert that the txs are ordered by fee, after their parents
+	manager.EXPECT().NewBlock(gomock.Any()).DoAndReturn(
+		func(block *block.StandardBlock) snowman.Block {
+			require.Equal([]*txs.Tx{highFeeTx, lowFeeTx, childTx}, block.Transactions)
+			return nil
+		},
+	)
+
+	// To marshal the tx/block
+	codec := codec.NewMockManager(ctrl)
+	codec.EXPECT().Marshal(gomock.Any(), gomock.Any()).Return([]byte{1, 2, 3}, nil).AnyTimes()
+	codec.EXPECT().Size(gomock.Any(), gomock.Any()).Return(2, nil).AnyTimes()
+
+	builder := New(
+		&txexecutor.Backend{
+			Codec: codec,
+			Ctx: &snow.Context{
+				Log: logging.NoLog{},
+			},
+		},
+		manager,
+		&mockable.Clock{},
+		mempool,
+	)
+	_, err = builder.BuildBlock(context.Background())
+	require.NoError(err)
+	require.Zero(mempool.Len())
+}
+
+func TestBuilderBuildBlockFeePriorityEviction(t *testing.T) {
+	tests := []struct {
+		name string
+		// addTxs fills the mempool and then adds a tx that requires txs to be
+		// evicted. It returns the txs that should have been evicted.
+		addTxs func(
+			require *require.Assertions,
+			pool mempool.Mempool,
+			newTx func(parentID ids.ID, feePerByte uint64) *txs.Tx,
+		) []*txs.Tx
+	}{
+		{
+			name: "evicted tx is evicted with its descendants",
+			addTxs: func(
+				require *require.Assertions,
+				pool mempool.Mempool,
+				newTx func(parentID ids.ID, feePerByte uint64) *txs.Tx,
+			) []*txs.Tx {
+				fillers := fillMempool(require, pool, newTx)
+				pool.Remove(fillers[len(fillers)-2:]...)
+
+				// The child tx can't be issued once its parent is evicted.
+				var (
+					parentTx = newTx(ids.Empty, 1)
+					childTx  = newTx(parentTx.ID(), 10)
+				)
+				require.NoError(pool.Add(parentTx))
+				require.NoError(pool.Add(childTx))
+				require.NoError(pool.Add(newTx(ids.Empty, 3)))
+				return []*txs.Tx{parentTx, childTx}
+			},
+		},
+		{
+			name: "ancestors of the new tx are not evicted",
+			addTxs: func(
+				require *require.Assertions,
+				pool mempool.Mempool,
+				newTx func(parentID ids.ID, feePerByte uint64) *txs.Tx,
+			) []*txs.Tx {
+				fillers := fillMempool(require, pool, newTx)
+				pool.Remove(fillers[len(fillers)-1])
+
+				// The parent tx pays the lowest fee, but the child tx can't be
+				// issued without it.
+				parentTx := newTx(ids.Empty, 1)
+				require.NoError(pool.Add(parentTx))
+				require.NoError(pool.Add(newTx(parentTx.ID(), 10)))
+				return []*txs.Tx{fillers[len(fillers)-2]}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			feeAssetID := ids.GenerateTestID()
+			pool, err := mempool.NewWithFeePriority("mempool", prometheus.NewRegistry(), nil, feeAssetID)
+			require.NoError(err)
+
+			// executed is the set of txs that have been executed into blocks.
+			var executed set.Set[ids.ID]
+			newTx := func(parentID ids.ID, feePerByte uint64) *txs.Tx {
+				return newFeeTx(ctrl, feeAssetID, &executed, parentID, mempool.MaxTxSize, feePerByte*mempool.MaxTxSize)
+			}
+
+			evictedTxs := test.addTxs(require, pool, newTx)
+			for _, tx := range evictedTxs {
+				err := pool.GetDropReason(tx.ID())
+				require.ErrorIs(err, mempool.ErrEvicted)
+			}
+
+			preferredID := ids.GenerateTestID()
+			preferredBlock := block.NewMockBlock(ctrl)
+			preferredBlock.EXPECT().Height().Return(uint64(1337)).AnyTimes()
+			preferredBlock.EXPECT().Timestamp().Return(time.Now()).AnyTimes()
+
+			preferredState := state.NewMockChain(ctrl)
+			preferredState.EXPECT().GetLastAccepted().Return(preferredID).AnyTimes()
+			preferredState.EXPECT().GetTimestamp().Return(time.Now()).AnyTimes()
+
+			// Every tx left in the mempool should be built into a block, after
+			// the txs it depends on.
+			var (
+				expectedTxs = pool.Len()
+				builtTxs    []*txs.Tx
+			)
+			manager := blkexecutor.NewMockManager(ctrl)
+			manager.EXPECT().Preferred().Return(preferredID).AnyTimes()
+			manager.EXPECT().GetStatelessBlock(preferredID).Return(preferredBlock, nil).AnyTimes()
+			manager.EXPECT().GetState(preferredID).Return(preferredState, true).AnyTimes()
+			manager.EXPECT().VerifyUniqueInputs(preferredID, gomock.Any()).Return(nil).AnyTimes()
+			manager.EXPECT().NewBlock(gomock.Any()).DoAndReturn(
+				func(block *block.StandardBlock) snowman.Block {
+					builtTxs = append(builtTxs, block.Transactions...)
+					return nil
+				},
+			).AnyTimes()
+
+			// To marshal the tx/block
+			codec := codec.NewMockManager(ctrl)
+			codec.EXPECT().Marshal(gomock.Any(), gomock.Any()).Return([]byte{1, 2, 3}, nil).AnyTimes()
+			codec.EXPECT().Size(gomock.Any(), gomock.Any()).Return(2, nil).AnyTimes()
+
+			builder := New(
+				&txexecutor.Backend{
+					Codec: codec,
+					Ctx: &snow.Context{
+						Log: logging.NoLog{},
+					},
+				},
+				manager,
+				&mockable.Clock{},
+				pool,
+			)
+			for pool.Len() > 0 {
+				_, err := builder.BuildBlock(context.Background())
+				require.NoError(err)
+			}
+			require.Len(builtTxs, expectedTxs)
+			for _, tx := range evictedTxs {
+				require.NotContains(builtTxs, tx)
+			}
+		})
+	}
+}
+
 func TestBlockBuilderAddLocalTx(t *testing.T) {
 	transactions := createTxs()
 
@@ -634,3 +831,67 @@ func createParentTxs(cm codec.Manager) ([]*txs.Tx, error) {
 	}
 	return testTxs, nil
 }
+
+// newFeeTx returns a tx of [size] bytes that burns [fee] of [feeAssetID] by
+// consuming an output of [parentID]. The tx only passes verification once
+// [parentID] has been added to [executed], unless [parentID] is empty.
+// Executing the tx adds it to [executed].
+func newFeeTx(
+	ctrl *gomock.Controller,
+	feeAssetID ids.ID,
+	executed *set.Set[ids.ID],
+	parentID ids.ID,
+	size int,
+	fee uint64,
+) *txs.Tx {
+	inputID := ids.GenerateTestID()
+	baseTx := &txs.BaseTx{
+		BaseTx: mempooltest.NewBaseTx(feeAssetID, parentID, 0, fee),
+	}
+
+	tx := &txs.Tx{}
+	unsignedTx := txs.NewMockUnsignedTx(ctrl)
+	unsignedTx.EXPECT().Visit(gomock.Any()).DoAndReturn(
+		func(visitor txs.Visitor) error {
+			switch visitor := visitor.(type) {
+			case *txexecutor.SemanticVerifier:
+				if parentID != ids.Empty && !executed.Contains(parentID) {
+					return errTest // The consumed UTXO doesn't exist yet
+				}
+				return nil
+			case *txexecutor.Executor:
+				visitor.Inputs.Add(inputID)
+				executed.Add(tx.ID())
+				return nil
+			default:
+				// Calculate the fee of the tx
+				return visitor.BaseTx(baseTx)
+			}
+		},
+	).AnyTimes()
+	unsignedTx.EXPECT().InputIDs().Return(set.Of(inputID)).AnyTimes()
+	unsignedTx.EXPECT().SetBytes(gomock.Any()).AnyTimes()
+
+	tx.Unsigned = unsignedTx
+	tx.SetBytes(utils.RandomBytes(32), utils.RandomBytes(size))
+	return tx
+}
+
+// fillMempool adds txs paying 2 per byte to [pool] until it is full and
+// returns the added txs.
+func fillMempool(
+	require *require.Assertions,
+	pool mempool.Mempool,
+	newTx func(parentID ids.ID, feePerByte uint64) *txs.Tx,
+) []*txs.Tx {
+	var added []*txs.Tx
+	for {
+		tx := newTx(ids.Empty, 2)
+		err := pool.Add(tx)
+		if errors.Is(err, mempool.ErrMempoolFull) {
+			return added
+		}
+		require.NoError(err)
+		added = append(added, tx)
+	}
+}
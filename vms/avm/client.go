@@ -30,6 +30,9 @@ type Client interface {
 	GetBlockByHeight(ctx context.Context, height uint64, options ...rpc.Option) ([]byte, error)
 	// GetHeight returns the height of the last accepted block.
 	GetHeight(ctx context.Context, options ...rpc.Option) (uint64, error)
+	// GetMempool returns the txs that are pending in the mempool and the txs
+	// that were recently dropped from the mempool
+	GetMempool(ctx context.Context, options ...rpc.Option) (*api.GetMempoolReply, error)
 	// GetTxStatus returns the status of [txID]
 	//
 	// Deprecated: GetTxStatus only returns Accepted or Unknown, GetTx should be
@@ -277,6 +280,12 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) GetMempool(ctx context.Context, options ...rpc.Option) (*api.GetMempoolReply, error) {
+	res := &api.GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "avm.getMempool", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (choices.Status, error) {
 	res := &GetTxStatusReply{}
 	err := c.requester.SendRequest(ctx, "avm.getTxStatus", &api.JSONTxID{
@@ -14,6 +14,8 @@ var DefaultConfig = Config{
 	IndexTransactions:    false,
 	IndexAllowIncomplete: false,
 	ChecksumsEnabled:     false,
+	AdminAPIEnabled:      false,
+	MempoolFeePriority:   false,
 }
 
 type Config struct {
@@ -21,6 +23,8 @@ type Config struct {
 	IndexTransactions    bool           `json:"index-transactions"`
 	IndexAllowIncomplete bool           `json:"index-allow-incomplete"`
 	ChecksumsEnabled     bool           `json:"checksums-enabled"`
+	AdminAPIEnabled      bool           `json:"admin-api-enabled"`
+	MempoolFeePriority   bool           `json:"mempool-fee-priority"`
 }
 
 func ParseConfig(configBytes []byte) (Config, error) {
@@ -33,6 +33,29 @@ func TestParseConfig(t *testing.T) {
 				ChecksumsEnabled:     true,
 			},
 		},
+		{
+			name:        "manually specified admin api enabled",
+			configBytes: []byte(`{"admin-api-enabled":true}`),
+			expectedConfig: Config{
+				Network:              network.DefaultConfig,
+				IndexTransactions:    DefaultConfig.IndexTransactions,
+				IndexAllowIncomplete: DefaultConfig.IndexAllowIncomplete,
+				ChecksumsEnabled:     DefaultConfig.ChecksumsEnabled,
+				AdminAPIEnabled:      true,
+			},
+		},
+		{
+			name:        "manually specified mempool fee priority",
+			configBytes: []byte(`{"mempool-fee-priority":true}`),
+			expectedConfig: Config{
+				Network:              network.DefaultConfig,
+				IndexTransactions:    DefaultConfig.IndexTransactions,
+				IndexAllowIncomplete: DefaultConfig.IndexAllowIncomplete,
+				ChecksumsEnabled:     DefaultConfig.ChecksumsEnabled,
+				AdminAPIEnabled:      DefaultConfig.AdminAPIEnabled,
+				MempoolFeePriority:   true,
+			},
+		},
 		{
 			name:        "manually specified network value",
 			configBytes: []byte(`{"network":{"max-validator-set-staleness":1}}`),
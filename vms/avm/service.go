@@ -296,6 +296,46 @@ func (s *Service) GetAddressTxs(_ *http.Request, args *GetAddressTxsArgs, reply
 	return nil
 }
 
+// GetMempool returns the txs that are pending in the mempool, the txs that
+// were recently dropped from the mempool and the space used by the mempool.
+func (s *Service) GetMempool(_ *http.Request, _ *struct{}, reply *api.GetMempoolReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "avm"),
+		zap.String("method", "getMempool"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	mempool := s.vm.mempool
+	s.vm.ctx.Lock.Unlock()
+
+	if mempool == nil {
+		return errNotLinearized
+	}
+
+	reply.Txs = []api.MempoolTx{}
+	mempool.Iterate(func(tx *txs.Tx) bool {
+		size := avajson.Uint64(len(tx.Bytes()))
+		reply.Txs = append(reply.Txs, api.MempoolTx{
+			TxID: tx.ID(),
+			Size: size,
+		})
+		reply.Bytes += size
+		return true
+	})
+
+	reply.DroppedTxs = []api.DroppedTx{}
+	mempool.IterateDropped(func(txID ids.ID, reason error) bool {
+		reply.DroppedTxs = append(reply.DroppedTxs, api.DroppedTx{
+			TxID:   txID,
+			Reason: reason.Error(),
+		})
+		return true
+	})
+
+	reply.BytesAvailable = avajson.Uint64(mempool.BytesAvailable())
+	return nil
+}
+
 // GetTxStatus returns the status of the specified transaction
 //
 // Deprecated: GetTxStatus only returns Accepted or Unknown, GetTx should be
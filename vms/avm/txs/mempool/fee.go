@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"github.com/ava-labs/avalanchego/vms/avm/txs"
+
+	txmempool "github.com/ava-labs/avalanchego/vms/txs/mempool"
+)
+
+var _ txs.Visitor = (*feeCalculator)(nil)
+
+// feeCalculator calculates the amount of [AssetID] that a tx burns.
+type feeCalculator struct {
+	txmempool.FeeCalculator
+}
+
+func (c *feeCalculator) BaseTx(tx *txs.BaseTx) error {
+	if err := c.Consume(tx.Ins); err != nil {
+		return err
+	}
+	return c.Produce(tx.Outs)
+}
+
+func (c *feeCalculator) CreateAssetTx(tx *txs.CreateAssetTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *feeCalculator) OperationTx(tx *txs.OperationTx) error {
+	for _, op := range tx.Ops {
+		for _, utxoID := range op.UTXOIDs {
+			c.Parents.Add(utxoID.TxID)
+		}
+	}
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *feeCalculator) ImportTx(tx *txs.ImportTx) error {
+	if err := c.BaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	return c.Consume(tx.ImportedIns)
+}
+
+func (c *feeCalculator) ExportTx(tx *txs.ExportTx) error {
+	if err := c.BaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	return c.Produce(tx.ExportedOuts)
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -15,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/txs/mempool/mempooltest"
 )
 
 func TestAdd(t *testing.T) {
@@ -110,12 +112,14 @@ func TestGet(t *testing.T) {
 	require.False(exists)
 
 	require.NoError(mempool.Add(tx))
+	require.Equal(maxMempoolSize-len(tx.Bytes()), mempool.BytesAvailable())
 
 	returned, exists := mempool.Get(txID)
 	require.True(exists)
 	require.Equal(tx, returned)
 
 	mempool.Remove(tx)
+	require.Equal(maxMempoolSize, mempool.BytesAvailable())
 
 	_, exists = mempool.Get(txID)
 	require.False(exists)
@@ -316,3 +320,113 @@ func newTx(index uint32, size int) *txs.Tx {
 	tx.SetBytes(utils.RandomBytes(size), utils.RandomBytes(size))
 	return tx
 }
+
+func TestIterateDropped(t *testing.T) {
+	require := require.New(t)
+
+	mempool, err := New("mempool", prometheus.NewRegistry(), nil)
+	require.NoError(err)
+
+	var (
+		testErr = errors.New("test")
+		txIDs   = make([]ids.ID, droppedTxIDsCacheSize+1)
+	)
+	for i := range txIDs {
+		txIDs[i] = ids.GenerateTestID()
+		mempool.MarkDropped(txIDs[i], testErr)
+	}
+
+	// The least recently dropped tx should have been evicted.
+	require.NoError(mempool.GetDropReason(txIDs[0]))
+
+	// Dropping a tx again marks it as the most recently dropped tx.
+	mempool.MarkDropped(txIDs[1], testErr)
+	expectedTxIDs := append(txIDs[2:], txIDs[1])
+
+	var droppedTxIDs []ids.ID
+	mempool.IterateDropped(func(txID ids.ID, reason error) bool {
+		require.ErrorIs(reason, testErr)
+		droppedTxIDs = append(droppedTxIDs, txID)
+		return true
+	})
+	require.Equal(expectedTxIDs, droppedTxIDs)
+}
+
+func TestFeePriority(t *testing.T) {
+	require := require.New(t)
+
+	assetID := ids.GenerateTestID()
+	mempool, err := NewWithFeePriority("mempool", prometheus.NewRegistry(), nil, assetID)
+	require.NoError(err)
+
+	// The tx paying the highest fee per byte should be peeked first, regardless
+	// of insertion order. A tx consuming the outputs of another tx in the
+	// mempool must be peeked after it, regardless of the fee it pays.
+	var (
+		lowFeeTx    = newFeeTx(assetID, ids.Empty, 0, 1000, 1000)
+		highFeeTx   = newFeeTx(assetID, ids.Empty, 1, 1000, 3000)
+		mediumFeeTx = newFeeTx(assetID, ids.Empty, 2, 2000, 4000)
+		childTx     = newFeeTx(assetID, lowFeeTx.ID(), 0, 1000, 5000)
+	)
+	for _, tx := range []*txs.Tx{childTx, lowFeeTx, highFeeTx, mediumFeeTx} {
+		require.NoError(mempool.Add(tx))
+	}
+	for _, expectedTx := range []*txs.Tx{highFeeTx, mediumFeeTx, lowFeeTx, childTx} {
+		tx, exists := mempool.Peek()
+		require.True(exists)
+		require.Equal(expectedTx, tx)
+		mempool.Remove(tx)
+	}
+
+	_, exists := mempool.Peek()
+	require.False(exists)
+}
+
+func TestFeePriorityEviction(t *testing.T) {
+	require := require.New(t)
+
+	assetID := ids.GenerateTestID()
+	registerer := prometheus.NewRegistry()
+	mempoolIntf, err := NewWithFeePriority("mempool", registerer, nil, assetID)
+	require.NoError(err)
+	mempool := mempoolIntf.(*mempool)
+
+	numTxs := maxMempoolSize / MaxTxSize
+	initialTxs := make([]*txs.Tx, numTxs)
+	for i := range initialTxs {
+		initialTxs[i] = newFeeTx(assetID, ids.Empty, uint32(i), MaxTxSize, 1)
+		require.NoError(mempool.Add(initialTxs[i]))
+	}
+
+	// A tx that pays the same fee per byte must not evict any txs.
+	err = mempool.Add(newFeeTx(assetID, ids.Empty, uint32(numTxs), MaxTxSize, 1))
+	require.ErrorIs(err, ErrMempoolFull)
+	require.Equal(numTxs, mempool.Len())
+
+	// A tx that pays a higher fee per byte should evict the most recently
+	// added of the txs paying the lowest fee per byte.
+	highFeeTx := newFeeTx(assetID, ids.Empty, uint32(numTxs+1), MaxTxSize, 2)
+	require.NoError(mempool.Add(highFeeTx))
+	require.Equal(numTxs, mempool.Len())
+
+	evictedTx := initialTxs[numTxs-1]
+	_, ok := mempool.Get(evictedTx.ID())
+	require.False(ok)
+	err = mempool.GetDropReason(evictedTx.ID())
+	require.ErrorIs(err, ErrEvicted)
+	require.Equal(float64(1), testutil.ToFloat64(mempool.numEvicted))
+
+	tx, exists := mempool.Peek()
+	require.True(exists)
+	require.Equal(highFeeTx, tx)
+}
+
+// newFeeTx returns a tx of [size] bytes that burns [fee] of [assetID] by
+// consuming output [index] of [parentID].
+func newFeeTx(assetID ids.ID, parentID ids.ID, index uint32, size int, fee uint64) *txs.Tx {
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{
+		BaseTx: mempooltest.NewBaseTx(assetID, parentID, index, fee),
+	}}
+	tx.SetBytes(utils.RandomBytes(size), utils.RandomBytes(size))
+	return tx
+}
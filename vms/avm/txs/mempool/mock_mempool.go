@@ -54,6 +54,20 @@ func (mr *MockMempoolMockRecorder) Add(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockMempool)(nil).Add), arg0)
 }
 
+// BytesAvailable mocks base method.
+func (m *MockMempool) BytesAvailable() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BytesAvailable")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// BytesAvailable indicates an expected call of BytesAvailable.
+func (mr *MockMempoolMockRecorder) BytesAvailable() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesAvailable", reflect.TypeOf((*MockMempool)(nil).BytesAvailable))
+}
+
 // Get mocks base method.
 func (m *MockMempool) Get(arg0 ids.ID) (*txs.Tx, bool) {
 	m.ctrl.T.Helper()
@@ -95,6 +109,18 @@ func (mr *MockMempoolMockRecorder) Iterate(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iterate", reflect.TypeOf((*MockMempool)(nil).Iterate), arg0)
 }
 
+// IterateDropped mocks base method.
+func (m *MockMempool) IterateDropped(arg0 func(ids.ID, error) bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IterateDropped", arg0)
+}
+
+// IterateDropped indicates an expected call of IterateDropped.
+func (mr *MockMempoolMockRecorder) IterateDropped(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateDropped", reflect.TypeOf((*MockMempool)(nil).IterateDropped), arg0)
+}
+
 // Len mocks base method.
 func (m *MockMempool) Len() int {
 	m.ctrl.T.Helper()
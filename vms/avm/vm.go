@@ -119,10 +119,18 @@ type VM struct {
 	awaitShutdown       sync.WaitGroup
 
 	networkConfig network.Config
+
+	// True if the admin API should be served
+	adminAPIEnabled bool
+
+	// True if the mempool should order txs by fee
+	mempoolFeePriority bool
+
 	// These values are only initialized after the chain has been linearized.
 	blockbuilder.Builder
 	chainManager blockexecutor.Manager
 	network      *network.Network
+	mempool      mempool.Mempool
 }
 
 func (vm *VM) Connected(ctx context.Context, nodeID ids.NodeID, version *version.Application) error {
@@ -276,6 +284,8 @@ func (vm *VM) Initialize(
 
 	vm.onShutdownCtx, vm.onShutdownCtxCancel = context.WithCancel(context.Background())
 	vm.networkConfig = avmConfig.Network
+	vm.adminAPIEnabled = avmConfig.AdminAPIEnabled
+	vm.mempoolFeePriority = avmConfig.MempoolFeePriority
 	return vm.state.Commit()
 }
 
@@ -350,13 +360,28 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 	walletServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
 	walletServer.RegisterAfterFunc(vm.metrics.AfterRequest)
 	// name this service "wallet"
-	err := walletServer.RegisterService(&vm.walletService, "wallet")
+	if err := walletServer.RegisterService(&vm.walletService, "wallet"); err != nil {
+		return nil, err
+	}
 
-	return map[string]http.Handler{
+	handlers := map[string]http.Handler{
 		"":        rpcServer,
 		"/wallet": walletServer,
 		"/events": vm.pubsub,
-	}, err
+	}
+	if !vm.adminAPIEnabled {
+		return handlers, nil
+	}
+
+	adminServer := rpc.NewServer()
+	adminServer.RegisterCodec(codec, "application/json")
+	adminServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	adminServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
+	adminServer.RegisterAfterFunc(vm.metrics.AfterRequest)
+	// name this service "admin"
+	err := adminServer.RegisterService(&AdminService{vm: vm}, "admin")
+	handlers["/admin"] = adminServer
+	return handlers, err
 }
 
 /*
@@ -403,13 +428,19 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID, toEngine chan<
 		return err
 	}
 
-	mempool, err := mempool.New("mempool", vm.registerer, toEngine)
+	var txMempool mempool.Mempool
+	if vm.mempoolFeePriority {
+		txMempool, err = mempool.NewWithFeePriority("mempool", vm.registerer, toEngine, vm.feeAssetID)
+	} else {
+		txMempool, err = mempool.New("mempool", vm.registerer, toEngine)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
+	vm.mempool = txMempool
 
 	vm.chainManager = blockexecutor.NewManager(
-		mempool,
+		txMempool,
 		vm.metrics,
 		vm.state,
 		vm.txBackend,
@@ -421,7 +452,7 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID, toEngine chan<
 		vm.txBackend,
 		vm.chainManager,
 		&vm.clock,
-		mempool,
+		txMempool,
 	)
 
 	// Invariant: The context lock is not held when calling network.IssueTx.
@@ -435,7 +466,7 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID, toEngine chan<
 			&vm.ctx.Lock,
 			vm.chainManager,
 		),
-		mempool,
+		txMempool,
 		vm.appSender,
 		vm.registerer,
 		vm.networkConfig,
@@ -9,11 +9,15 @@ import (
 	"net/http"
 
 	"github.com/gorilla/rpc/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/network/p2p/acp118"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -30,6 +34,9 @@ import (
 	xsblock "github.com/ava-labs/avalanchego/vms/example/xsvm/block"
 )
 
+// signatureCacheSize is the number of warp signatures that are cached
+const signatureCacheSize = 1024
+
 var (
 	_ smblock.ChainVM                      = (*VM)(nil)
 	_ smblock.BuildBlockWithContextChainVM = (*VM)(nil)
@@ -56,14 +63,33 @@ func (vm *VM) Initialize(
 	_ []byte,
 	engineChan chan<- common.Message,
 	_ []*common.Fx,
-	_ common.AppSender,
+	appSender common.AppSender,
 ) error {
-	vm.AppHandler = common.NewNoOpAppHandler(chainContext.Log)
-
 	chainContext.Log.Info("initializing xsvm",
 		zap.Stringer("version", Version),
 	)
 
+	registerer := prometheus.NewRegistry()
+	if err := chainContext.Metrics.Register(registerer); err != nil {
+		return err
+	}
+
+	network, err := p2p.NewNetwork(chainContext.Log, appSender, registerer, "p2p")
+	if err != nil {
+		return fmt.Errorf("failed to initialize p2p network: %w", err)
+	}
+
+	// Allow signatures of warp messages to be requested over p2p
+	err = network.AddHandler(acp118.HandlerID, acp118.NewCachedHandler(
+		&cache.LRU[ids.ID, []byte]{Size: signatureCacheSize},
+		&warpVerifier{db: db},
+		chainContext.WarpSigner,
+	))
+	if err != nil {
+		return fmt.Errorf("failed to add signature request handler: %w", err)
+	}
+	vm.AppHandler = network
+
 	vm.chainContext = chainContext
 	vm.db = db
 	g, err := genesis.Parse(genesisBytes)
@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package xsvm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p/acp118"
+	"github.com/ava-labs/avalanchego/vms/example/xsvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+var (
+	_ acp118.Verifier = (*warpVerifier)(nil)
+
+	errUnknownMessage = errors.New("unknown message")
+)
+
+// warpVerifier approves the messages that were created by accepted
+// transactions. The justification of a request is the ID of the transaction
+// that created the message.
+type warpVerifier struct {
+	db database.KeyValueReader
+}
+
+func (v *warpVerifier) Verify(_ context.Context, message *warp.UnsignedMessage, justification []byte) error {
+	txID, err := ids.ToID(justification)
+	if err != nil {
+		return fmt.Errorf("failed to parse txID: %w", err)
+	}
+
+	expectedMessage, err := state.GetMessage(v.db, txID)
+	if err != nil {
+		return fmt.Errorf("failed to get message of %s: %w", txID, err)
+	}
+
+	if !bytes.Equal(message.Bytes(), expectedMessage.Bytes()) {
+		return fmt.Errorf("%w: %s", errUnknownMessage, message.ID())
+	}
+	return nil
+}
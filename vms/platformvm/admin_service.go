@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api"
+)
+
+var (
+	errTxNotInMempool = errors.New("tx not in mempool")
+	errRemovedByAdmin = errors.New("removed from the mempool over the admin API")
+)
+
+// AdminService defines the admin API calls that can be made to the platform
+// chain. It is only served if the admin API is enabled in the chain config.
+type AdminService struct {
+	vm *VM
+}
+
+// RemoveMempoolTx removes the tx from the mempool. The tx is marked as dropped
+// so that it isn't re-added to the mempool by gossip.
+func (a *AdminService) RemoveMempoolTx(_ *http.Request, args *api.JSONTxID, _ *api.EmptyReply) error {
+	a.vm.ctx.Log.Info("admin API called",
+		zap.String("service", "platform.admin"),
+		zap.String("method", "removeMempoolTx"),
+		zap.Stringer("txID", args.TxID),
+	)
+
+	a.vm.ctx.Lock.Lock()
+	defer a.vm.ctx.Lock.Unlock()
+
+	tx, ok := a.vm.Builder.Get(args.TxID)
+	if !ok {
+		return fmt.Errorf("%w: %s", errTxNotInMempool, args.TxID)
+	}
+
+	a.vm.Builder.Remove(tx)
+	a.vm.Builder.MarkDropped(args.TxID, errRemovedByAdmin)
+	return nil
+}
@@ -19,11 +19,13 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
 	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
@@ -89,6 +91,107 @@ func TestBuildBlockDoesNotBuildWithEmptyMempool(t *testing.T) {
 	require.Nil(blk)
 }
 
+func TestBuildBlockFeePriority(t *testing.T) {
+	require := require.New(t)
+
+	env := newFeePriorityEnvironment(t, latestFork)
+	env.ctx.Lock.Lock()
+	defer env.ctx.Lock.Unlock()
+
+	// Create a tx that pays the required fee and a tx that pays double the
+	// required fee.
+	var (
+		txFee = env.config.TxFee
+		owner = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+	)
+	lowFeeTx, err := env.txBuilder.NewBaseTx(
+		1,
+		owner,
+		[]*secp256k1.PrivateKey{preFundedKeys[3]},
+		preFundedKeys[3].PublicKey().Address(),
+		nil,
+	)
+	require.NoError(err)
+
+	env.config.TxFee = 2 * txFee
+	highFeeTx, err := env.txBuilder.NewBaseTx(
+		1,
+		owner,
+		[]*secp256k1.PrivateKey{preFundedKeys[4]},
+		preFundedKeys[4].PublicKey().Address(),
+		nil,
+	)
+	require.NoError(err)
+	env.config.TxFee = txFee
+
+	// Create a tx that spends the change of the low fee tx and pays triple the
+	// required fee.
+	var changeUTXO *avax.UTXO
+	for _, utxo := range lowFeeTx.UTXOs() {
+		out := utxo.Out.(*secp256k1fx.TransferOutput)
+		if out.Addrs[0] == preFundedKeys[3].PublicKey().Address() {
+			changeUTXO = utxo
+		}
+	}
+	require.NotNil(changeUTXO)
+
+	changeAmount := changeUTXO.Out.(*secp256k1fx.TransferOutput).Amount()
+	childTx, err := txs.NewSigned(
+		&txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    env.ctx.NetworkID,
+			BlockchainID: env.ctx.ChainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: changeUTXO.UTXOID,
+				Asset:  changeUTXO.Asset,
+				In: &secp256k1fx.TransferInput{
+					Amt: changeAmount,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{0},
+					},
+				},
+			}},
+			Outs: []*avax.TransferableOutput{{
+				Asset: changeUTXO.Asset,
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          changeAmount - 3*txFee,
+					OutputOwners: owner,
+				},
+			}},
+		}},
+		txs.Codec,
+		[][]*secp256k1.PrivateKey{{preFundedKeys[3]}},
+	)
+	require.NoError(err)
+
+	// Issue the low fee tx before the high fee tx
+	env.ctx.Lock.Unlock()
+	require.NoError(env.network.IssueTxFromRPC(lowFeeTx))
+	require.NoError(env.network.IssueTxFromRPC(highFeeTx))
+	env.ctx.Lock.Lock()
+
+	// The child tx spends a UTXO that isn't accepted yet, so it is added to
+	// the mempool directly.
+	require.NoError(env.mempool.Add(childTx))
+
+	// The high fee tx should be preferred even though it was issued last. The
+	// child tx pays the highest fee, but can't be issued before its parent.
+	tx, exists := env.mempool.Peek()
+	require.True(exists)
+	require.Equal(highFeeTx.ID(), tx.ID())
+
+	// [BuildBlock] should order the txs by fee, after their parents
+	blkIntf, err := env.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+
+	require.IsType(&blockexecutor.Block{}, blkIntf)
+	blk := blkIntf.(*blockexecutor.Block)
+	require.Equal([]*txs.Tx{highFeeTx, lowFeeTx, childTx}, blk.Txs())
+	require.NoError(blk.Verify(context.Background()))
+}
+
 func TestBuildBlockShouldReward(t *testing.T) {
 	require := require.New(t)
 
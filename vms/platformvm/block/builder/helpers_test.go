@@ -122,6 +122,16 @@ type environment struct {
 }
 
 func newEnvironment(t *testing.T, f fork) *environment { //nolint:unparam
+	return newEnvironmentWithMempool(t, f, false)
+}
+
+// newFeePriorityEnvironment returns an environment whose mempool orders txs by
+// fee.
+func newFeePriorityEnvironment(t *testing.T, f fork) *environment {
+	return newEnvironmentWithMempool(t, f, true)
+}
+
+func newEnvironmentWithMempool(t *testing.T, f fork, feePriority bool) *environment {
 	require := require.New(t)
 
 	res := &environment{
@@ -184,7 +194,11 @@ func newEnvironment(t *testing.T, f fork) *environment { //nolint:unparam
 	metrics, err := metrics.New("", registerer)
 	require.NoError(err)
 
-	res.mempool, err = mempool.New("mempool", registerer, nil)
+	if feePriority {
+		res.mempool, err = mempool.NewWithFeePriority("mempool", registerer, nil, res.ctx.AVAXAssetID)
+	} else {
+		res.mempool, err = mempool.New("mempool", registerer, nil)
+	}
 	require.NoError(err)
 
 	res.blkManager = blockexecutor.NewManager(
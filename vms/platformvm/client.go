@@ -117,6 +117,13 @@ type Client interface {
 	//
 	// Deprecated: GetRewardUTXOs should be fetched from a dedicated indexer.
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
+	// GetAddressTxs returns the IDs of the accepted transactions that
+	// referenced [addr], starting at [cursor], and the cursor to use to fetch
+	// the next page.
+	GetAddressTxs(ctx context.Context, addr ids.ShortID, cursor uint64, pageSize uint64, options ...rpc.Option) ([]ids.ID, uint64, error)
+	// GetMempool returns the txs that are pending in the mempool and the txs
+	// that were recently dropped from the mempool
+	GetMempool(ctx context.Context, options ...rpc.Option) (*api.GetMempoolReply, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided
@@ -503,6 +510,22 @@ func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, option
 	return utxos, err
 }
 
+func (c *client) GetAddressTxs(ctx context.Context, addr ids.ShortID, cursor uint64, pageSize uint64, options ...rpc.Option) ([]ids.ID, uint64, error) {
+	res := &GetAddressTxsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getAddressTxs", &GetAddressTxsArgs{
+		Address:  addr.String(),
+		Cursor:   json.Uint64(cursor),
+		PageSize: json.Uint64(pageSize),
+	}, res, options...)
+	return res.TxIDs, uint64(res.Cursor), err
+}
+
+func (c *client) GetMempool(ctx context.Context, options ...rpc.Option) (*api.GetMempoolReply, error) {
+	res := &api.GetMempoolReply{}
+	err := c.requester.SendRequest(ctx, "platform.getMempool", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error) {
 	res := &GetTimestampReply{}
 	err := c.requester.SendRequest(ctx, "platform.getTimestamp", struct{}{}, res, options...)
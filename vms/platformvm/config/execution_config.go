@@ -23,6 +23,9 @@ var DefaultExecutionConfig = ExecutionConfig{
 	FxOwnerCacheSize:             4 * units.MiB,
 	ChecksumsEnabled:             false,
 	MempoolPruneFrequency:        30 * time.Minute,
+	IndexAddressTxs:              false,
+	AdminAPIEnabled:              false,
+	MempoolFeePriority:           false,
 }
 
 // ExecutionConfig provides execution parameters of PlatformVM
@@ -38,6 +41,9 @@ type ExecutionConfig struct {
 	FxOwnerCacheSize             int            `json:"fx-owner-cache-size"`
 	ChecksumsEnabled             bool           `json:"checksums-enabled"`
 	MempoolPruneFrequency        time.Duration  `json:"mempool-prune-frequency"`
+	IndexAddressTxs              bool           `json:"index-address-txs"`
+	AdminAPIEnabled              bool           `json:"admin-api-enabled"`
+	MempoolFeePriority           bool           `json:"mempool-fee-priority"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
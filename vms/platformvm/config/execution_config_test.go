@@ -70,7 +70,10 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			"block-id-cache-size": 8,
 			"fx-owner-cache-size": 9,
 			"checksums-enabled": true,
-			"mempool-prune-frequency": 60000000000
+			"mempool-prune-frequency": 60000000000,
+			"index-address-txs": true,
+			"admin-api-enabled": true,
+			"mempool-fee-priority": true
 		}`)
 		ec, err := GetExecutionConfig(b)
 		require.NoError(err)
@@ -104,6 +107,9 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			FxOwnerCacheSize:             9,
 			ChecksumsEnabled:             true,
 			MempoolPruneFrequency:        time.Minute,
+			IndexAddressTxs:              true,
+			AdminAPIEnabled:              true,
+			MempoolFeePriority:           true,
 		}
 		require.Equal(expected, ec)
 	})
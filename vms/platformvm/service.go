@@ -51,6 +51,9 @@ const (
 	// Max number of addresses that can be passed in as argument to GetStake
 	maxGetStakeAddrs = 256
 
+	// Max number of tx IDs that can be returned by GetAddressTxs
+	maxGetAddressTxsPageSize = 1024
+
 	// Note: Staker attributes cache should be large enough so that no evictions
 	// happen when the API loops through all stakers.
 	stakerAttributesCacheSize = 100_000
@@ -61,6 +64,7 @@ var (
 	errPrimaryNetworkIsNotASubnet = errors.New("the primary network isn't a subnet")
 	errNoAddresses                = errors.New("no addresses provided")
 	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
+	errPageSizeTooLarge           = errors.New("page size too large")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -1631,6 +1635,91 @@ func (s *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, reply *Ge
 	return nil
 }
 
+// GetAddressTxsArgs are the arguments for calling GetAddressTxs
+type GetAddressTxsArgs struct {
+	Address string `json:"address"`
+	// Cursor used as a page index / offset
+	Cursor avajson.Uint64 `json:"cursor"`
+	// PageSize num of items per page
+	PageSize avajson.Uint64 `json:"pageSize"`
+}
+
+// GetAddressTxsReply is the response from calling GetAddressTxs
+type GetAddressTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Cursor used as a page index / offset
+	Cursor avajson.Uint64 `json:"cursor"`
+}
+
+// GetAddressTxs returns the IDs of the accepted transactions that referenced
+// the provided address.
+func (s *Service) GetAddressTxs(_ *http.Request, args *GetAddressTxsArgs, reply *GetAddressTxsReply) error {
+	cursor := uint64(args.Cursor)
+	pageSize := uint64(args.PageSize)
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getAddressTxs"),
+		logging.UserString("address", args.Address),
+		zap.Uint64("cursor", cursor),
+		zap.Uint64("pageSize", pageSize),
+	)
+
+	if pageSize > maxGetAddressTxsPageSize {
+		return fmt.Errorf("%w: %d > %d", errPageSizeTooLarge, pageSize, maxGetAddressTxsPageSize)
+	} else if pageSize == 0 {
+		pageSize = maxGetAddressTxsPageSize
+	}
+
+	address, err := avax.ParseServiceAddress(s.addrManager, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse argument 'address' to address: %w", err)
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply.TxIDs, err = s.vm.state.GetAddressTxs(address, cursor, pageSize)
+	if err != nil {
+		return fmt.Errorf("couldn't get address txs: %w", err)
+	}
+
+	// To get the next set of tx IDs, the user should provide this cursor.
+	reply.Cursor = avajson.Uint64(cursor + uint64(len(reply.TxIDs)))
+	return nil
+}
+
+// GetMempool returns the txs that are pending in the mempool, the txs that
+// were recently dropped from the mempool and the space used by the mempool.
+func (s *Service) GetMempool(_ *http.Request, _ *struct{}, reply *api.GetMempoolReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getMempool"),
+	)
+
+	reply.Txs = []api.MempoolTx{}
+	s.vm.Builder.Iterate(func(tx *txs.Tx) bool {
+		size := avajson.Uint64(len(tx.Bytes()))
+		reply.Txs = append(reply.Txs, api.MempoolTx{
+			TxID: tx.ID(),
+			Size: size,
+		})
+		reply.Bytes += size
+		return true
+	})
+
+	reply.DroppedTxs = []api.DroppedTx{}
+	s.vm.Builder.IterateDropped(func(txID ids.ID, reason error) bool {
+		reply.DroppedTxs = append(reply.DroppedTxs, api.DroppedTx{
+			TxID:   txID,
+			Reason: reason.Error(),
+		})
+		return true
+	})
+
+	reply.BytesAvailable = avajson.Uint64(s.vm.Builder.BytesAvailable())
+	return nil
+}
+
 // GetTimestampReply is the response from GetTimestamp
 type GetTimestampReply struct {
 	// Current timestamp
@@ -981,3 +981,82 @@ func TestServiceGetBlockByHeight(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAddressTxs(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	var (
+		addr  = ids.GenerateTestShortID()
+		txIDs = []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+	)
+
+	state := state.NewMockState(ctrl)
+	state.EXPECT().GetAddressTxs(addr, uint64(3), uint64(maxGetAddressTxsPageSize)).Return(txIDs, nil)
+
+	service := &Service{
+		vm: &VM{
+			state: state,
+			ctx: &snow.Context{
+				Log: logging.NoLog{},
+			},
+		},
+	}
+
+	reply := GetAddressTxsReply{}
+	require.NoError(service.GetAddressTxs(nil, &GetAddressTxsArgs{
+		Address: addr.String(),
+		Cursor:  3,
+	}, &reply))
+	require.Equal(txIDs, reply.TxIDs)
+	require.Equal(avajson.Uint64(5), reply.Cursor)
+
+	err := service.GetAddressTxs(nil, &GetAddressTxsArgs{
+		Address:  addr.String(),
+		PageSize: maxGetAddressTxsPageSize + 1,
+	}, &reply)
+	require.ErrorIs(err, errPageSizeTooLarge)
+}
+
+func TestGetMempool(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	adminService := &AdminService{vm: service.vm}
+
+	service.vm.ctx.Lock.Lock()
+	tx, err := service.vm.txBuilder.NewCreateChainTx(
+		testSubnet1.ID(),
+		[]byte{},
+		constants.AVMID,
+		[]ids.ID{},
+		"chain name",
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0].PublicKey().Address(), // change addr
+		nil,
+	)
+	require.NoError(err)
+	require.NoError(service.vm.Builder.Add(tx))
+	service.vm.ctx.Lock.Unlock()
+
+	txID := tx.ID()
+	txSize := avajson.Uint64(len(tx.Bytes()))
+
+	reply := api.GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, nil, &reply))
+	require.Equal([]api.MempoolTx{{TxID: txID, Size: txSize}}, reply.Txs)
+	require.Empty(reply.DroppedTxs)
+	require.Equal(txSize, reply.Bytes)
+	bytesAvailable := reply.BytesAvailable
+
+	require.NoError(adminService.RemoveMempoolTx(nil, &api.JSONTxID{TxID: txID}, &api.EmptyReply{}))
+
+	err = adminService.RemoveMempoolTx(nil, &api.JSONTxID{TxID: txID}, &api.EmptyReply{})
+	require.ErrorIs(err, errTxNotInMempool)
+
+	reply = api.GetMempoolReply{}
+	require.NoError(service.GetMempool(nil, nil, &reply))
+	require.Empty(reply.Txs)
+	require.Equal([]api.DroppedTx{{TxID: txID, Reason: errRemovedByAdmin.Error()}}, reply.DroppedTxs)
+	require.Zero(reply.Bytes)
+	require.Equal(bytesAvailable+txSize, reply.BytesAvailable)
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var ErrAddressTxsNotIndexed = errors.New("address txs are not indexed")
+
+// The address txs index is stored in [addressTxsDB] as:
+//
+//	[address]           -> number of txs indexed for [address]
+//	[address] + [index] -> ID of the [index]'th tx that referenced [address]
+func addressTxKey(addr ids.ShortID, index uint64) []byte {
+	key := make([]byte, ids.ShortIDLen+database.Uint64Size)
+	copy(key, addr[:])
+	copy(key[ids.ShortIDLen:], database.PackUInt64(index))
+	return key
+}
+
+func (s *state) GetAddressTxs(addr ids.ShortID, cursor, pageSize uint64) ([]ids.ID, error) {
+	if !s.indexAddressTxs {
+		return nil, ErrAddressTxsNotIndexed
+	}
+
+	it := s.addressTxsDB.NewIteratorWithStartAndPrefix(addressTxKey(addr, cursor), addr[:])
+	defer it.Release()
+
+	var txIDs []ids.ID
+	for uint64(len(txIDs)) < pageSize && it.Next() {
+		txID, err := ids.ToID(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		txIDs = append(txIDs, txID)
+	}
+	return txIDs, it.Error()
+}
+
+// writeAddressTxs indexes the txs of the accepted blocks that are about to be
+// written.
+//
+// Invariant: writeAddressTxs must be called before the added blocks and the
+// modified UTXOs are written.
+func (s *state) writeAddressTxs() error {
+	if !s.indexAddressTxs {
+		return nil
+	}
+
+	blks := maps.Values(s.addedBlocks)
+	slices.SortFunc(blks, func(a, b block.Block) int {
+		return cmp.Compare(a.Height(), b.Height())
+	})
+
+	// UTXOs produced by txs that are being indexed may be consumed by later
+	// txs that are being indexed, so they must be tracked here rather than
+	// read from disk.
+	producedUTXOs := make(map[ids.ID]*avax.UTXO)
+	for _, blk := range blks {
+		for _, tx := range blk.Txs() {
+			addrs, err := s.txAddresses(tx, producedUTXOs)
+			if err != nil {
+				return fmt.Errorf("failed to get addresses of tx %s: %w", tx.ID(), err)
+			}
+
+			txID := tx.ID()
+			for addr := range addrs {
+				if err := s.addAddressTx(addr, txID); err != nil {
+					return fmt.Errorf("failed to index tx %s: %w", txID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *state) addAddressTx(addr ids.ShortID, txID ids.ID) error {
+	numTxs, err := database.GetUInt64(s.addressTxsDB, addr[:])
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+	if err := s.addressTxsDB.Put(addressTxKey(addr, numTxs), txID[:]); err != nil {
+		return err
+	}
+	return database.PutUInt64(s.addressTxsDB, addr[:], numTxs+1)
+}
+
+// txAddresses returns the addresses of the UTXOs that [tx] consumes and
+// produces, the addresses that [tx] stakes to or rewards, and the addresses
+// that [tx] sets as the owner of a subnet.
+//
+// [producedUTXOs] is used to look up UTXOs that haven't been written to disk
+// yet and is updated with the UTXOs produced by [tx].
+//
+// The UTXOs consumed by an ImportTx are read from shared memory, which must be
+// done before the block's atomic requests are applied.
+func (s *state) txAddresses(tx *txs.Tx, producedUTXOs map[ids.ID]*avax.UTXO) (set.Set[ids.ShortID], error) {
+	addrs := set.Set[ids.ShortID]{}
+
+	inputIDs := tx.Unsigned.InputIDs()
+	if importTx, ok := tx.Unsigned.(*txs.ImportTx); ok {
+		// Imported UTXOs are stored in shared memory rather than in the state.
+		inputIDs.Difference(importTx.InputUTXOs())
+		if err := s.addImportedAddresses(addrs, importTx); err != nil {
+			return nil, err
+		}
+	}
+	for inputID := range inputIDs {
+		utxo, ok := producedUTXOs[inputID]
+		if !ok {
+			var err error
+			utxo, err = s.utxoState.GetUTXO(inputID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get UTXO %s: %w", inputID, err)
+			}
+		}
+		addAddresses(addrs, utxo.Out)
+	}
+
+	for _, utxo := range tx.UTXOs() {
+		producedUTXOs[utxo.InputID()] = utxo
+		addAddresses(addrs, utxo.Out)
+	}
+
+	switch utx := tx.Unsigned.(type) {
+	case *txs.ExportTx:
+		for _, out := range utx.ExportedOutputs {
+			addAddresses(addrs, out.Out)
+		}
+	case *txs.CreateSubnetTx:
+		addAddresses(addrs, utx.Owner)
+	case *txs.TransferSubnetOwnershipTx:
+		addAddresses(addrs, utx.Owner)
+	case *txs.RewardValidatorTx:
+		// The stake is returned to, and the rewards are paid to, the addresses
+		// of the staker tx.
+		stakerTx, _, err := s.GetTx(utx.TxID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get staker tx %s: %w", utx.TxID, err)
+		}
+		addStakerAddresses(addrs, stakerTx.Unsigned)
+	default:
+		addStakerAddresses(addrs, utx)
+	}
+	return addrs, nil
+}
+
+// addImportedAddresses adds the addresses of the UTXOs imported by [tx] to
+// [addrs].
+//
+// The UTXOs may not be in shared memory if the source chain hasn't accepted
+// the export yet, which can happen while bootstrapping. In that case the
+// owners of the imported UTXOs can't be indexed and are skipped.
+func (s *state) addImportedAddresses(addrs set.Set[ids.ShortID], tx *txs.ImportTx) error {
+	if len(tx.ImportedInputs) == 0 {
+		return nil
+	}
+
+	utxoIDs := make([][]byte, len(tx.ImportedInputs))
+	for i, in := range tx.ImportedInputs {
+		utxoID := in.UTXOID.InputID()
+		utxoIDs[i] = utxoID[:]
+	}
+	allUTXOBytes, err := s.ctx.SharedMemory.Get(tx.SourceChain, utxoIDs)
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get imported UTXOs: %w", err)
+	}
+
+	for _, utxoBytes := range allUTXOBytes {
+		utxo := &avax.UTXO{}
+		if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+			return fmt.Errorf("failed to unmarshal imported UTXO: %w", err)
+		}
+		addAddresses(addrs, utxo.Out)
+	}
+	return nil
+}
+
+func addStakerAddresses(addrs set.Set[ids.ShortID], utx txs.UnsignedTx) {
+	if staker, ok := utx.(txs.PermissionlessStaker); ok {
+		for _, out := range staker.Stake() {
+			addAddresses(addrs, out.Out)
+		}
+	}
+
+	switch staker := utx.(type) {
+	case txs.ValidatorTx:
+		addAddresses(addrs, staker.ValidationRewardsOwner())
+		addAddresses(addrs, staker.DelegationRewardsOwner())
+	case txs.DelegatorTx:
+		addAddresses(addrs, staker.RewardsOwner())
+	}
+}
+
+// addAddresses adds the addresses of [owner] to [addrs] if [owner] is
+// addressable.
+func addAddresses(addrs set.Set[ids.ShortID], owner any) {
+	addressable, ok := owner.(avax.Addressable)
+	if !ok {
+		return
+	}
+	for _, addrBytes := range addressable.Addresses() {
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			continue
+		}
+		addrs.Add(addr)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newTestTransferOutput(assetID ids.ID, amount uint64, addr ids.ShortID) *avax.TransferableOutput {
+	return &avax.TransferableOutput{
+		Asset: avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+}
+
+func newTestTransferInput(utxo *avax.UTXO) *avax.TransferableInput {
+	return &avax.TransferableInput{
+		UTXOID: utxo.UTXOID,
+		Asset:  utxo.Asset,
+		In: &secp256k1fx.TransferInput{
+			Amt: utxo.Out.(*secp256k1fx.TransferOutput).Amt,
+			Input: secp256k1fx.Input{
+				SigIndices: []uint32{0},
+			},
+		},
+	}
+}
+
+func TestAddressTxs(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newUninitializedState(require)
+	s.indexAddressTxs = true
+
+	var (
+		assetID = ids.GenerateTestID()
+		addr0   = ids.GenerateTestShortID()
+		addr1   = ids.GenerateTestShortID()
+		addr2   = ids.GenerateTestShortID()
+		addr3   = ids.GenerateTestShortID()
+	)
+
+	initialOutput := newTestTransferOutput(assetID, 3, addr0)
+	initialUTXO := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: initialOutput.Asset,
+		Out:   initialOutput.Out,
+	}
+	s.AddUTXO(initialUTXO)
+	require.NoError(s.Commit())
+
+	// [baseTx] consumes a UTXO owned by [addr0] and produces a UTXO owned by
+	// [addr1].
+	baseTx := &txs.Tx{
+		Unsigned: &txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: constants.PlatformChainID,
+				Ins:          []*avax.TransferableInput{newTestTransferInput(initialUTXO)},
+				Outs:         []*avax.TransferableOutput{newTestTransferOutput(assetID, 2, addr1)},
+			},
+		},
+	}
+	require.NoError(baseTx.Initialize(txs.Codec))
+
+	// [createSubnetTx] consumes the UTXO produced by [baseTx], produces a UTXO
+	// owned by [addr2], and sets [addr3] as the owner of the subnet.
+	createSubnetTx := &txs.Tx{
+		Unsigned: &txs.CreateSubnetTx{
+			BaseTx: txs.BaseTx{
+				BaseTx: avax.BaseTx{
+					NetworkID:    constants.UnitTestID,
+					BlockchainID: constants.PlatformChainID,
+					Ins:          []*avax.TransferableInput{newTestTransferInput(baseTx.UTXOs()[0])},
+					Outs:         []*avax.TransferableOutput{newTestTransferOutput(assetID, 1, addr2)},
+				},
+			},
+			Owner: &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr3},
+			},
+		},
+	}
+	require.NoError(createSubnetTx.Initialize(txs.Codec))
+
+	blk, err := block.NewBanffStandardBlock(
+		time.Unix(0, 0),
+		ids.GenerateTestID(),
+		1,
+		[]*txs.Tx{baseTx, createSubnetTx},
+	)
+	require.NoError(err)
+
+	s.AddStatelessBlock(blk)
+	for _, tx := range blk.Txs() {
+		for _, utxo := range tx.UTXOs() {
+			s.AddUTXO(utxo)
+		}
+	}
+	s.DeleteUTXO(initialUTXO.InputID())
+	s.DeleteUTXO(baseTx.UTXOs()[0].InputID())
+	require.NoError(s.Commit())
+
+	tests := []struct {
+		name     string
+		addr     ids.ShortID
+		cursor   uint64
+		pageSize uint64
+		expected []ids.ID
+	}{
+		{
+			name:     "consumed UTXO",
+			addr:     addr0,
+			pageSize: 10,
+			expected: []ids.ID{baseTx.ID()},
+		},
+		{
+			name:     "produced and consumed UTXO",
+			addr:     addr1,
+			pageSize: 10,
+			expected: []ids.ID{baseTx.ID(), createSubnetTx.ID()},
+		},
+		{
+			name:     "produced UTXO",
+			addr:     addr2,
+			pageSize: 10,
+			expected: []ids.ID{createSubnetTx.ID()},
+		},
+		{
+			name:     "subnet owner",
+			addr:     addr3,
+			pageSize: 10,
+			expected: []ids.ID{createSubnetTx.ID()},
+		},
+		{
+			name:     "page size",
+			addr:     addr1,
+			pageSize: 1,
+			expected: []ids.ID{baseTx.ID()},
+		},
+		{
+			name:     "cursor",
+			addr:     addr1,
+			cursor:   1,
+			pageSize: 10,
+			expected: []ids.ID{createSubnetTx.ID()},
+		},
+		{
+			name:     "cursor after last tx",
+			addr:     addr1,
+			cursor:   2,
+			pageSize: 10,
+			expected: nil,
+		},
+		{
+			name:     "unknown address",
+			addr:     ids.GenerateTestShortID(),
+			pageSize: 10,
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(*testing.T) {
+			txIDs, err := s.GetAddressTxs(test.addr, test.cursor, test.pageSize)
+			require.NoError(err)
+			require.Equal(test.expected, txIDs)
+		})
+	}
+}
+
+func TestAddressTxsImportTx(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newUninitializedState(require)
+	s.indexAddressTxs = true
+
+	var (
+		xChainID = ids.GenerateTestID()
+		assetID  = ids.GenerateTestID()
+		addr0    = ids.GenerateTestShortID()
+		addr1    = ids.GenerateTestShortID()
+	)
+
+	m := atomic.NewMemory(memdb.New())
+	s.ctx.SharedMemory = m.NewSharedMemory(constants.PlatformChainID)
+	peerSharedMemory := m.NewSharedMemory(xChainID)
+
+	// [importedUTXO] is exported from the X-chain to [addr0].
+	importedOutput := newTestTransferOutput(assetID, 2, addr0)
+	importedUTXO := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: importedOutput.Asset,
+		Out:   importedOutput.Out,
+	}
+	utxoBytes, err := txs.Codec.Marshal(txs.CodecVersion, importedUTXO)
+	require.NoError(err)
+
+	inputID := importedUTXO.InputID()
+	require.NoError(peerSharedMemory.Apply(map[ids.ID]*atomic.Requests{
+		constants.PlatformChainID: {
+			PutRequests: []*atomic.Element{
+				{
+					Key:   inputID[:],
+					Value: utxoBytes,
+					Traits: [][]byte{
+						addr0.Bytes(),
+					},
+				},
+			},
+		},
+	}))
+
+	// [importTx] imports the UTXO owned by [addr0] and produces a UTXO owned
+	// by [addr1].
+	importTx := &txs.Tx{
+		Unsigned: &txs.ImportTx{
+			BaseTx: txs.BaseTx{
+				BaseTx: avax.BaseTx{
+					NetworkID:    constants.UnitTestID,
+					BlockchainID: constants.PlatformChainID,
+					Outs:         []*avax.TransferableOutput{newTestTransferOutput(assetID, 1, addr1)},
+				},
+			},
+			SourceChain:    xChainID,
+			ImportedInputs: []*avax.TransferableInput{newTestTransferInput(importedUTXO)},
+		},
+	}
+	require.NoError(importTx.Initialize(txs.Codec))
+
+	blk, err := block.NewBanffStandardBlock(
+		time.Unix(0, 0),
+		ids.GenerateTestID(),
+		1,
+		[]*txs.Tx{importTx},
+	)
+	require.NoError(err)
+
+	s.AddStatelessBlock(blk)
+	for _, utxo := range importTx.UTXOs() {
+		s.AddUTXO(utxo)
+	}
+	require.NoError(s.Commit())
+
+	for _, addr := range []ids.ShortID{addr0, addr1} {
+		txIDs, err := s.GetAddressTxs(addr, 0, 10)
+		require.NoError(err)
+		require.Equal([]ids.ID{importTx.ID()}, txIDs)
+	}
+}
+
+func TestAddressTxsNotIndexed(t *testing.T) {
+	require := require.New(t)
+
+	s, _ := newUninitializedState(require)
+
+	_, err := s.GetAddressTxs(ids.GenerateTestShortID(), 0, 10)
+	require.ErrorIs(err, ErrAddressTxsNotIndexed)
+}
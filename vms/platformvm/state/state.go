@@ -69,6 +69,7 @@ var (
 	SupplyPrefix                        = []byte("supply")
 	ChainPrefix                         = []byte("chain")
 	SingletonPrefix                     = []byte("singleton")
+	AddressTxsPrefix                    = []byte("addressTxs")
 
 	TimestampKey      = []byte("timestamp")
 	CurrentSupplyKey  = []byte("current supply")
@@ -123,6 +124,14 @@ type State interface {
 	GetBlockIDAtHeight(height uint64) (ids.ID, error)
 
 	GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error)
+
+	// GetAddressTxs returns the IDs of the accepted txs that referenced
+	// [addr], in order of acceptance, starting with the [cursor]'th tx. At
+	// most [pageSize] tx IDs are returned.
+	//
+	// Returns ErrAddressTxsNotIndexed if the address txs index is disabled.
+	GetAddressTxs(addr ids.ShortID, cursor, pageSize uint64) ([]ids.ID, error)
+
 	GetSubnets() ([]*txs.Tx, error)
 	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
 
@@ -340,6 +349,9 @@ type state struct {
 	lastAccepted, persistedLastAccepted ids.ID
 	indexedHeights                      *heightRange
 	singletonDB                         database.Database
+
+	indexAddressTxs bool
+	addressTxsDB    database.Database
 }
 
 // heightRange is used to track which heights are safe to use the native DB
@@ -648,6 +660,9 @@ func newState(
 		chainDBCache: chainDBCache,
 
 		singletonDB: prefixdb.New(SingletonPrefix, baseDB),
+
+		indexAddressTxs: execCfg.IndexAddressTxs,
+		addressTxsDB:    prefixdb.New(AddressTxsPrefix, baseDB),
 	}, nil
 }
 
@@ -1666,6 +1681,7 @@ func (s *state) write(updateValidators bool, height uint64) error {
 	}
 
 	return utils.Err(
+		s.writeAddressTxs(), // Must be called before writeBlocks and writeUTXOs
 		s.writeBlocks(),
 		s.writeCurrentStakers(updateValidators, height, codecVersion),
 		s.writePendingStakers(),
@@ -1703,6 +1719,7 @@ func (s *state) Close() error {
 		s.supplyDB.Close(),
 		s.chainDB.Close(),
 		s.singletonDB.Close(),
+		s.addressTxsDB.Close(),
 		s.blockDB.Close(),
 		s.blockIDDB.Close(),
 	)
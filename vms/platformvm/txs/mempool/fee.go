@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	txmempool "github.com/ava-labs/avalanchego/vms/txs/mempool"
+)
+
+var _ txs.Visitor = (*feeCalculator)(nil)
+
+// feeCalculator calculates the amount of [AssetID] that a tx burns.
+type feeCalculator struct {
+	txmempool.FeeCalculator
+}
+
+func (c *feeCalculator) BaseTx(tx *txs.BaseTx) error {
+	if err := c.Consume(tx.Ins); err != nil {
+		return err
+	}
+	return c.Produce(tx.Outs)
+}
+
+func (c *feeCalculator) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	if err := c.BaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	return c.Produce(tx.StakeOuts)
+}
+
+func (c *feeCalculator) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *feeCalculator) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	if err := c.BaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	return c.Produce(tx.StakeOuts)
+}
+
+func (c *feeCalculator) CreateChainTx(tx *txs.CreateChainTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *feeCalculator) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *feeCalculator) ImportTx(tx *txs.ImportTx) error {
+	if err := c.BaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	return c.Consume(tx.ImportedInputs)
+}
+
+func (c *feeCalculator) ExportTx(tx *txs.ExportTx) error {
+	if err := c.BaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	return c.Produce(tx.ExportedOutputs)
+}
+
+func (*feeCalculator) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return ErrCantIssueAdvanceTimeTx
+}
+
+func (*feeCalculator) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	return ErrCantIssueRewardValidatorTx
+}
+
+func (c *feeCalculator) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *feeCalculator) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
+
+func (c *feeCalculator) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	if err := c.BaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	return c.Produce(tx.StakeOuts)
+}
+
+func (c *feeCalculator) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	if err := c.BaseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	return c.Produce(tx.StakeOuts)
+}
+
+func (c *feeCalculator) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	return c.BaseTx(&tx.BaseTx)
+}
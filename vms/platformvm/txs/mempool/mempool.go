@@ -10,14 +10,16 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/linkedhashmap"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/setmap"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	txmempool "github.com/ava-labs/avalanchego/vms/txs/mempool"
 )
 
 const (
@@ -39,6 +41,7 @@ var (
 	ErrTxTooLarge                 = errors.New("tx too large")
 	ErrMempoolFull                = errors.New("mempool is full")
 	ErrConflictsWithOtherTx       = errors.New("tx conflicts with other tx")
+	ErrEvicted                    = errors.New("evicted by a tx paying a higher fee")
 	ErrCantIssueAdvanceTimeTx     = errors.New("can not issue an advance time tx")
 	ErrCantIssueRewardValidatorTx = errors.New("can not issue a reward validator tx")
 )
@@ -49,7 +52,9 @@ type Mempool interface {
 	// Remove [txs] and any conflicts of [txs] from the mempool.
 	Remove(txs ...*txs.Tx)
 
-	// Peek returns the oldest tx in the mempool.
+	// Peek returns the oldest tx in the mempool. If the mempool orders txs by
+	// fee, Peek returns the tx paying the highest fee per byte out of the txs
+	// that don't consume the outputs of other txs in the mempool.
 	Peek() (tx *txs.Tx, exists bool)
 
 	// Iterate iterates over the txs until f returns false
@@ -67,9 +72,15 @@ type Mempool interface {
 	// possibly reissued.
 	MarkDropped(txID ids.ID, reason error)
 	GetDropReason(txID ids.ID) error
+	// IterateDropped iterates over the recently dropped txs, from the least
+	// recently dropped to the most recently dropped, until f returns false.
+	IterateDropped(f func(txID ids.ID, reason error) bool)
 
 	// Len returns the number of txs in the mempool.
 	Len() int
+	// BytesAvailable returns the number of bytes of space currently available
+	// in the mempool.
+	BytesAvailable() int
 }
 
 // Transactions from clients that have not yet been put into blocks and added to
@@ -79,12 +90,17 @@ type mempool struct {
 	unissuedTxs    linkedhashmap.LinkedHashmap[ids.ID, *txs.Tx]
 	consumedUTXOs  *setmap.SetMap[ids.ID, ids.ID] // TxID -> Consumed UTXOs
 	bytesAvailable int
-	droppedTxIDs   *cache.LRU[ids.ID, error] // TxID -> verification error
+	droppedTxIDs   linkedhashmap.LinkedHashmap[ids.ID, error] // TxID -> verification error
 
 	toEngine chan<- common.Message
 
 	numTxs               prometheus.Gauge
 	bytesAvailableMetric prometheus.Gauge
+
+	// The following fields are only used if the mempool orders txs by fee.
+	feeAssetID ids.ID
+	feeQueue   *txmempool.FeeQueue
+	numEvicted prometheus.Counter
 }
 
 func New(
@@ -92,11 +108,44 @@ func New(
 	registerer prometheus.Registerer,
 	toEngine chan<- common.Message,
 ) (Mempool, error) {
+	return newMempool(namespace, registerer, toEngine)
+}
+
+// NewWithFeePriority returns a mempool that orders txs by the amount of
+// [feeAssetID] they burn per byte. When the mempool is full, txs paying the
+// lowest fee per byte are evicted to make space for txs paying a higher fee
+// per byte.
+func NewWithFeePriority(
+	namespace string,
+	registerer prometheus.Registerer,
+	toEngine chan<- common.Message,
+	feeAssetID ids.ID,
+) (Mempool, error) {
+	m, err := newMempool(namespace, registerer, toEngine)
+	if err != nil {
+		return nil, err
+	}
+
+	m.feeAssetID = feeAssetID
+	m.feeQueue = txmempool.NewFeeQueue()
+	m.numEvicted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "evicted_txs",
+		Help:      "Number of transactions evicted from the mempool by transactions paying a higher fee",
+	})
+	return m, registerer.Register(m.numEvicted)
+}
+
+func newMempool(
+	namespace string,
+	registerer prometheus.Registerer,
+	toEngine chan<- common.Message,
+) (*mempool, error) {
 	m := &mempool{
 		unissuedTxs:    linkedhashmap.New[ids.ID, *txs.Tx](),
 		consumedUTXOs:  setmap.New[ids.ID, ids.ID](),
 		bytesAvailable: maxMempoolSize,
-		droppedTxIDs:   &cache.LRU[ids.ID, error]{Size: droppedTxIDsCacheSize},
+		droppedTxIDs:   linkedhashmap.New[ids.ID, error](),
 		toEngine:       toEngine,
 		numTxs: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
@@ -145,7 +194,37 @@ func (m *mempool) Add(tx *txs.Tx) error {
 			MaxTxSize,
 		)
 	}
-	if txSize > m.bytesAvailable {
+
+	inputs := tx.Unsigned.InputIDs()
+	if m.consumedUTXOs.HasOverlap(inputs) {
+		return fmt.Errorf("%w: %s", ErrConflictsWithOtherTx, txID)
+	}
+
+	var (
+		fee     txmempool.TxFee
+		parents set.Set[ids.ID]
+	)
+	if m.feeQueue != nil {
+		calculator := &feeCalculator{
+			FeeCalculator: txmempool.FeeCalculator{
+				AssetID: m.feeAssetID,
+			},
+		}
+		if err := tx.Unsigned.Visit(calculator); err != nil {
+			return fmt.Errorf("failed to calculate fee of %s: %w", txID, err)
+		}
+		burned, err := calculator.Fee()
+		if err != nil {
+			return fmt.Errorf("failed to calculate fee of %s: %w", txID, err)
+		}
+		fee = txmempool.TxFee{
+			Fee:  burned,
+			Size: uint64(txSize),
+		}
+		parents = calculator.Parents
+	}
+
+	if txSize > m.bytesAvailable && !m.evictLowerFeeTxs(fee, parents) {
 		return fmt.Errorf("%w: %s size (%d) > available space (%d)",
 			ErrMempoolFull,
 			txID,
@@ -154,9 +233,8 @@ func (m *mempool) Add(tx *txs.Tx) error {
 		)
 	}
 
-	inputs := tx.Unsigned.InputIDs()
-	if m.consumedUTXOs.HasOverlap(inputs) {
-		return fmt.Errorf("%w: %s", ErrConflictsWithOtherTx, txID)
+	if m.feeQueue != nil {
+		m.feeQueue.Push(txID, fee, parents)
 	}
 
 	m.unissuedTxs.Put(txID, tx)
@@ -168,7 +246,7 @@ func (m *mempool) Add(tx *txs.Tx) error {
 	m.consumedUTXOs.Put(txID, inputs)
 
 	// An explicitly added tx must not be marked as dropped.
-	m.droppedTxIDs.Evict(txID)
+	m.droppedTxIDs.Delete(txID)
 
 	return nil
 }
@@ -185,8 +263,7 @@ func (m *mempool) Remove(txs ...*txs.Tx) {
 		txID := tx.ID()
 		// If the transaction is in the mempool, remove it.
 		if _, ok := m.consumedUTXOs.DeleteKey(txID); ok {
-			m.unissuedTxs.Delete(txID)
-			m.bytesAvailable += len(tx.Bytes())
+			m.deleteTx(txID, tx)
 			continue
 		}
 
@@ -194,15 +271,58 @@ func (m *mempool) Remove(txs ...*txs.Tx) {
 		inputs := tx.Unsigned.InputIDs()
 		for _, removed := range m.consumedUTXOs.DeleteOverlapping(inputs) {
 			tx, _ := m.unissuedTxs.Get(removed.Key)
-			m.unissuedTxs.Delete(removed.Key)
-			m.bytesAvailable += len(tx.Bytes())
+			m.deleteTx(removed.Key, tx)
 		}
 	}
 	m.bytesAvailableMetric.Set(float64(m.bytesAvailable))
 	m.numTxs.Set(float64(m.unissuedTxs.Len()))
 }
 
+// deleteTx removes [tx] from the mempool, other than from [consumedUTXOs].
+func (m *mempool) deleteTx(txID ids.ID, tx *txs.Tx) {
+	m.unissuedTxs.Delete(txID)
+	m.bytesAvailable += len(tx.Bytes())
+	if m.feeQueue != nil {
+		m.feeQueue.Remove(txID)
+	}
+}
+
+// evictLowerFeeTxs evicts the txs paying the lowest fee per byte until there
+// is enough space for a tx paying [fee] that consumes the outputs of [parents].
+// Only txs paying strictly less per byte than [fee] are evicted, along with the
+// txs that depend on them. The txs that the new tx depends on are never
+// evicted. If enough space can't be made, no txs are evicted and false is
+// returned.
+func (m *mempool) evictLowerFeeTxs(fee txmempool.TxFee, parents set.Set[ids.ID]) bool {
+	if m.feeQueue == nil {
+		return false
+	}
+
+	evictedTxIDs, ok := m.feeQueue.Evictable(fee, parents, fee.Size-uint64(m.bytesAvailable))
+	if !ok {
+		return false
+	}
+
+	for _, txID := range evictedTxIDs {
+		tx, _ := m.unissuedTxs.Get(txID)
+		m.consumedUTXOs.DeleteKey(txID)
+		m.deleteTx(txID, tx)
+		m.markDropped(txID, ErrEvicted)
+		m.numEvicted.Inc()
+	}
+	m.numTxs.Set(float64(m.unissuedTxs.Len()))
+	return true
+}
+
 func (m *mempool) Peek() (*txs.Tx, bool) {
+	if m.feeQueue != nil {
+		txID, exists := m.feeQueue.Peek()
+		if !exists {
+			return nil, false
+		}
+		return m.unissuedTxs.Get(txID)
+	}
+
 	_, tx, exists := m.unissuedTxs.Oldest()
 	return tx, exists
 }
@@ -224,14 +344,22 @@ func (m *mempool) MarkDropped(txID ids.ID, reason error) {
 		return
 	}
 
-	m.lock.RLock()
-	defer m.lock.RUnlock()
+	m.lock.Lock()
+	defer m.lock.Unlock()
 
 	if _, ok := m.unissuedTxs.Get(txID); ok {
 		return
 	}
 
+	m.markDropped(txID, reason)
+}
+
+func (m *mempool) markDropped(txID ids.ID, reason error) {
 	m.droppedTxIDs.Put(txID, reason)
+	if m.droppedTxIDs.Len() > droppedTxIDsCacheSize {
+		oldestTxID, _, _ := m.droppedTxIDs.Oldest()
+		m.droppedTxIDs.Delete(oldestTxID)
+	}
 }
 
 func (m *mempool) GetDropReason(txID ids.ID) error {
@@ -239,6 +367,18 @@ func (m *mempool) GetDropReason(txID ids.ID) error {
 	return err
 }
 
+func (m *mempool) IterateDropped(f func(txID ids.ID, reason error) bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	it := m.droppedTxIDs.NewIterator()
+	for it.Next() {
+		if !f(it.Key(), it.Value()) {
+			return
+		}
+	}
+}
+
 func (m *mempool) RequestBuildBlock(emptyBlockPermitted bool) {
 	if !emptyBlockPermitted && m.unissuedTxs.Len() == 0 {
 		return
@@ -256,3 +396,10 @@ func (m *mempool) Len() int {
 
 	return m.unissuedTxs.Len()
 }
+
+func (m *mempool) BytesAvailable() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.bytesAvailable
+}
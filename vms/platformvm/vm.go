@@ -88,6 +88,9 @@ type VM struct {
 	txBuilder txbuilder.Builder
 	manager   blockexecutor.Manager
 
+	// True if the admin API should be served
+	adminAPIEnabled bool
+
 	// Cancelled on shutdown
 	onShutdownCtx context.Context
 	// Call [onShutdownCtxCancel] to cancel [onShutdownCtx] during Shutdown()
@@ -114,6 +117,7 @@ func (vm *VM) Initialize(
 		return err
 	}
 	chainCtx.Log.Info("using VM execution config", zap.Reflect("config", execConfig))
+	vm.adminAPIEnabled = execConfig.AdminAPIEnabled
 
 	registerer := prometheus.NewRegistry()
 	if err := chainCtx.Metrics.Register(registerer); err != nil {
@@ -180,13 +184,18 @@ func (vm *VM) Initialize(
 		Bootstrapped: &vm.bootstrapped,
 	}
 
-	mempool, err := mempool.New("mempool", registerer, toEngine)
+	var txMempool mempool.Mempool
+	if execConfig.MempoolFeePriority {
+		txMempool, err = mempool.NewWithFeePriority("mempool", registerer, toEngine, vm.ctx.AVAXAssetID)
+	} else {
+		txMempool, err = mempool.New("mempool", registerer, toEngine)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
 
 	vm.manager = blockexecutor.NewManager(
-		mempool,
+		txMempool,
 		vm.metrics,
 		vm.state,
 		txExecutorBackend,
@@ -203,7 +212,7 @@ func (vm *VM) Initialize(
 			validatorManager,
 		),
 		txVerifier,
-		mempool,
+		txMempool,
 		txExecutorBackend.Config.PartialSyncPrimaryNetwork,
 		appSender,
 		registerer,
@@ -220,7 +229,7 @@ func (vm *VM) Initialize(
 	go vm.Network.PullGossip(vm.onShutdownCtx)
 
 	vm.Builder = blockbuilder.New(
-		mempool,
+		txMempool,
 		txExecutorBackend,
 		vm.manager,
 	)
@@ -470,10 +479,26 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 			Size: stakerAttributesCacheSize,
 		},
 	}
-	err := server.RegisterService(service, "platform")
-	return map[string]http.Handler{
+	if err := server.RegisterService(service, "platform"); err != nil {
+		return nil, err
+	}
+
+	handlers := map[string]http.Handler{
 		"": server,
-	}, err
+	}
+	if !vm.adminAPIEnabled {
+		return handlers, nil
+	}
+
+	adminServer := rpc.NewServer()
+	adminServer.RegisterCodec(json.NewCodec(), "application/json")
+	adminServer.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
+	adminServer.RegisterInterceptFunc(vm.metrics.InterceptRequest)
+	adminServer.RegisterAfterFunc(vm.metrics.AfterRequest)
+	// name this service "admin"
+	err := adminServer.RegisterService(&AdminService{vm: vm}, "admin")
+	handlers["/admin"] = adminServer
+	return handlers, err
 }
 
 func (vm *VM) Connected(_ context.Context, nodeID ids.NodeID, _ *version.Application) error {
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package payload
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var _ Payload = (*BlockHash)(nil)
+
+// BlockHash attests that [BlockID] was accepted at [Height] on the source
+// chain.
+type BlockHash struct {
+	Height  uint64 `serialize:"true"`
+	BlockID ids.ID `serialize:"true"`
+
+	bytes []byte
+}
+
+// NewBlockHash creates a new *BlockHash and initializes it.
+func NewBlockHash(height uint64, blockID ids.ID) (*BlockHash, error) {
+	bhp := &BlockHash{
+		Height:  height,
+		BlockID: blockID,
+	}
+	return bhp, initialize(bhp)
+}
+
+// ParseBlockHash converts a slice of bytes into an initialized BlockHash.
+func ParseBlockHash(b []byte) (*BlockHash, error) {
+	payloadIntf, err := Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	payload, ok := payloadIntf.(*BlockHash)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errWrongType, payloadIntf)
+	}
+	return payload, nil
+}
+
+// Bytes returns the binary representation of this payload. It assumes that the
+// payload is initialized from either NewBlockHash or Parse.
+func (b *BlockHash) Bytes() []byte {
+	return b.bytes
+}
+
+func (b *BlockHash) initialize(bytes []byte) {
+	b.bytes = bytes
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package payload
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestBlockHash(t *testing.T) {
+	require := require.New(t)
+
+	blockHashPayload, err := NewBlockHash(10, ids.GenerateTestID())
+	require.NoError(err)
+
+	blockHashPayloadBytes := blockHashPayload.Bytes()
+	parsedBlockHashPayload, err := ParseBlockHash(blockHashPayloadBytes)
+	require.NoError(err)
+	require.Equal(blockHashPayload, parsedBlockHashPayload)
+}
+
+func TestParseBlockHashJunk(t *testing.T) {
+	_, err := ParseBlockHash(junkBytes)
+	require.ErrorIs(t, err, codec.ErrUnknownVersion)
+}
+
+func TestBlockHashBytes(t *testing.T) {
+	require := require.New(t)
+	base64Payload := "AAAAAAADAAAAAAAAAAoEBQYAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=="
+	blockHashPayload, err := NewBlockHash(10, ids.ID{4, 5, 6})
+	require.NoError(err)
+	require.Equal(base64Payload, base64.StdEncoding.EncodeToString(blockHashPayload.Bytes()))
+}
@@ -16,18 +16,38 @@ const (
 	MaxMessageSize = 24 * units.KiB
 )
 
-var Codec codec.Manager
+var (
+	Codec codec.Manager
+
+	// types are the payload types defined in this package. New types must be
+	// appended so that the type IDs of existing types don't change.
+	types = []Payload{
+		&Hash{},
+		&AddressedCall{},
+		&ValidatorSet{},
+		&BlockHash{},
+	}
+)
 
 func init() {
 	Codec = codec.NewManager(MaxMessageSize)
 	lc := linearcodec.NewDefault()
 
 	err := utils.Err(
-		lc.RegisterType(&Hash{}),
-		lc.RegisterType(&AddressedCall{}),
+		registerTypes(lc),
 		Codec.RegisterCodec(CodecVersion, lc),
 	)
 	if err != nil {
 		panic(err)
 	}
 }
+
+// registerTypes registers the payload types defined in this package.
+func registerTypes(lc linearcodec.Codec) error {
+	for _, t := range types {
+		if err := lc.RegisterType(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -20,15 +20,36 @@ type Payload interface {
 	initialize(b []byte)
 }
 
+// verifier is implemented by payloads that must be verified after they are
+// parsed.
+type verifier interface {
+	verify() error
+}
+
 func Parse(bytes []byte) (Payload, error) {
 	var payload Payload
 	if _, err := Codec.Unmarshal(bytes, &payload); err != nil {
 		return nil, err
 	}
+	if err := verify(payload); err != nil {
+		return nil, err
+	}
 	payload.initialize(bytes)
 	return payload, nil
 }
 
+// verify returns an error if [p] implements verifier and is invalid.
+func verify(p Payload) error {
+	v, ok := p.(verifier)
+	if !ok {
+		return nil
+	}
+	if err := v.verify(); err != nil {
+		return fmt.Errorf("invalid %T payload: %w", p, err)
+	}
+	return nil
+}
+
 func initialize(p Payload) error {
 	bytes, err := Codec.Marshal(CodecVersion, &p)
 	if err != nil {
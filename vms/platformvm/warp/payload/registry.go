@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package payload
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+)
+
+// FirstCustomTypeID is the type ID of the first payload type registered with a
+// Registry. Type IDs below it are reserved for the types defined in this
+// package, so that adding a type here doesn't change the type IDs of
+// registered types.
+const FirstCustomTypeID = 1024
+
+// Base implements Payload. Embedding it allows payload types that are defined
+// outside of this package to be registered with a Registry.
+type Base struct {
+	bytes []byte
+}
+
+// Bytes returns the binary representation of this payload. It assumes that the
+// payload is initialized from either Registry.Initialize or Registry.Parse.
+func (b *Base) Bytes() []byte {
+	return b.bytes
+}
+
+func (b *Base) initialize(bytes []byte) {
+	b.bytes = bytes
+}
+
+// Registry parses the payload types defined in this package along with payload
+// types registered by a VM.
+type Registry struct {
+	codec codec.Manager
+	lc    linearcodec.Codec
+}
+
+// NewRegistry returns a Registry that only contains the payload types defined
+// in this package.
+func NewRegistry() (*Registry, error) {
+	r := &Registry{
+		codec: codec.NewManager(MaxMessageSize),
+		lc:    linearcodec.NewDefault(),
+	}
+	if err := registerTypes(r.lc); err != nil {
+		return nil, err
+	}
+	r.lc.SkipRegistrations(FirstCustomTypeID - len(types))
+	return r, r.codec.RegisterCodec(CodecVersion, r.lc)
+}
+
+// Register assigns the next type ID, starting at FirstCustomTypeID, to the type
+// of [payload]. Types must be registered in the same order by every user of
+// the payloads for their type IDs to match.
+func (r *Registry) Register(payload Payload) error {
+	return r.lc.RegisterType(payload)
+}
+
+// Initialize sets the binary representation of [payload].
+func (r *Registry) Initialize(payload Payload) error {
+	bytes, err := r.codec.Marshal(CodecVersion, &payload)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal %T payload: %w", payload, err)
+	}
+	payload.initialize(bytes)
+	return nil
+}
+
+// Parse converts a slice of bytes into an initialized payload of a type defined
+// in this package or registered with this Registry.
+func (r *Registry) Parse(bytes []byte) (Payload, error) {
+	var payload Payload
+	if _, err := r.codec.Unmarshal(bytes, &payload); err != nil {
+		return nil, err
+	}
+	if err := verify(payload); err != nil {
+		return nil, err
+	}
+	payload.initialize(bytes)
+	return payload, nil
+}
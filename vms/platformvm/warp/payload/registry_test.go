@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package payload
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+type testPayload struct {
+	Base
+
+	Value uint64 `serialize:"true"`
+}
+
+func TestRegistry(t *testing.T) {
+	require := require.New(t)
+
+	registry, err := NewRegistry()
+	require.NoError(err)
+	require.NoError(registry.Register(&testPayload{}))
+
+	err = registry.Register(&testPayload{})
+	require.ErrorIs(err, codec.ErrDuplicateType)
+
+	customPayload := &testPayload{
+		Value: 5,
+	}
+	require.NoError(registry.Initialize(customPayload))
+
+	// The type ID follows the codec version
+	customPayloadBytes := customPayload.Bytes()
+	require.Equal(uint32(FirstCustomTypeID), binary.BigEndian.Uint32(customPayloadBytes[2:]))
+
+	parsedCustomPayload, err := registry.Parse(customPayloadBytes)
+	require.NoError(err)
+	require.Equal(customPayload, parsedCustomPayload)
+
+	// Payloads defined in this package can be parsed by the registry
+	hashPayload, err := NewHash(ids.GenerateTestID())
+	require.NoError(err)
+
+	parsedHashPayload, err := registry.Parse(hashPayload.Bytes())
+	require.NoError(err)
+	require.Equal(hashPayload, parsedHashPayload)
+
+	blockHashPayload, err := NewBlockHash(10, ids.GenerateTestID())
+	require.NoError(err)
+
+	parsedBlockHashPayload, err := registry.Parse(blockHashPayload.Bytes())
+	require.NoError(err)
+	require.Equal(blockHashPayload, parsedBlockHashPayload)
+}
+
+func TestRegistryParseJunk(t *testing.T) {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+
+	_, err = registry.Parse(junkBytes)
+	require.ErrorIs(t, err, codec.ErrUnknownVersion)
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package payload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// validatorSetOverhead is the size of a ValidatorSet payload, including
+	// its codec version and type ID, without any validators.
+	validatorSetOverhead = wrappers.ShortLen + wrappers.IntLen + ids.IDLen + wrappers.LongLen + wrappers.IntLen
+	// validatorSize is the size of a Validator.
+	validatorSize = bls.PublicKeyLen + wrappers.LongLen
+
+	// MaxValidators is the maximum number of validators in a ValidatorSet, as
+	// the payload can't be larger than [MaxMessageSize].
+	MaxValidators = (MaxMessageSize - validatorSetOverhead) / validatorSize
+)
+
+var (
+	ErrTooManyValidators   = errors.New("too many validators")
+	ErrInvalidPublicKey    = errors.New("invalid public key")
+	ErrDuplicateValidator  = errors.New("duplicate validator")
+	ErrValidatorsNotSorted = errors.New("validators not sorted")
+
+	_ Payload                             = (*ValidatorSet)(nil)
+	_ verifier                            = (*ValidatorSet)(nil)
+	_ utils.Sortable[*canonicalValidator] = (*canonicalValidator)(nil)
+)
+
+// Validator is a member of a ValidatorSet.
+type Validator struct {
+	// PublicKey is the compressed BLS public key of the validator.
+	PublicKey [bls.PublicKeyLen]byte `serialize:"true"`
+	Weight    uint64                 `serialize:"true"`
+}
+
+// ValidatorSet attests that the validators of [SubnetID] at [PChainHeight] are
+// [Validators].
+//
+// Validators are sorted by their uncompressed public key, which is the order
+// of warp.GetCanonicalValidatorSet, and each public key is included at most
+// once. At most [MaxValidators] validators can be included, so the validator
+// sets of larger subnets, such as the Primary Network, can't be attested to.
+type ValidatorSet struct {
+	SubnetID     ids.ID      `serialize:"true"`
+	PChainHeight uint64      `serialize:"true"`
+	Validators   []Validator `serialize:"true"`
+
+	bytes []byte
+}
+
+// NewValidatorSet creates a new *ValidatorSet and initializes it. The
+// validators are sorted into their canonical order. Returns an error if there
+// are more than [MaxValidators] validators, or if a public key is invalid or
+// is included more than once.
+func NewValidatorSet(
+	subnetID ids.ID,
+	pChainHeight uint64,
+	validators []Validator,
+) (*ValidatorSet, error) {
+	if len(validators) > MaxValidators {
+		return nil, fmt.Errorf("%w: %d > %d", ErrTooManyValidators, len(validators), MaxValidators)
+	}
+
+	canonicalVdrs, err := newCanonicalValidators(validators)
+	if err != nil {
+		return nil, err
+	}
+	utils.Sort(canonicalVdrs)
+
+	sortedVdrs := make([]Validator, len(canonicalVdrs))
+	for i, vdr := range canonicalVdrs {
+		if i > 0 && vdr.Compare(canonicalVdrs[i-1]) == 0 {
+			return nil, fmt.Errorf("%w: %x", ErrDuplicateValidator, vdr.PublicKey)
+		}
+		sortedVdrs[i] = vdr.Validator
+	}
+
+	vsp := &ValidatorSet{
+		SubnetID:     subnetID,
+		PChainHeight: pChainHeight,
+		Validators:   sortedVdrs,
+	}
+	return vsp, initialize(vsp)
+}
+
+// ParseValidatorSet converts a slice of bytes into an initialized
+// ValidatorSet.
+func ParseValidatorSet(b []byte) (*ValidatorSet, error) {
+	payloadIntf, err := Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	payload, ok := payloadIntf.(*ValidatorSet)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errWrongType, payloadIntf)
+	}
+	return payload, nil
+}
+
+// Bytes returns the binary representation of this payload. It assumes that the
+// payload is initialized from either NewValidatorSet or Parse.
+func (v *ValidatorSet) Bytes() []byte {
+	return v.bytes
+}
+
+func (v *ValidatorSet) initialize(bytes []byte) {
+	v.bytes = bytes
+}
+
+// verify returns an error if the validators aren't in their canonical order or
+// if a public key is invalid or is included more than once.
+func (v *ValidatorSet) verify() error {
+	canonicalVdrs, err := newCanonicalValidators(v.Validators)
+	if err != nil {
+		return err
+	}
+	for i := 1; i < len(canonicalVdrs); i++ {
+		switch canonicalVdrs[i].Compare(canonicalVdrs[i-1]) {
+		case 0:
+			return fmt.Errorf("%w: %x", ErrDuplicateValidator, canonicalVdrs[i].PublicKey)
+		case -1:
+			return fmt.Errorf("%w: %x before %x", ErrValidatorsNotSorted, canonicalVdrs[i-1].PublicKey, canonicalVdrs[i].PublicKey)
+		}
+	}
+	return nil
+}
+
+// canonicalValidator is a Validator along with its uncompressed public key,
+// which determines the canonical order of validators.
+type canonicalValidator struct {
+	Validator
+
+	publicKeyBytes []byte
+}
+
+func newCanonicalValidators(validators []Validator) ([]*canonicalValidator, error) {
+	canonicalVdrs := make([]*canonicalValidator, len(validators))
+	for i, vdr := range validators {
+		pk, err := bls.PublicKeyFromCompressedBytes(vdr.PublicKey[:])
+		if err != nil {
+			return nil, fmt.Errorf("%w %x: %w", ErrInvalidPublicKey, vdr.PublicKey, err)
+		}
+		canonicalVdrs[i] = &canonicalValidator{
+			Validator:      vdr,
+			publicKeyBytes: bls.PublicKeyToUncompressedBytes(pk),
+		}
+	}
+	return canonicalVdrs, nil
+}
+
+func (v *canonicalValidator) Compare(o *canonicalValidator) int {
+	return bytes.Compare(v.publicKeyBytes, o.publicKeyBytes)
+}
@@ -0,0 +1,204 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package payload
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+func newTestValidator(t *testing.T, weight uint64) Validator {
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+
+	vdr := Validator{
+		Weight: weight,
+	}
+	copy(vdr.PublicKey[:], bls.PublicKeyToCompressedBytes(bls.PublicFromSecretKey(sk)))
+	return vdr
+}
+
+func TestValidatorSet(t *testing.T) {
+	require := require.New(t)
+
+	validators := make([]Validator, 5)
+	for i := range validators {
+		validators[i] = newTestValidator(t, uint64(i+1))
+	}
+
+	validatorSetPayload, err := NewValidatorSet(
+		ids.GenerateTestID(),
+		10,
+		validators,
+	)
+	require.NoError(err)
+	require.ElementsMatch(validators, validatorSetPayload.Validators)
+
+	// The validators are sorted by their uncompressed public key
+	canonicalVdrs, err := newCanonicalValidators(validatorSetPayload.Validators)
+	require.NoError(err)
+	require.True(utils.IsSortedAndUnique(canonicalVdrs))
+
+	validatorSetPayloadBytes := validatorSetPayload.Bytes()
+	parsedValidatorSetPayload, err := ParseValidatorSet(validatorSetPayloadBytes)
+	require.NoError(err)
+	require.Equal(validatorSetPayload, parsedValidatorSetPayload)
+}
+
+func TestValidatorSetMaxValidators(t *testing.T) {
+	require := require.New(t)
+
+	validators := make([]Validator, MaxValidators)
+	for i := range validators {
+		validators[i] = newTestValidator(t, uint64(i+1))
+	}
+
+	validatorSetPayload, err := NewValidatorSet(ids.GenerateTestID(), 10, validators)
+	require.NoError(err)
+	require.LessOrEqual(len(validatorSetPayload.Bytes()), MaxMessageSize)
+	require.Greater(len(validatorSetPayload.Bytes())+validatorSize, MaxMessageSize)
+}
+
+func TestNewValidatorSetInvalid(t *testing.T) {
+	vdr := newTestValidator(t, 1)
+
+	tests := []struct {
+		name        string
+		validators  []Validator
+		expectedErr error
+	}{
+		{
+			name: "invalid public key",
+			validators: []Validator{
+				{
+					PublicKey: [bls.PublicKeyLen]byte{1},
+					Weight:    1,
+				},
+			},
+			expectedErr: ErrInvalidPublicKey,
+		},
+		{
+			name: "duplicate public key",
+			validators: []Validator{
+				vdr,
+				newTestValidator(t, 2),
+				{
+					PublicKey: vdr.PublicKey,
+					Weight:    3,
+				},
+			},
+			expectedErr: ErrDuplicateValidator,
+		},
+		{
+			name:        "too many validators",
+			validators:  make([]Validator, MaxValidators+1),
+			expectedErr: ErrTooManyValidators,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewValidatorSet(ids.GenerateTestID(), 10, test.validators)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestParseValidatorSetInvalid(t *testing.T) {
+	validatorSetPayload, err := NewValidatorSet(
+		ids.GenerateTestID(),
+		10,
+		[]Validator{
+			newTestValidator(t, 1),
+			newTestValidator(t, 2),
+		},
+	)
+	require.NoError(t, err)
+	sortedVdrs := validatorSetPayload.Validators
+
+	tests := []struct {
+		name        string
+		validators  []Validator
+		expectedErr error
+	}{
+		{
+			name:        "unsorted",
+			validators:  []Validator{sortedVdrs[1], sortedVdrs[0]},
+			expectedErr: ErrValidatorsNotSorted,
+		},
+		{
+			name:        "duplicate public key",
+			validators:  []Validator{sortedVdrs[0], sortedVdrs[0]},
+			expectedErr: ErrDuplicateValidator,
+		},
+		{
+			name: "invalid public key",
+			validators: []Validator{
+				{
+					PublicKey: [bls.PublicKeyLen]byte{1},
+					Weight:    1,
+				},
+			},
+			expectedErr: ErrInvalidPublicKey,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			// Bypass the checks of NewValidatorSet
+			invalidPayload := &ValidatorSet{
+				SubnetID:     ids.GenerateTestID(),
+				PChainHeight: 10,
+				Validators:   test.validators,
+			}
+			require.NoError(initialize(invalidPayload))
+
+			_, err := ParseValidatorSet(invalidPayload.Bytes())
+			require.ErrorIs(err, test.expectedErr)
+
+			registry, err := NewRegistry()
+			require.NoError(err)
+
+			_, err = registry.Parse(invalidPayload.Bytes())
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestParseValidatorSetJunk(t *testing.T) {
+	_, err := ParseValidatorSet(junkBytes)
+	require.ErrorIs(t, err, codec.ErrUnknownVersion)
+}
+
+func TestValidatorSetBytes(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.SecretKeyFromBytes([]byte{
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+		0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+		0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+	})
+	require.NoError(err)
+	vdr := Validator{
+		Weight: 3,
+	}
+	copy(vdr.PublicKey[:], bls.PublicKeyToCompressedBytes(bls.PublicFromSecretKey(sk)))
+
+	base64Payload := "AAAAAAACBAUGAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAACgAAAAGWogu5SF/22JUJVaYp6AQ6Q3dZaKwTPrexnF8DiaIlNnar3WyGx7aNOKG39q+GUOcAAAAAAAAAAw=="
+	validatorSetPayload, err := NewValidatorSet(
+		ids.ID{4, 5, 6},
+		10,
+		[]Validator{vdr},
+	)
+	require.NoError(err)
+	require.Equal(base64Payload, base64.StdEncoding.EncodeToString(validatorSetPayload.Bytes()))
+}
@@ -330,13 +330,15 @@ func (p *postForkCommonComponents) verifyPreDurangoBlockDelay(
 		blkTimestamp = blk.Timestamp()
 		childHeight  = blk.Height()
 		proposerID   = blk.Proposer()
+		params       = p.vm.ProposerWindowConfig.ParamsAt(parentTimestamp)
 	)
-	minDelay, err := p.vm.Windower.Delay(
+	minDelay, err := p.vm.Windower.DelayWithWindowDuration(
 		ctx,
 		childHeight,
 		parentPChainHeight,
 		proposerID,
-		proposer.MaxVerifyWindows,
+		params.MaxWindows,
+		params.WindowDuration,
 	)
 	if err != nil {
 		p.vm.ctx.Log.Error("unexpected block verification failure",
@@ -352,7 +354,7 @@ func (p *postForkCommonComponents) verifyPreDurangoBlockDelay(
 		return false, fmt.Errorf("%w: delay %s < minDelay %s", errProposerWindowNotStarted, delay, minDelay)
 	}
 
-	return delay < proposer.MaxVerifyDelay, nil
+	return delay < params.MaxVerifyDelay(), nil
 }
 
 func (p *postForkCommonComponents) verifyPostDurangoBlockDelay(
@@ -364,7 +366,8 @@ func (p *postForkCommonComponents) verifyPostDurangoBlockDelay(
 	var (
 		blkTimestamp = blk.Timestamp()
 		blkHeight    = blk.Height()
-		currentSlot  = proposer.TimeToSlot(parentTimestamp, blkTimestamp)
+		params       = p.vm.ProposerWindowConfig.ParamsAt(parentTimestamp)
+		currentSlot  = params.TimeToSlot(parentTimestamp, blkTimestamp)
 		proposerID   = blk.Proposer()
 	)
 
@@ -398,8 +401,11 @@ func (p *postForkCommonComponents) shouldBuildSignedBlockPostDurango(
 	parentPChainHeight uint64,
 	newTimestamp time.Time,
 ) (bool, error) {
-	parentHeight := p.innerBlk.Height()
-	currentSlot := proposer.TimeToSlot(parentTimestamp, newTimestamp)
+	var (
+		parentHeight = p.innerBlk.Height()
+		params       = p.vm.ProposerWindowConfig.ParamsAt(parentTimestamp)
+		currentSlot  = params.TimeToSlot(parentTimestamp, newTimestamp)
+	)
 	expectedProposerID, err := p.vm.Windower.ExpectedProposer(
 		ctx,
 		parentHeight+1,
@@ -465,14 +471,17 @@ func (p *postForkCommonComponents) shouldBuildSignedBlockPreDurango(
 	parentPChainHeight uint64,
 	newTimestamp time.Time,
 ) (bool, error) {
-	delay := newTimestamp.Sub(parentTimestamp)
-	if delay >= proposer.MaxBuildDelay {
+	var (
+		params = p.vm.ProposerWindowConfig.ParamsAt(parentTimestamp)
+		delay  = newTimestamp.Sub(parentTimestamp)
+	)
+	if delay >= params.MaxBuildDelay() {
 		return false, nil // time for any node to build an unsigned block
 	}
 
 	parentHeight := p.innerBlk.Height()
 	proposerID := p.vm.ctx.NodeID
-	minDelay, err := p.vm.Windower.Delay(ctx, parentHeight+1, parentPChainHeight, proposerID, proposer.MaxBuildWindows)
+	minDelay, err := p.vm.Windower.DelayWithWindowDuration(ctx, parentHeight+1, parentPChainHeight, proposerID, proposer.MaxBuildWindows, params.WindowDuration)
 	if err != nil {
 		p.vm.ctx.Log.Error("unexpected build block failure",
 			zap.String("reason", "failed to calculate required timestamp delay"),
@@ -485,7 +494,7 @@ func (p *postForkCommonComponents) shouldBuildSignedBlockPreDurango(
 	if delay >= minDelay {
 		// it's time for this node to propose a block. It'll be signed or
 		// unsigned depending on the delay
-		return delay < proposer.MaxVerifyDelay, nil
+		return delay < params.MaxVerifyDelay(), nil
 	}
 
 	// It's not our turn to propose a block yet. This is likely caused by having
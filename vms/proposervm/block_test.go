@@ -26,6 +26,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 	"github.com/ava-labs/avalanchego/vms/proposervm/scheduler"
+
+	statelessblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
 )
 
 // Assert that when the underlying VM implements ChainVMWithBuildBlockContext
@@ -431,7 +433,7 @@ func TestPostDurangoBuildChildResetScheduler(t *testing.T) {
 	}
 
 	for _, delay := range delays {
-		windower.EXPECT().MinDelayForProposer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		windower.EXPECT().MinDelayForProposerWithWindowDuration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(delay, nil).Times(1)
 
 		// we mock the scheduler setting the exact time we expect it to be reset
@@ -448,3 +450,185 @@ func TestPostDurangoBuildChildResetScheduler(t *testing.T) {
 		require.ErrorIs(err, errUnexpectedProposer)
 	}
 }
+
+func TestPreDurangoVerifyBlockDelayProposerWindowConfig(t *testing.T) {
+	var (
+		activationTime = time.Unix(1_000, 0)
+		windowConfig   = &proposer.WindowConfig{
+			ActivationTime: activationTime,
+			Params: proposer.Params{
+				MaxWindows:     2,
+				WindowDuration: 2 * time.Second,
+			},
+		}
+	)
+
+	tests := []struct {
+		name                       string
+		parentTimestamp            time.Time
+		expectedMaxWindows         int
+		expectedWindowDuration     time.Duration
+		expectedShouldHaveProposer bool
+	}{
+		{
+			name:                       "before activation",
+			parentTimestamp:            activationTime.Add(-time.Second),
+			expectedMaxWindows:         proposer.MaxVerifyWindows,
+			expectedWindowDuration:     proposer.WindowDuration,
+			expectedShouldHaveProposer: true,
+		},
+		{
+			name:                       "at activation",
+			parentTimestamp:            activationTime,
+			expectedMaxWindows:         2,
+			expectedWindowDuration:     2 * time.Second,
+			expectedShouldHaveProposer: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			var (
+				pChainHeight uint64 = 1337
+				blkHeight    uint64 = 1234
+				proposerID          = ids.NodeIDFromCert(pTestCert)
+			)
+
+			windower := proposer.NewMockWindower(ctrl)
+			windower.EXPECT().DelayWithWindowDuration(
+				gomock.Any(),
+				blkHeight,
+				pChainHeight,
+				proposerID,
+				test.expectedMaxWindows,
+				test.expectedWindowDuration,
+			).Return(time.Duration(0), nil)
+
+			vm := &VM{
+				Config: Config{
+					ProposerWindowConfig: windowConfig,
+				},
+				ctx: &snow.Context{
+					Log: logging.NoLog{},
+				},
+				Windower: windower,
+			}
+
+			blk := buildTestPostForkBlock(t, vm, test.parentTimestamp.Add(10*time.Second), pChainHeight, blkHeight)
+			shouldHaveProposer, err := blk.verifyPreDurangoBlockDelay(
+				context.Background(),
+				test.parentTimestamp,
+				pChainHeight,
+				blk,
+			)
+			require.NoError(err)
+			require.Equal(test.expectedShouldHaveProposer, shouldHaveProposer)
+		})
+	}
+}
+
+func TestPostDurangoVerifyBlockDelayProposerWindowConfig(t *testing.T) {
+	var (
+		activationTime = time.Unix(1_000, 0)
+		windowConfig   = &proposer.WindowConfig{
+			ActivationTime: activationTime,
+			Params: proposer.Params{
+				MaxWindows:     proposer.MaxVerifyWindows,
+				WindowDuration: 2 * time.Second,
+			},
+		}
+	)
+
+	tests := []struct {
+		name            string
+		parentTimestamp time.Time
+		expectedSlot    uint64
+	}{
+		{
+			name:            "before activation",
+			parentTimestamp: activationTime.Add(-time.Second),
+			expectedSlot:    2,
+		},
+		{
+			name:            "at activation",
+			parentTimestamp: activationTime,
+			expectedSlot:    5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			var (
+				pChainHeight uint64 = 1337
+				blkHeight    uint64 = 1234
+				proposerID          = ids.NodeIDFromCert(pTestCert)
+			)
+
+			windower := proposer.NewMockWindower(ctrl)
+			windower.EXPECT().ExpectedProposer(
+				gomock.Any(),
+				blkHeight,
+				pChainHeight,
+				test.expectedSlot,
+			).Return(proposerID, nil)
+
+			vm := &VM{
+				Config: Config{
+					ProposerWindowConfig: windowConfig,
+				},
+				ctx: &snow.Context{
+					Log: logging.NoLog{},
+				},
+				Windower: windower,
+			}
+
+			blk := buildTestPostForkBlock(t, vm, test.parentTimestamp.Add(10*time.Second), pChainHeight, blkHeight)
+			shouldHaveProposer, err := blk.verifyPostDurangoBlockDelay(
+				context.Background(),
+				test.parentTimestamp,
+				pChainHeight,
+				blk,
+			)
+			require.NoError(err)
+			require.True(shouldHaveProposer)
+		})
+	}
+}
+
+func buildTestPostForkBlock(
+	t *testing.T,
+	vm *VM,
+	timestamp time.Time,
+	pChainHeight uint64,
+	height uint64,
+) *postForkBlock {
+	innerBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV: ids.GenerateTestID(),
+		},
+		HeightV: height,
+		BytesV:  []byte{1},
+	}
+	slb, err := statelessblock.Build(
+		ids.GenerateTestID(),
+		timestamp,
+		pChainHeight,
+		pTestCert,
+		innerBlk.Bytes(),
+		ids.GenerateTestID(),
+		pTestSigner,
+	)
+	require.NoError(t, err)
+
+	return &postForkBlock{
+		SignedBlock: slb,
+		postForkCommonComponents: postForkCommonComponents{
+			vm:       vm,
+			innerBlk: innerBlk,
+		},
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var _ Client = (*client)(nil)
+
+// Client interface for interacting with the proposervm API of a chain
+type Client interface {
+	// GetProposers returns the proposers of the next block of the chain and
+	// the windows that recently accepted blocks were proposed in
+	GetProposers(context.Context, ...rpc.Option) (*GetProposersReply, error)
+}
+
+// client implementation for interacting with the proposervm API of a chain
+type client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a Client for interacting with the proposervm API of
+// [chain] on the node at [uri]
+func NewClient(uri, chain string) Client {
+	path := fmt.Sprintf(
+		"%s/ext/%s/%s%s",
+		uri,
+		constants.ChainAliasPrefix,
+		chain,
+		apiEndpoint,
+	)
+	return &client{
+		requester: rpc.NewEndpointRequester(path),
+	}
+}
+
+func (c *client) GetProposers(ctx context.Context, options ...rpc.Option) (*GetProposersReply, error) {
+	res := &GetProposersReply{}
+	err := c.requester.SendRequest(ctx, "proposervm.getProposers", struct{}{}, res, options...)
+	return res, err
+}
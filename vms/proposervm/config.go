@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
 )
 
 type Config struct {
@@ -32,6 +33,10 @@ type Config struct {
 
 	// Block certificate
 	StakingCertLeaf *staking.Certificate
+
+	// Proposer windows to use once activated. If nil, the default proposer
+	// windows are always used.
+	ProposerWindowConfig *proposer.WindowConfig
 }
 
 func (c *Config) IsDurangoActivated(timestamp time.Time) bool {
@@ -56,6 +56,21 @@ func (mr *MockWindowerMockRecorder) Delay(arg0, arg1, arg2, arg3, arg4 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delay", reflect.TypeOf((*MockWindower)(nil).Delay), arg0, arg1, arg2, arg3, arg4)
 }
 
+// DelayWithWindowDuration mocks base method.
+func (m *MockWindower) DelayWithWindowDuration(arg0 context.Context, arg1, arg2 uint64, arg3 ids.NodeID, arg4 int, arg5 time.Duration) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelayWithWindowDuration", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DelayWithWindowDuration indicates an expected call of DelayWithWindowDuration.
+func (mr *MockWindowerMockRecorder) DelayWithWindowDuration(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelayWithWindowDuration", reflect.TypeOf((*MockWindower)(nil).DelayWithWindowDuration), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // ExpectedProposer mocks base method.
 func (m *MockWindower) ExpectedProposer(arg0 context.Context, arg1, arg2, arg3 uint64) (ids.NodeID, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +101,21 @@ func (mr *MockWindowerMockRecorder) MinDelayForProposer(arg0, arg1, arg2, arg3,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinDelayForProposer", reflect.TypeOf((*MockWindower)(nil).MinDelayForProposer), arg0, arg1, arg2, arg3, arg4)
 }
 
+// MinDelayForProposerWithWindowDuration mocks base method.
+func (m *MockWindower) MinDelayForProposerWithWindowDuration(arg0 context.Context, arg1, arg2 uint64, arg3 ids.NodeID, arg4 uint64, arg5 time.Duration) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinDelayForProposerWithWindowDuration", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MinDelayForProposerWithWindowDuration indicates an expected call of MinDelayForProposerWithWindowDuration.
+func (mr *MockWindowerMockRecorder) MinDelayForProposerWithWindowDuration(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinDelayForProposerWithWindowDuration", reflect.TypeOf((*MockWindower)(nil).MinDelayForProposerWithWindowDuration), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // Proposers mocks base method.
 func (m *MockWindower) Proposers(arg0 context.Context, arg1, arg2 uint64, arg3 int) ([]ids.NodeID, error) {
 	m.ctrl.T.Helper()
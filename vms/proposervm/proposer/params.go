@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// DefaultParams are the proposer window parameters used by chains that
+	// don't configure their own.
+	DefaultParams = Params{
+		MaxWindows:     MaxVerifyWindows,
+		WindowDuration: WindowDuration,
+	}
+
+	ErrInvalidMaxWindows     = errors.New("invalid max windows")
+	ErrInvalidWindowDuration = errors.New("invalid window duration")
+)
+
+// Params define the proposer windows of a chain.
+type Params struct {
+	// MaxWindows is the number of proposers that are given a window before any
+	// node can propose a block. Post-Durango, every slot has a proposer, so
+	// this only bounds the windows reported by the API. If zero,
+	// [MaxVerifyWindows] is used.
+	MaxWindows int `json:"maxWindows" yaml:"maxWindows"`
+	// WindowDuration is the length of each proposer window. It must be a
+	// whole number of seconds, as block timestamps only have one second
+	// granularity.
+	//
+	// The window duration doesn't bound how quickly blocks are produced. The
+	// proposer of the first window can build a block as soon as its parent is
+	// accepted, subject to the node's minimum block delay, so chains can target
+	// sub-second block times with one second windows. The window duration is how long a proposer has to build a block
+	// before the next proposer can.
+	WindowDuration time.Duration `json:"windowDuration" yaml:"windowDuration"`
+}
+
+// Verify returns an error if the windows can't be used to propose blocks.
+func (p Params) Verify() error {
+	switch {
+	case p.MaxWindows < 0 || p.MaxWindows > MaxBuildWindows:
+		return fmt.Errorf("%w: %d not in [0, %d]", ErrInvalidMaxWindows, p.MaxWindows, MaxBuildWindows)
+	case p.WindowDuration < time.Second || p.WindowDuration%time.Second != 0:
+		// Block timestamps are only specific to the second, so the slot of a
+		// block can't be determined for windows that aren't whole seconds.
+		// Supporting sub-second windows would require sub-second block
+		// timestamps.
+		return fmt.Errorf("%w: %s isn't a positive number of seconds, which is required because block timestamps only have one second granularity",
+			ErrInvalidWindowDuration,
+			p.WindowDuration,
+		)
+	default:
+		return nil
+	}
+}
+
+// MaxVerifyDelay is the delay after which any node can propose a block
+// pre-Durango.
+func (p Params) MaxVerifyDelay() time.Duration {
+	return time.Duration(p.MaxWindows) * p.WindowDuration
+}
+
+// MaxBuildDelay is the delay after which this node will build an unsigned
+// block pre-Durango.
+func (p Params) MaxBuildDelay() time.Duration {
+	return MaxBuildWindows * p.WindowDuration
+}
+
+// TimeToSlot returns the window that [now] is in, when the first window starts
+// at [start].
+func (p Params) TimeToSlot(start, now time.Time) uint64 {
+	if now.Before(start) {
+		return 0
+	}
+	return uint64(now.Sub(start) / p.WindowDuration)
+}
+
+// WindowConfig configures the proposer windows of a chain from
+// [ActivationTime] onwards. Blocks whose parent's timestamp is before
+// [ActivationTime] use [DefaultParams].
+//
+// The same config must be used by every node of the chain.
+type WindowConfig struct {
+	ActivationTime time.Time `json:"activationTime" yaml:"activationTime"`
+	Params         `yaml:",inline"`
+}
+
+// ParamsAt returns the proposer window parameters of a block whose parent has
+// [parentTimestamp]. A nil config always uses [DefaultParams].
+func (c *WindowConfig) ParamsAt(parentTimestamp time.Time) Params {
+	if c == nil || parentTimestamp.Before(c.ActivationTime) {
+		return DefaultParams
+	}
+	params := c.Params
+	if params.MaxWindows == 0 {
+		params.MaxWindows = DefaultParams.MaxWindows
+	}
+	return params
+}
+
+// MaxWindowsAffectsBlocks returns true if [c.MaxWindows] changes which blocks
+// are valid. Post-Durango, every slot has a proposer, so [c.MaxWindows] only
+// affects blocks if [c] activates before [durangoTime].
+func (c *WindowConfig) MaxWindowsAffectsBlocks(durangoTime time.Time) bool {
+	return c != nil && c.ActivationTime.Before(durangoTime)
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamsVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      Params
+		expectedErr error
+	}{
+		{
+			name:        "default",
+			params:      DefaultParams,
+			expectedErr: nil,
+		},
+		{
+			name: "max build windows",
+			params: Params{
+				MaxWindows:     MaxBuildWindows,
+				WindowDuration: time.Second,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "default windows",
+			params: Params{
+				MaxWindows:     0,
+				WindowDuration: WindowDuration,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "negative windows",
+			params: Params{
+				MaxWindows:     -1,
+				WindowDuration: WindowDuration,
+			},
+			expectedErr: ErrInvalidMaxWindows,
+		},
+		{
+			name: "too many windows",
+			params: Params{
+				MaxWindows:     MaxBuildWindows + 1,
+				WindowDuration: WindowDuration,
+			},
+			expectedErr: ErrInvalidMaxWindows,
+		},
+		{
+			name: "zero window duration",
+			params: Params{
+				MaxWindows:     MaxVerifyWindows,
+				WindowDuration: 0,
+			},
+			expectedErr: ErrInvalidWindowDuration,
+		},
+		{
+			name: "sub-second window duration",
+			params: Params{
+				MaxWindows:     MaxVerifyWindows,
+				WindowDuration: 1500 * time.Millisecond,
+			},
+			expectedErr: ErrInvalidWindowDuration,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.params.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestParamsDelays(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(MaxVerifyDelay, DefaultParams.MaxVerifyDelay())
+	require.Equal(MaxBuildDelay, DefaultParams.MaxBuildDelay())
+
+	params := Params{
+		MaxWindows:     2,
+		WindowDuration: 2 * time.Second,
+	}
+	require.Equal(4*time.Second, params.MaxVerifyDelay())
+	require.Equal(MaxBuildWindows*2*time.Second, params.MaxBuildDelay())
+
+	start := time.Unix(1_000, 0)
+	require.Zero(params.TimeToSlot(start, start.Add(-time.Second)))
+	require.Equal(uint64(2), params.TimeToSlot(start, start.Add(5*time.Second)))
+}
+
+func TestWindowConfigParamsAt(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		activationTime = time.Unix(1_000, 0)
+		params         = Params{
+			MaxWindows:     2,
+			WindowDuration: 2 * time.Second,
+		}
+		config = &WindowConfig{
+			ActivationTime: activationTime,
+			Params:         params,
+		}
+		nilConfig *WindowConfig
+	)
+
+	require.Equal(DefaultParams, nilConfig.ParamsAt(activationTime))
+	require.Equal(DefaultParams, config.ParamsAt(activationTime.Add(-time.Second)))
+	require.Equal(params, config.ParamsAt(activationTime))
+	require.Equal(params, config.ParamsAt(activationTime.Add(time.Second)))
+
+	// Omitting the max windows uses the default
+	config.MaxWindows = 0
+	require.Equal(
+		Params{
+			MaxWindows:     MaxVerifyWindows,
+			WindowDuration: 2 * time.Second,
+		},
+		config.ParamsAt(activationTime),
+	)
+}
+
+func TestWindowConfigMaxWindowsAffectsBlocks(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		durangoTime = time.Unix(1_000, 0)
+		nilConfig   *WindowConfig
+	)
+	require.False(nilConfig.MaxWindowsAffectsBlocks(durangoTime))
+
+	config := &WindowConfig{
+		ActivationTime: durangoTime.Add(-time.Second),
+	}
+	require.True(config.MaxWindowsAffectsBlocks(durangoTime))
+
+	config.ActivationTime = durangoTime
+	require.False(config.MaxWindowsAffectsBlocks(durangoTime))
+}
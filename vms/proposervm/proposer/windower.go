@@ -21,6 +21,9 @@ import (
 )
 
 // Proposer list constants
+//
+// WindowDuration and MaxVerifyWindows are the default proposer window
+// parameters. See [Params].
 const (
 	WindowDuration = 5 * time.Second
 
@@ -44,7 +47,7 @@ type Windower interface {
 	// Proposers returns the proposer list for building a block at [blockHeight]
 	// when the validator set is defined at [pChainHeight]. The list is returned
 	// in order. The minimum delay of a validator is the index they appear times
-	// [WindowDuration].
+	// the window duration.
 	Proposers(
 		ctx context.Context,
 		blockHeight,
@@ -63,6 +66,17 @@ type Windower interface {
 		maxWindows int,
 	) (time.Duration, error)
 
+	// DelayWithWindowDuration is the same as [Delay], except that each window
+	// lasts [windowDuration] rather than [WindowDuration].
+	DelayWithWindowDuration(
+		ctx context.Context,
+		blockHeight,
+		pChainHeight uint64,
+		validatorID ids.NodeID,
+		maxWindows int,
+		windowDuration time.Duration,
+	) (time.Duration, error)
+
 	// In the Post-Durango windowing scheme, every validator active at
 	// [pChainHeight] gets specific slots it can propose in (instead of being
 	// able to propose from a given time on as it happens Pre-Durango).
@@ -93,6 +107,18 @@ type Windower interface {
 		nodeID ids.NodeID,
 		startSlot uint64,
 	) (time.Duration, error)
+
+	// MinDelayForProposerWithWindowDuration is the same as
+	// [MinDelayForProposer], except that each slot lasts [windowDuration]
+	// rather than [WindowDuration].
+	MinDelayForProposerWithWindowDuration(
+		ctx context.Context,
+		blockHeight,
+		pChainHeight uint64,
+		nodeID ids.NodeID,
+		startSlot uint64,
+		windowDuration time.Duration,
+	) (time.Duration, error)
 }
 
 // windower interfaces with P-Chain and it is responsible for calculating the
@@ -145,8 +171,12 @@ func (w *windower) Proposers(ctx context.Context, blockHeight, pChainHeight uint
 }
 
 func (w *windower) Delay(ctx context.Context, blockHeight, pChainHeight uint64, validatorID ids.NodeID, maxWindows int) (time.Duration, error) {
+	return w.DelayWithWindowDuration(ctx, blockHeight, pChainHeight, validatorID, maxWindows, WindowDuration)
+}
+
+func (w *windower) DelayWithWindowDuration(ctx context.Context, blockHeight, pChainHeight uint64, validatorID ids.NodeID, maxWindows int, windowDuration time.Duration) (time.Duration, error) {
 	if validatorID == ids.EmptyNodeID {
-		return time.Duration(maxWindows) * WindowDuration, nil
+		return time.Duration(maxWindows) * windowDuration, nil
 	}
 
 	proposers, err := w.Proposers(ctx, blockHeight, pChainHeight, maxWindows)
@@ -159,7 +189,7 @@ func (w *windower) Delay(ctx context.Context, blockHeight, pChainHeight uint64,
 		if nodeID == validatorID {
 			return delay, nil
 		}
-		delay += WindowDuration
+		delay += windowDuration
 	}
 	return delay, nil
 }
@@ -194,6 +224,17 @@ func (w *windower) MinDelayForProposer(
 	pChainHeight uint64,
 	nodeID ids.NodeID,
 	startSlot uint64,
+) (time.Duration, error) {
+	return w.MinDelayForProposerWithWindowDuration(ctx, blockHeight, pChainHeight, nodeID, startSlot, WindowDuration)
+}
+
+func (w *windower) MinDelayForProposerWithWindowDuration(
+	ctx context.Context,
+	blockHeight,
+	pChainHeight uint64,
+	nodeID ids.NodeID,
+	startSlot uint64,
+	windowDuration time.Duration,
 ) (time.Duration, error) {
 	source := prng.NewMT19937_64()
 	sampler, validators, err := w.makeSampler(ctx, pChainHeight, source)
@@ -218,12 +259,12 @@ func (w *windower) MinDelayForProposer(
 		}
 
 		if expectedNodeID == nodeID {
-			return time.Duration(slot) * WindowDuration, nil
+			return time.Duration(slot) * windowDuration, nil
 		}
 	}
 
 	// no slots scheduled for the max window we inspect. Return max delay
-	return time.Duration(maxSlot) * WindowDuration, nil
+	return time.Duration(maxSlot) * windowDuration, nil
 }
 
 func (w *windower) makeSampler(
@@ -278,9 +319,8 @@ func (w *windower) expectedProposer(
 	return validators[indices[0]].id, nil
 }
 
+// TimeToSlot returns the window that [now] is in, when the first window starts
+// at [start] and the windows use [DefaultParams].
 func TimeToSlot(start, now time.Time) uint64 {
-	if now.Before(start) {
-		return 0
-	}
-	return uint64(now.Sub(start) / WindowDuration)
+	return DefaultParams.TimeToSlot(start, now)
 }
@@ -80,6 +80,33 @@ func TestWindowerRepeatedValidator(t *testing.T) {
 	require.Equal(MaxVerifyDelay, nonValidatorDelay)
 }
 
+func TestWindowerWindowDuration(t *testing.T) {
+	require := require.New(t)
+
+	validatorIDs, vdrState := makeValidators(t, MaxVerifyWindows)
+	w := New(vdrState, subnetID, randomChainID)
+
+	const (
+		chainHeight    uint64 = 1
+		pChainHeight   uint64 = 0
+		startSlot      uint64 = 1
+		windowDuration        = 2 * WindowDuration
+	)
+	for _, validatorID := range validatorIDs {
+		delay, err := w.Delay(context.Background(), chainHeight, pChainHeight, validatorID, MaxVerifyWindows)
+		require.NoError(err)
+		scaledDelay, err := w.DelayWithWindowDuration(context.Background(), chainHeight, pChainHeight, validatorID, MaxVerifyWindows, windowDuration)
+		require.NoError(err)
+		require.Equal(2*delay, scaledDelay)
+
+		delay, err = w.MinDelayForProposer(context.Background(), chainHeight, pChainHeight, validatorID, startSlot)
+		require.NoError(err)
+		scaledDelay, err = w.MinDelayForProposerWithWindowDuration(context.Background(), chainHeight, pChainHeight, validatorID, startSlot, windowDuration)
+		require.NoError(err)
+		require.Equal(2*delay, scaledDelay)
+	}
+}
+
 func TestDelayChangeByHeight(t *testing.T) {
 	require := require.New(t)
 
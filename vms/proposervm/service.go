@@ -0,0 +1,233 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+const (
+	// numProposalsHistory is the maximum number of recently accepted blocks
+	// reported by getProposers.
+	numProposalsHistory = 10
+)
+
+// Service is the API service for the proposervm
+type Service struct {
+	vm *VM
+}
+
+// ProposerWindow is a window in which [NodeID] may propose a block.
+type ProposerWindow struct {
+	NodeID    ids.NodeID `json:"nodeID"`
+	StartTime time.Time  `json:"startTime"`
+}
+
+// Proposal is a block that was proposed in [Window]. [MissedProposers] are the
+// proposers of the earlier windows, which didn't propose a block.
+type Proposal struct {
+	BlockID         ids.ID         `json:"blockID"`
+	Height          avajson.Uint64 `json:"height"`
+	Timestamp       time.Time      `json:"timestamp"`
+	Proposer        ids.NodeID     `json:"proposer"`
+	Window          avajson.Uint64 `json:"window"`
+	MissedProposers []ids.NodeID   `json:"missedProposers"`
+}
+
+// GetProposersReply is the response from GetProposers
+type GetProposersReply struct {
+	// Height is the height of the next block
+	Height avajson.Uint64 `json:"height"`
+	// ParentID is the ID of the preferred block, which the next block will be
+	// built on
+	ParentID ids.ID `json:"parentID"`
+	// PChainHeight is the P-chain height that the proposers are sampled at
+	PChainHeight avajson.Uint64 `json:"pChainHeight"`
+	// Proposers are the proposers of the next block, in order. If empty, any
+	// node can propose the next block.
+	Proposers []ProposerWindow `json:"proposers"`
+	// IsProposer is true if this node is one of [Proposers]
+	IsProposer bool `json:"isProposer"`
+	// RecentProposals are the most recently accepted blocks, starting with the
+	// last accepted block
+	RecentProposals []Proposal `json:"recentProposals"`
+}
+
+// GetProposers returns the proposer windows for the next block along with the
+// windows that recently accepted blocks were proposed in.
+func (s *Service) GetProposers(r *http.Request, _ *struct{}, reply *GetProposersReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "proposervm"),
+		zap.String("method", "getProposers"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	ctx := r.Context()
+	parentID := s.vm.preferred
+	if parentID == ids.Empty {
+		var err error
+		parentID, err = s.vm.LastAccepted(ctx)
+		if err != nil {
+			return fmt.Errorf("couldn't get last accepted block: %w", err)
+		}
+	}
+
+	parent, err := s.vm.getBlock(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("couldn't get block %s: %w", parentID, err)
+	}
+	pChainHeight, err := parent.pChainHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't get P-chain height of %s: %w", parentID, err)
+	}
+
+	var (
+		height          = parent.Height() + 1
+		parentTimestamp = parent.Timestamp()
+		params          = s.vm.ProposerWindowConfig.ParamsAt(parentTimestamp)
+	)
+	nodeIDs, err := s.vm.proposers(ctx, height, pChainHeight, parentTimestamp, params.MaxWindows)
+	if err != nil {
+		return fmt.Errorf("couldn't get proposers: %w", err)
+	}
+
+	reply.Height = avajson.Uint64(height)
+	reply.ParentID = parentID
+	reply.PChainHeight = avajson.Uint64(pChainHeight)
+	reply.Proposers = make([]ProposerWindow, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		reply.Proposers[i] = ProposerWindow{
+			NodeID:    nodeID,
+			StartTime: parentTimestamp.Add(time.Duration(i) * params.WindowDuration),
+		}
+		reply.IsProposer = reply.IsProposer || nodeID == s.vm.ctx.NodeID
+	}
+
+	reply.RecentProposals, err = s.vm.recentProposals(ctx, numProposalsHistory)
+	if err != nil {
+		return fmt.Errorf("couldn't get recent proposals: %w", err)
+	}
+	return nil
+}
+
+// proposers returns the proposers of the first [numWindows] windows for
+// building a block at [blkHeight] on a parent with [parentTimestamp]. If any
+// node can propose, an empty list is returned.
+func (vm *VM) proposers(
+	ctx context.Context,
+	blkHeight,
+	pChainHeight uint64,
+	parentTimestamp time.Time,
+	numWindows int,
+) ([]ids.NodeID, error) {
+	if !vm.IsDurangoActivated(parentTimestamp) {
+		return vm.Windower.Proposers(ctx, blkHeight, pChainHeight, numWindows)
+	}
+
+	// Post-Durango, each window has a single proposer.
+	nodeIDs := make([]ids.NodeID, numWindows)
+	for slot := range nodeIDs {
+		nodeID, err := vm.Windower.ExpectedProposer(ctx, blkHeight, pChainHeight, uint64(slot))
+		if errors.Is(err, proposer.ErrAnyoneCanPropose) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		nodeIDs[slot] = nodeID
+	}
+	return nodeIDs, nil
+}
+
+// recentProposals returns the windows that up to [numProposals] of the most
+// recently accepted blocks were proposed in. Option blocks aren't proposed, so
+// they are skipped.
+func (vm *VM) recentProposals(ctx context.Context, numProposals int) ([]Proposal, error) {
+	blkID, err := vm.LastAccepted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	proposals := make([]Proposal, 0, numProposals)
+	for len(proposals) < numProposals {
+		blk, err := vm.getPostForkBlock(ctx, blkID)
+		if err != nil {
+			// Blocks before the fork don't have proposers.
+			break
+		}
+		blkID = blk.Parent()
+
+		signedBlk, ok := blk.(*postForkBlock)
+		if !ok {
+			continue
+		}
+
+		parent, err := vm.getBlock(ctx, blkID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get block %s: %w", blkID, err)
+		}
+		if _, ok := parent.(*preForkBlock); ok {
+			// The first block after the fork can be proposed by anyone.
+			proposals = append(proposals, Proposal{
+				BlockID:   signedBlk.ID(),
+				Height:    avajson.Uint64(signedBlk.Height()),
+				Timestamp: signedBlk.Timestamp(),
+				Proposer:  signedBlk.Proposer(),
+			})
+			break
+		}
+
+		pChainHeight, err := parent.pChainHeight(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get P-chain height of %s: %w", blkID, err)
+		}
+
+		var (
+			parentTimestamp = parent.Timestamp()
+			params          = vm.ProposerWindowConfig.ParamsAt(parentTimestamp)
+			window          = params.TimeToSlot(parentTimestamp, signedBlk.Timestamp())
+			numMissed       = int(min(window, uint64(params.MaxWindows)))
+		)
+		nodeIDs, err := vm.proposers(ctx, signedBlk.Height(), pChainHeight, parentTimestamp, numMissed)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get proposers of %s: %w", signedBlk.ID(), err)
+		}
+
+		proposals = append(proposals, Proposal{
+			BlockID:         signedBlk.ID(),
+			Height:          avajson.Uint64(signedBlk.Height()),
+			Timestamp:       signedBlk.Timestamp(),
+			Proposer:        signedBlk.Proposer(),
+			Window:          avajson.Uint64(window),
+			MissedProposers: missedProposers(nodeIDs, signedBlk.Proposer()),
+		})
+	}
+	return proposals, nil
+}
+
+// missedProposers returns [nodeIDs] without [proposerID]. Pre-Durango, the
+// proposer of a block may have been given an earlier window than the one it
+// proposed in.
+func missedProposers(nodeIDs []ids.NodeID, proposerID ids.NodeID) []ids.NodeID {
+	missed := make([]ids.NodeID, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if nodeID != proposerID {
+			missed = append(missed, nodeID)
+		}
+	}
+	return missed
+}
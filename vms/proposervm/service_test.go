@@ -0,0 +1,186 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+
+	avajson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+func TestServiceGetProposers(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		activationTime = time.Unix(0, 0)
+		durangoTime    = activationTime
+	)
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, activationTime, durangoTime, 0)
+	defer func() {
+		require.NoError(proVM.Shutdown(context.Background()))
+	}()
+
+	var (
+		ctx     = context.Background()
+		service = &Service{vm: proVM}
+	)
+
+	// Before the fork, the proposers are sampled at P-chain height 0.
+	reply := GetProposersReply{}
+	require.NoError(service.GetProposers(&http.Request{}, nil, &reply))
+	require.Equal(avajson.Uint64(1), reply.Height)
+	require.Equal(coreGenBlk.ID(), reply.ParentID)
+	require.Zero(reply.PChainHeight)
+	require.Len(reply.Proposers, proposer.MaxVerifyWindows)
+	for slot, window := range reply.Proposers {
+		expectedProposer, err := proVM.ExpectedProposer(ctx, 1, 0, uint64(slot))
+		require.NoError(err)
+		require.Equal(expectedProposer, window.NodeID)
+		require.Equal(coreGenBlk.Timestamp().Add(time.Duration(slot)*proposer.WindowDuration), window.StartTime)
+	}
+	require.Empty(reply.RecentProposals)
+
+	coreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp(),
+	}
+	coreBlk2 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{2},
+		ParentV:    coreBlk.ID(),
+		HeightV:    coreBlk.Height() + 1,
+		TimestampV: coreBlk.Timestamp(),
+	}
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk, nil
+	}
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case coreGenBlk.ID():
+			return coreGenBlk, nil
+		case coreBlk.ID():
+			return coreBlk, nil
+		case coreBlk2.ID():
+			return coreBlk2, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+	coreVM.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(b, coreGenBlk.Bytes()):
+			return coreGenBlk, nil
+		case bytes.Equal(b, coreBlk.Bytes()):
+			return coreBlk, nil
+		case bytes.Equal(b, coreBlk2.Bytes()):
+			return coreBlk2, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	require.NoError(waitForProposerWindow(proVM, coreGenBlk, 0))
+	proBlk, err := proVM.BuildBlock(ctx)
+	require.NoError(err)
+	require.NoError(proBlk.Verify(ctx))
+	require.NoError(proBlk.Accept(ctx))
+	require.NoError(proVM.SetPreference(ctx, proBlk.ID()))
+
+	require.IsType(&postForkBlock{}, proBlk)
+	pChainHeight := proBlk.(*postForkBlock).PChainHeight()
+
+	reply = GetProposersReply{}
+	require.NoError(service.GetProposers(&http.Request{}, nil, &reply))
+	require.Equal(avajson.Uint64(2), reply.Height)
+	require.Equal(proBlk.ID(), reply.ParentID)
+	require.Equal(avajson.Uint64(pChainHeight), reply.PChainHeight)
+	require.Len(reply.Proposers, proposer.MaxVerifyWindows)
+
+	isProposer := false
+	for slot, window := range reply.Proposers {
+		expectedProposer, err := proVM.ExpectedProposer(ctx, 2, pChainHeight, uint64(slot))
+		require.NoError(err)
+		require.Equal(expectedProposer, window.NodeID)
+		require.Equal(proBlk.Timestamp().Add(time.Duration(slot)*proposer.WindowDuration), window.StartTime)
+		isProposer = isProposer || expectedProposer == proVM.ctx.NodeID
+	}
+	require.Equal(isProposer, reply.IsProposer)
+
+	// The first block after the fork can be proposed by anyone
+	require.Equal([]Proposal{
+		{
+			BlockID:   proBlk.ID(),
+			Height:    1,
+			Timestamp: proBlk.Timestamp(),
+		},
+	}, reply.RecentProposals)
+
+	coreVM.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return coreBlk2, nil
+	}
+
+	require.NoError(waitForProposerWindow(proVM, proBlk, pChainHeight))
+	proBlk2, err := proVM.BuildBlock(ctx)
+	require.NoError(err)
+	require.NoError(proBlk2.Verify(ctx))
+	require.NoError(proBlk2.Accept(ctx))
+	require.NoError(proVM.SetPreference(ctx, proBlk2.ID()))
+
+	reply = GetProposersReply{}
+	require.NoError(service.GetProposers(&http.Request{}, nil, &reply))
+	require.Equal(avajson.Uint64(3), reply.Height)
+	require.Equal(proBlk2.ID(), reply.ParentID)
+
+	// The block was proposed by this node in its first window, so the
+	// proposers of the earlier windows missed their windows.
+	window := proposer.DefaultParams.TimeToSlot(proBlk.Timestamp(), proBlk2.Timestamp())
+	var expectedProposers []ids.NodeID
+	for slot := uint64(0); slot < min(window, uint64(proposer.DefaultParams.MaxWindows)); slot++ {
+		expectedProposer, err := proVM.ExpectedProposer(ctx, 2, pChainHeight, slot)
+		require.NoError(err)
+		expectedProposers = append(expectedProposers, expectedProposer)
+	}
+	require.Len(reply.RecentProposals, 2)
+	require.Equal(Proposal{
+		BlockID:         proBlk2.ID(),
+		Height:          2,
+		Timestamp:       proBlk2.Timestamp(),
+		Proposer:        proVM.ctx.NodeID,
+		Window:          avajson.Uint64(window),
+		MissedProposers: missedProposers(expectedProposers, proVM.ctx.NodeID),
+	}, reply.RecentProposals[0])
+	require.Equal(proBlk.ID(), reply.RecentProposals[1].BlockID)
+}
+
+func TestMissedProposers(t *testing.T) {
+	var (
+		nodeID0 = ids.GenerateTestNodeID()
+		nodeID1 = ids.GenerateTestNodeID()
+	)
+	require.Equal(
+		t,
+		[]ids.NodeID{nodeID0, nodeID0},
+		missedProposers([]ids.NodeID{nodeID0, nodeID1, nodeID0}, nodeID1),
+	)
+}
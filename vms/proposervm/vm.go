@@ -7,8 +7,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/gorilla/rpc/v2"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
@@ -25,6 +27,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/utils/units"
@@ -46,6 +49,10 @@ const (
 
 	checkIndexedFrequency = 10 * time.Second
 	innerBlkCacheSize     = 64 * units.MiB
+
+	// apiEndpoint is the extension of the chain's API that the proposervm
+	// service is served on
+	apiEndpoint = "/proposervm"
 )
 
 var (
@@ -58,6 +65,8 @@ var (
 	fujiXChainID    = ids.FromStringOrPanic("2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm")
 
 	dbPrefix = []byte("proposervm")
+
+	errDuplicateEndpoint = errors.New("duplicate API endpoint")
 )
 
 func cachedBlockSize(_ ids.ID, blk snowman.Block) int {
@@ -152,6 +161,15 @@ func (vm *VM) Initialize(
 	chainCtx.Metrics = optionalGatherer
 
 	vm.ctx = chainCtx
+	if cfg := vm.ProposerWindowConfig; cfg != nil && cfg.MaxWindows != 0 && !cfg.MaxWindowsAffectsBlocks(vm.DurangoTime) {
+		vm.ctx.Log.Warn("configured proposer maxWindows doesn't affect block validity",
+			zap.String("reason", "every window has a proposer post-Durango"),
+			zap.Int("maxWindows", cfg.MaxWindows),
+			zap.Time("activationTime", cfg.ActivationTime),
+			zap.Time("durangoTime", vm.DurangoTime),
+		)
+	}
+
 	vm.db = versiondb.New(prefixdb.New(dbPrefix, db))
 	baseState, err := state.NewMetered(vm.db, "state", registerer)
 	if err != nil {
@@ -232,6 +250,31 @@ func (vm *VM) Initialize(
 	return nil
 }
 
+// CreateHandlers returns the handlers of the inner VM along with the
+// proposervm service.
+func (vm *VM) CreateHandlers(ctx context.Context) (map[string]http.Handler, error) {
+	handlers, err := vm.ChainVM.CreateHandlers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := handlers[apiEndpoint]; ok {
+		return nil, fmt.Errorf("%w: %q", errDuplicateEndpoint, apiEndpoint)
+	}
+
+	server := rpc.NewServer()
+	server.RegisterCodec(json.NewCodec(), "application/json")
+	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
+	if err := server.RegisterService(&Service{vm: vm}, "proposervm"); err != nil {
+		return nil, err
+	}
+
+	if handlers == nil {
+		handlers = make(map[string]http.Handler, 1)
+	}
+	handlers[apiEndpoint] = server
+	return handlers, nil
+}
+
 // shutdown ops then propagate shutdown to innerVM
 func (vm *VM) Shutdown(ctx context.Context) error {
 	vm.onShutdown()
@@ -321,7 +364,7 @@ func (vm *VM) SetPreference(ctx context.Context, preferred ids.ID) error {
 			ctx,
 			childBlockHeight,
 			pChainHeight,
-			proposer.TimeToSlot(parentTimestamp, currentTime),
+			vm.ProposerWindowConfig.ParamsAt(parentTimestamp).TimeToSlot(parentTimestamp, currentTime),
 			parentTimestamp,
 		)
 	} else {
@@ -359,7 +402,8 @@ func (vm *VM) getPreDurangoSlotTime(
 	pChainHeight uint64,
 	parentTimestamp time.Time,
 ) (time.Time, error) {
-	delay, err := vm.Windower.Delay(ctx, blkHeight, pChainHeight, vm.ctx.NodeID, proposer.MaxBuildWindows)
+	params := vm.ProposerWindowConfig.ParamsAt(parentTimestamp)
+	delay, err := vm.Windower.DelayWithWindowDuration(ctx, blkHeight, pChainHeight, vm.ctx.NodeID, proposer.MaxBuildWindows, params.WindowDuration)
 	if err != nil {
 		return time.Time{}, err
 	}
@@ -381,12 +425,13 @@ func (vm *VM) getPostDurangoSlotTime(
 	slot uint64,
 	parentTimestamp time.Time,
 ) (time.Time, error) {
-	delay, err := vm.Windower.MinDelayForProposer(
+	delay, err := vm.Windower.MinDelayForProposerWithWindowDuration(
 		ctx,
 		blkHeight,
 		pChainHeight,
 		vm.ctx.NodeID,
 		slot,
+		vm.ProposerWindowConfig.ParamsAt(parentTimestamp).WindowDuration,
 	)
 	// Note: The P-chain does not currently try to target any block time. It
 	// notifies the consensus engine as soon as a new block may be built. To
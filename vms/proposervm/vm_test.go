@@ -218,16 +218,18 @@ func waitForProposerWindow(vm *VM, chainTip snowman.Block, pchainHeight uint64)
 		ctx              = context.Background()
 		childBlockHeight = chainTip.Height() + 1
 		parentTimestamp  = chainTip.Timestamp()
+		params           = vm.ProposerWindowConfig.ParamsAt(parentTimestamp)
 	)
 
 	for {
-		slot := proposer.TimeToSlot(parentTimestamp, vm.Clock.Time().Truncate(time.Second))
-		delay, err := vm.MinDelayForProposer(
+		slot := params.TimeToSlot(parentTimestamp, vm.Clock.Time().Truncate(time.Second))
+		delay, err := vm.MinDelayForProposerWithWindowDuration(
 			ctx,
 			childBlockHeight,
 			pchainHeight,
 			vm.ctx.NodeID,
 			slot,
+			params.WindowDuration,
 		)
 		if err != nil {
 			return err
@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"cmp"
+	"math/bits"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+// TxFee is the effective fee a tx pays, along with the size of the tx.
+type TxFee struct {
+	Fee  uint64
+	Size uint64
+	// nonce orders txs that pay the same fee per byte by insertion.
+	nonce uint64
+}
+
+// Compare compares the fee per byte paid by [f] and [o].
+func (f TxFee) Compare(o TxFee) int {
+	fHi, fLo := bits.Mul64(f.Fee, o.Size)
+	oHi, oLo := bits.Mul64(o.Fee, f.Size)
+	if c := cmp.Compare(fHi, oHi); c != 0 {
+		return c
+	}
+	return cmp.Compare(fLo, oLo)
+}
+
+// higherFee returns true if [a] pays more per byte than [b]. Txs paying the
+// same fee per byte are ordered from oldest to newest.
+func higherFee(a, b TxFee) bool {
+	if c := a.Compare(b); c != 0 {
+		return c > 0
+	}
+	return a.nonce < b.nonce
+}
+
+// lowerFee returns true if [a] pays less per byte than [b]. Txs paying the
+// same fee per byte are ordered from newest to oldest.
+func lowerFee(a, b TxFee) bool {
+	if c := a.Compare(b); c != 0 {
+		return c < 0
+	}
+	return a.nonce > b.nonce
+}
+
+// FeeCalculator calculates the amount of [AssetID] that a tx burns. It is
+// embedded by the tx visitors of each VM.
+type FeeCalculator struct {
+	AssetID ids.ID
+	// Parents are the IDs of the txs whose outputs are consumed.
+	Parents  set.Set[ids.ID]
+	consumed uint64
+	produced uint64
+}
+
+// Fee returns the amount of [AssetID] burned by the consumed inputs and the
+// produced outputs.
+func (c *FeeCalculator) Fee() (uint64, error) {
+	return safemath.Sub(c.consumed, c.produced)
+}
+
+func (c *FeeCalculator) Consume(ins []*avax.TransferableInput) error {
+	for _, in := range ins {
+		c.Parents.Add(in.TxID)
+		if in.AssetID() != c.AssetID {
+			continue
+		}
+		consumed, err := safemath.Add64(c.consumed, in.In.Amount())
+		if err != nil {
+			return err
+		}
+		c.consumed = consumed
+	}
+	return nil
+}
+
+func (c *FeeCalculator) Produce(outs []*avax.TransferableOutput) error {
+	for _, out := range outs {
+		if out.AssetID() != c.AssetID {
+			continue
+		}
+		produced, err := safemath.Add64(c.produced, out.Out.Amount())
+		if err != nil {
+			return err
+		}
+		c.produced = produced
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/heap"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// FeeQueue orders txs by the fee they pay per byte.
+//
+// A tx that consumes the outputs of another tx in the queue can't be issued
+// before that tx, so it isn't returned by Peek until its parents have been
+// removed from the queue.
+type FeeQueue struct {
+	nextNonce uint64
+	// issuable contains the txs that don't consume the outputs of other txs in
+	// the queue, ordered from the highest to the lowest fee per byte.
+	issuable heap.Map[ids.ID, TxFee]
+	// all contains every tx in the queue, ordered from the lowest to the
+	// highest fee per byte.
+	all heap.Map[ids.ID, TxFee]
+	// parents maps a tx in the queue to the IDs of the txs whose outputs it
+	// consumes.
+	parents map[ids.ID]set.Set[ids.ID]
+	// children maps a txID to the txs in the queue that consume its outputs.
+	children map[ids.ID]set.Set[ids.ID]
+}
+
+func NewFeeQueue() *FeeQueue {
+	return &FeeQueue{
+		issuable: heap.NewMap[ids.ID, TxFee](higherFee),
+		all:      heap.NewMap[ids.ID, TxFee](lowerFee),
+		parents:  make(map[ids.ID]set.Set[ids.ID]),
+		children: make(map[ids.ID]set.Set[ids.ID]),
+	}
+}
+
+// Push adds [txID], which pays [fee] and consumes the outputs of [parents], to
+// the queue.
+func (q *FeeQueue) Push(txID ids.ID, fee TxFee, parents set.Set[ids.ID]) {
+	if q.all.Contains(txID) {
+		return
+	}
+
+	fee.nonce = q.nextNonce
+	q.nextNonce++
+	q.all.Push(txID, fee)
+
+	q.parents[txID] = parents
+	for parentID := range parents {
+		children := q.children[parentID]
+		children.Add(txID)
+		q.children[parentID] = children
+	}
+	if !q.hasQueuedParent(txID) {
+		q.issuable.Push(txID, fee)
+	}
+
+	// Txs that consume the outputs of [txID] may have been added before it.
+	for childID := range q.children[txID] {
+		q.issuable.Remove(childID)
+	}
+}
+
+// Remove removes [txID] from the queue. Any txs that were only waiting on
+// [txID] become issuable.
+func (q *FeeQueue) Remove(txID ids.ID) {
+	if _, ok := q.all.Remove(txID); !ok {
+		return
+	}
+	q.issuable.Remove(txID)
+
+	for parentID := range q.parents[txID] {
+		children := q.children[parentID]
+		children.Remove(txID)
+		if children.Len() == 0 {
+			delete(q.children, parentID)
+		}
+	}
+	delete(q.parents, txID)
+
+	for childID := range q.children[txID] {
+		if q.hasQueuedParent(childID) {
+			continue
+		}
+		fee, _ := q.all.Get(childID)
+		q.issuable.Push(childID, fee)
+	}
+}
+
+// Peek returns the issuable tx paying the highest fee per byte.
+func (q *FeeQueue) Peek() (ids.ID, bool) {
+	txID, _, ok := q.issuable.Peek()
+	return txID, ok
+}
+
+// Evictable returns the txs paying the lowest fee per byte that must be
+// evicted to free [requiredBytes] for a tx paying [fee] that consumes the
+// outputs of [parents]. Only txs paying strictly less per byte than [fee] are
+// selected. A selected tx is returned along with all the txs that depend on it,
+// as they can no longer be issued once it is evicted. Txs that the new tx
+// depends on are never returned. If not enough space can be freed, false is
+// returned.
+func (q *FeeQueue) Evictable(fee TxFee, parents set.Set[ids.ID], requiredBytes uint64) ([]ids.ID, bool) {
+	var (
+		ancestors = q.ancestors(parents)
+		popped    = make(map[ids.ID]TxFee)
+		evicted   set.Set[ids.ID]
+		txIDs     []ids.ID
+		bytes     uint64
+	)
+	// The popped txs are restored before returning.
+	defer func() {
+		for txID, fee := range popped {
+			q.all.Push(txID, fee)
+		}
+	}()
+
+	for bytes < requiredBytes {
+		txID, lowestFee, ok := q.all.Pop()
+		if !ok {
+			return nil, false
+		}
+		popped[txID] = lowestFee
+		if evicted.Contains(txID) || ancestors.Contains(txID) {
+			continue
+		}
+		if lowestFee.Compare(fee) >= 0 {
+			return nil, false
+		}
+
+		// Evict [txID] along with its descendants, which may have already been
+		// popped.
+		toEvict := []ids.ID{txID}
+		for len(toEvict) > 0 {
+			txID := toEvict[0]
+			toEvict = toEvict[1:]
+			if evicted.Contains(txID) {
+				continue
+			}
+			txFee, ok := popped[txID]
+			if !ok {
+				txFee, _ = q.all.Get(txID)
+			}
+			evicted.Add(txID)
+			txIDs = append(txIDs, txID)
+			bytes += txFee.Size
+			for childID := range q.children[txID] {
+				toEvict = append(toEvict, childID)
+			}
+		}
+	}
+	return txIDs, true
+}
+
+// Len returns the number of txs in the queue.
+func (q *FeeQueue) Len() int {
+	return q.all.Len()
+}
+
+// ancestors returns the txs in the queue that must be issued before a tx that
+// consumes the outputs of [parents].
+func (q *FeeQueue) ancestors(parents set.Set[ids.ID]) set.Set[ids.ID] {
+	var (
+		ancestors set.Set[ids.ID]
+		toVisit   = parents.List()
+	)
+	for len(toVisit) > 0 {
+		txID := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+		if ancestors.Contains(txID) || !q.all.Contains(txID) {
+			continue
+		}
+		ancestors.Add(txID)
+		toVisit = append(toVisit, q.parents[txID].List()...)
+	}
+	return ancestors
+}
+
+func (q *FeeQueue) hasQueuedParent(txID ids.ID) bool {
+	for parentID := range q.parents[txID] {
+		if q.all.Contains(parentID) {
+			return true
+		}
+	}
+	return false
+}
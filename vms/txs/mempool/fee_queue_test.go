@@ -0,0 +1,174 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestFeeQueuePeek(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		q           = NewFeeQueue()
+		lowFeeTx    = ids.GenerateTestID()
+		highFeeTx   = ids.GenerateTestID()
+		mediumFeeTx = ids.GenerateTestID()
+		sameFeeTx   = ids.GenerateTestID()
+	)
+	q.Push(lowFeeTx, TxFee{Fee: 1000, Size: 1000}, nil)
+	q.Push(highFeeTx, TxFee{Fee: 3000, Size: 1000}, nil)
+	q.Push(mediumFeeTx, TxFee{Fee: 4000, Size: 2000}, nil)
+	q.Push(sameFeeTx, TxFee{Fee: 2000, Size: 2000}, nil)
+	require.Equal(4, q.Len())
+
+	// Txs paying the same fee per byte are ordered by insertion.
+	for _, expectedTxID := range []ids.ID{highFeeTx, mediumFeeTx, lowFeeTx, sameFeeTx} {
+		txID, ok := q.Peek()
+		require.True(ok)
+		require.Equal(expectedTxID, txID)
+		q.Remove(txID)
+	}
+
+	_, ok := q.Peek()
+	require.False(ok)
+	require.Zero(q.Len())
+}
+
+func TestFeeQueueDependentTxs(t *testing.T) {
+	tests := []struct {
+		name        string
+		parentFirst bool
+	}{
+		{
+			name:        "parent added first",
+			parentFirst: true,
+		},
+		{
+			name:        "child added first",
+			parentFirst: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				q           = NewFeeQueue()
+				lowFeeTx    = ids.GenerateTestID()
+				parentTx    = ids.GenerateTestID()
+				childTx     = ids.GenerateTestID()
+				parentFee   = TxFee{Fee: 1, Size: 1000}
+				childFee    = TxFee{Fee: 3000, Size: 1000}
+				lowFee      = TxFee{Fee: 2000, Size: 1000}
+				childParent = set.Of(parentTx, ids.GenerateTestID())
+			)
+			q.Push(lowFeeTx, lowFee, nil)
+			if test.parentFirst {
+				q.Push(parentTx, parentFee, nil)
+				q.Push(childTx, childFee, childParent)
+			} else {
+				q.Push(childTx, childFee, childParent)
+				q.Push(parentTx, parentFee, nil)
+			}
+
+			// The child pays the highest fee, but can't be issued before its
+			// parent.
+			for _, expectedTxID := range []ids.ID{lowFeeTx, parentTx, childTx} {
+				txID, ok := q.Peek()
+				require.True(ok)
+				require.Equal(expectedTxID, txID)
+				q.Remove(txID)
+			}
+			require.Zero(q.Len())
+		})
+	}
+}
+
+func TestFeeQueueEvictable(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		q          = NewFeeQueue()
+		lowFeeTxA  = ids.GenerateTestID()
+		lowFeeTxB  = ids.GenerateTestID()
+		highFeeTx  = ids.GenerateTestID()
+		lowFee     = TxFee{Fee: 1, Size: 10}
+		highFee    = TxFee{Fee: 3, Size: 10}
+		newFee     = TxFee{Fee: 2, Size: 10}
+		sameFeeTxs = []ids.ID{lowFeeTxA, lowFeeTxB}
+	)
+	for _, txID := range sameFeeTxs {
+		q.Push(txID, lowFee, nil)
+	}
+	q.Push(highFeeTx, highFee, nil)
+
+	// The most recently added of the txs paying the lowest fee is evicted
+	// first.
+	txIDs, ok := q.Evictable(newFee, nil, 10)
+	require.True(ok)
+	require.Equal([]ids.ID{lowFeeTxB}, txIDs)
+
+	txIDs, ok = q.Evictable(newFee, nil, 20)
+	require.True(ok)
+	require.Equal([]ids.ID{lowFeeTxB, lowFeeTxA}, txIDs)
+
+	// Txs paying at least [newFee] per byte can't be evicted.
+	_, ok = q.Evictable(newFee, nil, 30)
+	require.False(ok)
+
+	// Evictable doesn't modify the queue.
+	require.Equal(3, q.Len())
+	txID, ok := q.Peek()
+	require.True(ok)
+	require.Equal(highFeeTx, txID)
+}
+
+func TestFeeQueueEvictableDependentTxs(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		q            = NewFeeQueue()
+		parentTx     = ids.GenerateTestID()
+		childTx      = ids.GenerateTestID()
+		grandchildTx = ids.GenerateTestID()
+		otherTx      = ids.GenerateTestID()
+		lowFee       = TxFee{Fee: 1, Size: 10}
+		mediumFee    = TxFee{Fee: 2, Size: 10}
+		highFee      = TxFee{Fee: 4, Size: 10}
+		newFee       = TxFee{Fee: 3, Size: 10}
+		childParent  = set.Of(parentTx)
+	)
+	q.Push(parentTx, lowFee, nil)
+	q.Push(childTx, highFee, childParent)
+	q.Push(grandchildTx, highFee, set.Of(childTx))
+	q.Push(otherTx, mediumFee, nil)
+
+	// Evicting the parent tx requires evicting the txs that depend on it.
+	txIDs, ok := q.Evictable(newFee, nil, 10)
+	require.True(ok)
+	require.Equal([]ids.ID{parentTx, childTx, grandchildTx}, txIDs)
+
+	// The txs that the new tx depends on can't be evicted.
+	txIDs, ok = q.Evictable(newFee, set.Of(grandchildTx), 10)
+	require.True(ok)
+	require.Equal([]ids.ID{otherTx}, txIDs)
+
+	_, ok = q.Evictable(newFee, set.Of(grandchildTx), 20)
+	require.False(ok)
+
+	// Evictable doesn't modify the queue.
+	require.Equal(4, q.Len())
+	for _, expectedTxID := range []ids.ID{otherTx, parentTx, childTx, grandchildTx} {
+		txID, ok := q.Peek()
+		require.True(ok)
+		require.Equal(expectedTxID, txID)
+		q.Remove(txID)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempool
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+func TestTxFeeCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        TxFee
+		b        TxFee
+		expected int
+	}{
+		{
+			name:     "equal fee per byte",
+			a:        TxFee{Fee: 1, Size: 2},
+			b:        TxFee{Fee: 2, Size: 4},
+			expected: 0,
+		},
+		{
+			name:     "higher fee per byte",
+			a:        TxFee{Fee: 3, Size: 2},
+			b:        TxFee{Fee: 2, Size: 2},
+			expected: 1,
+		},
+		{
+			name:     "lower fee per byte",
+			a:        TxFee{Fee: 1, Size: 2},
+			b:        TxFee{Fee: 1, Size: 1},
+			expected: -1,
+		},
+		{
+			name:     "no overflow",
+			a:        TxFee{Fee: math.MaxUint64, Size: math.MaxUint64},
+			b:        TxFee{Fee: math.MaxUint64 - 1, Size: math.MaxUint64},
+			expected: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			require.Equal(test.expected, test.a.Compare(test.b))
+			require.Equal(-test.expected, test.b.Compare(test.a))
+		})
+	}
+}
+
+func TestFeeCalculator(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		assetID      = ids.GenerateTestID()
+		otherAssetID = ids.GenerateTestID()
+		parentID     = ids.GenerateTestID()
+		otherID      = ids.GenerateTestID()
+	)
+	calculator := &FeeCalculator{
+		AssetID: assetID,
+	}
+	require.NoError(calculator.Consume([]*avax.TransferableInput{
+		{
+			UTXOID: avax.UTXOID{TxID: parentID},
+			Asset:  avax.Asset{ID: assetID},
+			In:     &secp256k1fx.TransferInput{Amt: 10},
+		},
+		{
+			UTXOID: avax.UTXOID{TxID: otherID},
+			Asset:  avax.Asset{ID: otherAssetID},
+			In:     &secp256k1fx.TransferInput{Amt: 100},
+		},
+	}))
+	require.NoError(calculator.Produce([]*avax.TransferableOutput{
+		{
+			Asset: avax.Asset{ID: assetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: 7},
+		},
+		{
+			Asset: avax.Asset{ID: otherAssetID},
+			Out:   &secp256k1fx.TransferOutput{Amt: 100},
+		},
+	}))
+
+	fee, err := calculator.Fee()
+	require.NoError(err)
+	require.Equal(uint64(3), fee)
+	require.Equal(set.Of(parentID, otherID), calculator.Parents)
+
+	// Producing more than was consumed isn't a valid fee.
+	require.NoError(calculator.Produce([]*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: assetID},
+		Out:   &secp256k1fx.TransferOutput{Amt: 4},
+	}}))
+	_, err = calculator.Fee()
+	require.ErrorIs(err, safemath.ErrUnderflow)
+}
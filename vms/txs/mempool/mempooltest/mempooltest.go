@@ -0,0 +1,27 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mempooltest
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// NewBaseTx returns a tx that burns [fee] of [assetID] by consuming output
+// [outputIndex] of [parentID].
+func NewBaseTx(assetID ids.ID, parentID ids.ID, outputIndex uint32, fee uint64) avax.BaseTx {
+	return avax.BaseTx{
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{
+				TxID:        parentID,
+				OutputIndex: outputIndex,
+			},
+			Asset: avax.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: fee,
+			},
+		}},
+	}
+}
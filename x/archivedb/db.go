@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/database"
@@ -57,14 +59,35 @@ var (
 // foo was deleted at height 1000. When calling `reader.GetHeight(foo)` at
 // height 99 it will return a tuple `("foo's value is bar", 10)` returning the
 // value of `foo` at height 99 (which was set at height 10).
+//
+// Old versions can be removed by running Prune with a retention window. Once
+// pruned, heights below the window can no longer be opened.
 type Database struct {
 	db database.Database
+
+	// minHeight is the lowest height that can be read. It is only increased by
+	// pruning, after it has been persisted.
+	minHeight atomic.Uint64
+
+	pruneLock   sync.RWMutex
+	pruning     bool
+	pruneStatus *PruneStatus
+	pruneErr    error
 }
 
-func New(db database.Database) *Database {
-	return &Database{
+func New(db database.Database) (*Database, error) {
+	archiveDB := &Database{
 		db: db,
 	}
+
+	minHeight, err := database.GetUInt64(db, minHeightKey)
+	switch {
+	case err == nil:
+		archiveDB.minHeight.Store(minHeight)
+	case err != database.ErrNotFound:
+		return nil, err
+	}
+	return archiveDB, nil
 }
 
 // Height returns the last written height.
@@ -96,8 +119,26 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.db.Compact(start, limit)
 }
 
+// HealthCheck reports the health of the underlying database. If pruning was
+// started, the progress of pruning is reported as well.
 func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
-	return db.db.HealthCheck(ctx)
+	dbHealth, err := db.db.HealthCheck(ctx)
+
+	db.pruneLock.RLock()
+	defer db.pruneLock.RUnlock()
+
+	if db.pruneStatus == nil {
+		return dbHealth, err
+	}
+
+	details := map[string]interface{}{
+		"database": dbHealth,
+		"pruning":  *db.pruneStatus,
+	}
+	if err != nil {
+		return details, err
+	}
+	return details, db.pruneErr
 }
 
 func (db *Database) Close() error {
@@ -15,7 +15,8 @@ import (
 func TestDBEntries(t *testing.T) {
 	require := require.New(t)
 
-	db := New(memdb.New())
+	db, err := New(memdb.New())
+	require.NoError(err)
 
 	batch := db.NewBatch(1)
 	require.NoError(batch.Write())
@@ -104,7 +105,8 @@ func TestDBEntries(t *testing.T) {
 func TestDelete(t *testing.T) {
 	require := require.New(t)
 
-	db := New(memdb.New())
+	db, err := New(memdb.New())
+	require.NoError(err)
 
 	batch := db.NewBatch(1)
 	require.NoError(batch.Put([]byte("key1"), []byte("value1@10")))
@@ -173,7 +175,8 @@ func TestDBKeySpace(t *testing.T) {
 	require.NotEqual(key1, key3)
 	require.NotEqual(key2, key3)
 
-	db := New(memdb.New())
+	db, err := New(memdb.New())
+	require.NoError(err)
 
 	batch := db.NewBatch(1)
 	require.NoError(batch.Put(key1, value1))
@@ -206,9 +209,10 @@ func TestDBKeySpace(t *testing.T) {
 func TestSkipHeight(t *testing.T) {
 	require := require.New(t)
 
-	db := New(memdb.New())
+	db, err := New(memdb.New())
+	require.NoError(err)
 
-	_, err := db.Height()
+	_, err = db.Height()
 	require.ErrorIs(err, database.ErrNotFound)
 
 	batch := db.NewBatch(0)
@@ -0,0 +1,269 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/heap"
+)
+
+var _ database.Iterator = (*iterator)(nil)
+
+// iterator iterates over the user keys of the database as of a given height.
+//
+// Database keys are prefixed by the length of the user key, so the user keys
+// are not stored in lexicographical order. To return the keys in
+// lexicographical order, the iterator opens one iterator per user key length
+// and merges them.
+type iterator struct {
+	db     database.Database
+	height uint64
+	start  []byte
+	prefix []byte
+
+	initialized bool
+	current     *lengthIterator
+	iterators   heap.Queue[*lengthIterator]
+	err         error
+}
+
+func newIterator(db database.Database, height uint64, start, prefix []byte) *iterator {
+	return &iterator{
+		db:        db,
+		height:    height,
+		start:     slices.Clone(start),
+		prefix:    slices.Clone(prefix),
+		iterators: heap.NewQueue(lessLengthIterator),
+	}
+}
+
+func (it *iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if !it.initialized {
+		it.initialized = true
+		if err := it.init(); err != nil {
+			it.setError(err)
+			return false
+		}
+	} else if it.current != nil {
+		if it.current.next() {
+			it.iterators.Push(it.current)
+		} else {
+			err := it.current.error()
+			it.current.it.Release()
+			if err != nil {
+				it.setError(err)
+				return false
+			}
+		}
+	}
+
+	current, ok := it.iterators.Pop()
+	if !ok {
+		it.current = nil
+		return false
+	}
+	it.current = current
+	return true
+}
+
+func (it *iterator) Error() error {
+	return it.err
+}
+
+func (it *iterator) Key() []byte {
+	if it.current == nil {
+		return nil
+	}
+	return it.current.key
+}
+
+func (it *iterator) Value() []byte {
+	if it.current == nil {
+		return nil
+	}
+	return it.current.value
+}
+
+func (it *iterator) Release() {
+	if it.current != nil {
+		it.current.it.Release()
+		it.current = nil
+	}
+	for {
+		lengthIt, ok := it.iterators.Pop()
+		if !ok {
+			return
+		}
+		lengthIt.it.Release()
+	}
+}
+
+func (it *iterator) setError(err error) {
+	it.err = err
+	it.Release()
+}
+
+// init opens an iterator for every user key length that can contain keys with
+// the requested prefix and advances them to their first entry.
+func (it *iterator) init() error {
+	lengths, err := it.keyLengths()
+	if err != nil {
+		return err
+	}
+
+	for _, keyLen := range lengths {
+		if keyLen < uint64(len(it.prefix)) {
+			continue
+		}
+
+		lengthIt := newLengthIterator(it.db, it.height, keyLen, it.start, it.prefix)
+		if lengthIt.next() {
+			it.iterators.Push(lengthIt)
+			continue
+		}
+
+		err := lengthIt.error()
+		lengthIt.it.Release()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyLengths returns all the distinct key length prefixes that are present in
+// the database.
+//
+// Every distinct length requires a new seek into the database, but the
+// versions of the keys are never scanned.
+func (it *iterator) keyLengths() ([]uint64, error) {
+	var (
+		lengths []uint64
+		start   []byte
+	)
+	for {
+		dbIt := it.db.NewIteratorWithStart(start)
+		next := dbIt.Next()
+		dbKey := slices.Clone(dbIt.Key())
+		err := dbIt.Error()
+		dbIt.Release()
+		if err != nil {
+			return nil, err
+		}
+		if !next {
+			return lengths, nil
+		}
+
+		keyLen, offset := binary.Uvarint(dbKey)
+		if offset <= 0 {
+			return nil, ErrParsingKeyLength
+		}
+		lengths = append(lengths, keyLen)
+
+		// The last byte of a uvarint is always less than 0x80, so incrementing
+		// it produces the smallest key that is larger than every key with this
+		// length prefix.
+		start = dbKey[:offset]
+		start[offset-1]++
+	}
+}
+
+func lessLengthIterator(a, b *lengthIterator) bool {
+	return bytes.Compare(a.key, b.key) < 0
+}
+
+// lengthIterator iterates over the user keys of a single length as of a given
+// height.
+type lengthIterator struct {
+	it     database.Iterator
+	height uint64
+	start  []byte
+
+	// lastKey is the last user key whose entry at [height] was resolved. All
+	// remaining versions of this key must be skipped. It is nil until the
+	// first key is resolved, as the empty key is a valid user key.
+	lastKey []byte
+	key     []byte
+	value   []byte
+}
+
+func newLengthIterator(
+	db database.Database,
+	height uint64,
+	keyLen uint64,
+	start []byte,
+	prefix []byte,
+) *lengthIterator {
+	lengthPrefix := binary.AppendUvarint(nil, keyLen)
+	dbPrefix := append(slices.Clone(lengthPrefix), prefix...)
+
+	// Keys of this length that are smaller than [start] are filtered out in
+	// next, but as many of them as possible are skipped by seeking.
+	userStart := start
+	if uint64(len(userStart)) > keyLen {
+		userStart = userStart[:keyLen]
+	}
+	dbStart := append(lengthPrefix, userStart...)
+	return &lengthIterator{
+		it:     db.NewIteratorWithStartAndPrefix(dbStart, dbPrefix),
+		height: height,
+		start:  start,
+	}
+}
+
+func (it *lengthIterator) next() bool {
+	for it.it.Next() {
+		dbKey := it.it.Key()
+		if isDBKeyFromMetadata(dbKey) {
+			continue
+		}
+
+		key, height, err := parseDBKeyFromUser(dbKey)
+		if err != nil {
+			// parseDBKeyFromUser should only error if the database is
+			// corrupted, so the iteration is aborted.
+			it.it.Release()
+			it.it = &database.IteratorError{
+				Err: err,
+			}
+			return false
+		}
+
+		// Versions are sorted by decreasing height, so the first version at or
+		// below [it.height] is the latest version of this key.
+		if height > it.height || (it.lastKey != nil && bytes.Equal(key, it.lastKey)) {
+			continue
+		}
+		it.lastKey = slices.Clone(key)
+
+		if bytes.Compare(key, it.start) < 0 {
+			continue
+		}
+
+		value, exists := parseDBValue(it.it.Value())
+		if !exists {
+			continue
+		}
+
+		it.key = it.lastKey
+		it.value = slices.Clone(value)
+		return true
+	}
+
+	it.key = nil
+	it.value = nil
+	return false
+}
+
+func (it *lengthIterator) error() error {
+	return it.it.Error()
+}
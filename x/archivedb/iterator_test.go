@@ -0,0 +1,262 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+type keyValue struct {
+	key   []byte
+	value []byte
+}
+
+func collect(t *testing.T, it database.Iterator) []keyValue {
+	require := require.New(t)
+
+	defer it.Release()
+
+	var kvs []keyValue
+	for it.Next() {
+		kvs = append(kvs, keyValue{
+			key:   it.Key(),
+			value: it.Value(),
+		})
+	}
+	require.NoError(it.Error())
+	require.Nil(it.Key())
+	require.Nil(it.Value())
+	return kvs
+}
+
+func TestIteratorHeights(t *testing.T) {
+	require := require.New(t)
+
+	db, err := New(memdb.New())
+	require.NoError(err)
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("b"), []byte("b@1")))
+	require.NoError(batch.Put([]byte("aa"), []byte("aa@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Put([]byte("b"), []byte("b@2")))
+	require.NoError(batch.Put([]byte("c"), []byte("c@2")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Delete([]byte("aa")))
+	require.NoError(batch.Write())
+
+	tests := []struct {
+		height   uint64
+		expected []keyValue
+	}{
+		{
+			height:   0,
+			expected: nil,
+		},
+		{
+			height: 1,
+			expected: []keyValue{
+				{key: []byte("aa"), value: []byte("aa@1")},
+				{key: []byte("b"), value: []byte("b@1")},
+			},
+		},
+		{
+			height: 2,
+			expected: []keyValue{
+				{key: []byte("aa"), value: []byte("aa@1")},
+				{key: []byte("b"), value: []byte("b@2")},
+				{key: []byte("c"), value: []byte("c@2")},
+			},
+		},
+		{
+			height: 3,
+			expected: []keyValue{
+				{key: []byte("b"), value: []byte("b@2")},
+				{key: []byte("c"), value: []byte("c@2")},
+			},
+		},
+		{
+			height: 100,
+			expected: []keyValue{
+				{key: []byte("b"), value: []byte("b@2")},
+				{key: []byte("c"), value: []byte("c@2")},
+			},
+		},
+	}
+	for _, test := range tests {
+		kvs := collect(t, db.Open(test.height).NewIterator())
+		require.Equal(test.expected, kvs, "height %d", test.height)
+	}
+}
+
+func TestIteratorStartAndPrefix(t *testing.T) {
+	require := require.New(t)
+
+	db, err := New(memdb.New())
+	require.NoError(err)
+
+	var (
+		longKey  = append([]byte("ab"), bytes.Repeat([]byte{0xff}, 200)...)
+		shortKey = []byte("ab")
+	)
+
+	batch := db.NewBatch(1)
+	for _, key := range [][]byte{
+		[]byte("a"),
+		shortKey,
+		[]byte("abc"),
+		[]byte("abd"),
+		longKey,
+		[]byte("b"),
+		[]byte("bab"),
+	} {
+		require.NoError(batch.Put(key, key))
+	}
+	require.NoError(batch.Write())
+
+	tests := []struct {
+		name     string
+		start    []byte
+		prefix   []byte
+		expected [][]byte
+	}{
+		{
+			name: "all",
+			expected: [][]byte{
+				[]byte("a"),
+				shortKey,
+				[]byte("abc"),
+				[]byte("abd"),
+				longKey,
+				[]byte("b"),
+				[]byte("bab"),
+			},
+		},
+		{
+			name:  "start",
+			start: []byte("abd"),
+			expected: [][]byte{
+				[]byte("abd"),
+				longKey,
+				[]byte("b"),
+				[]byte("bab"),
+			},
+		},
+		{
+			name:  "start between keys",
+			start: []byte("abca"),
+			expected: [][]byte{
+				[]byte("abd"),
+				longKey,
+				[]byte("b"),
+				[]byte("bab"),
+			},
+		},
+		{
+			name:   "prefix",
+			prefix: []byte("ab"),
+			expected: [][]byte{
+				shortKey,
+				[]byte("abc"),
+				[]byte("abd"),
+				longKey,
+			},
+		},
+		{
+			name:   "start and prefix",
+			start:  []byte("abd"),
+			prefix: []byte("ab"),
+			expected: [][]byte{
+				[]byte("abd"),
+				longKey,
+			},
+		},
+		{
+			name:     "start after prefix",
+			start:    []byte("b"),
+			prefix:   []byte("ab"),
+			expected: nil,
+		},
+		{
+			name:     "unknown prefix",
+			prefix:   []byte("c"),
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(*testing.T) {
+			kvs := collect(t, db.Open(1).NewIteratorWithStartAndPrefix(test.start, test.prefix))
+
+			keys := make([][]byte, len(kvs))
+			for i, kv := range kvs {
+				keys[i] = kv.key
+				require.Equal(kv.key, kv.value)
+			}
+			if len(keys) == 0 {
+				keys = nil
+			}
+			require.Equal(test.expected, keys)
+		})
+	}
+}
+
+func TestIteratorSkipsMetadata(t *testing.T) {
+	require := require.New(t)
+
+	db, err := New(memdb.New())
+	require.NoError(err)
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte{}, []byte("empty")))
+	require.NoError(batch.Put([]byte{0x00}, []byte("zero")))
+	require.NoError(batch.Write())
+
+	kvs := collect(t, db.Open(1).NewIterator())
+	require.Equal(
+		[]keyValue{
+			{key: []byte{}, value: []byte("empty")},
+			{key: []byte{0x00}, value: []byte("zero")},
+		},
+		kvs,
+	)
+}
+
+func TestIteratorRelease(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := New(baseDB)
+	require.NoError(err)
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("a"), []byte("a")))
+	require.NoError(batch.Put([]byte("bb"), []byte("bb")))
+	require.NoError(batch.Write())
+
+	it := db.Open(1).NewIterator()
+	require.True(it.Next())
+	require.Equal([]byte("a"), it.Key())
+
+	it.Release()
+	require.False(it.Next())
+	require.NoError(it.Error())
+
+	require.NoError(baseDB.Close())
+
+	it = db.Open(1).NewIterator()
+	require.False(it.Next())
+	err = it.Error()
+	require.ErrorIs(err, database.ErrClosed)
+	it.Release()
+}
@@ -14,7 +14,8 @@ var (
 	ErrParsingKeyLength   = errors.New("failed reading key length")
 	ErrIncorrectKeyLength = errors.New("incorrect key length")
 
-	heightKey = newDBKeyFromMetadata([]byte{})
+	heightKey    = newDBKeyFromMetadata([]byte{})
+	minHeightKey = newDBKeyFromMetadata([]byte("minHeight"))
 )
 
 // The requirements of a database key are:
@@ -93,3 +94,13 @@ func newDBKeyFromMetadata(key []byte) []byte {
 	offset += copy(dbKey[offset:], key)
 	return dbKey[:offset]
 }
+
+// isDBKeyFromMetadata returns true if the database formatted key was created
+// by newDBKeyFromMetadata.
+//
+// Metadata keys store their length + 1 without a height suffix, so they are
+// always shorter than a user key with the same length prefix.
+func isDBKeyFromMetadata(dbKey []byte) bool {
+	keyLen, offset := binary.Uvarint(dbKey)
+	return offset > 0 && keyLen > 0 && uint64(len(dbKey)) == uint64(offset)+keyLen-1
+}
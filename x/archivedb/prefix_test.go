@@ -55,7 +55,8 @@ func TestDBEfficientLookups(t *testing.T) {
 		maliciousKey, _ = newDBKeyFromUser(key, 2)
 	)
 
-	db := New(&limitIterationDB{Database: memdb.New()})
+	db, err := New(&limitIterationDB{Database: memdb.New()})
+	require.NoError(err)
 
 	batch := db.NewBatch(1)
 	require.NoError(batch.Put(key, []byte("value")))
@@ -87,7 +88,8 @@ func TestDBMoreEfficientLookups(t *testing.T) {
 		maliciousKey = []byte("key\xff\xff\xff\xff\xff\xff\xff\xfd")
 	)
 
-	db := New(&limitIterationDB{Database: memdb.New()})
+	db, err := New(&limitIterationDB{Database: memdb.New()})
+	require.NoError(err)
 
 	batch := db.NewBatch(1)
 	require.NoError(batch.Put(key, []byte("value")))
@@ -0,0 +1,308 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var (
+	ErrHeightPruned = errors.New("height has been pruned")
+
+	errZeroBatchSize      = errors.New("batch size must be greater than 0")
+	errNegativeBatchDelay = errors.New("batch delay must not be negative")
+	errZeroFrequency      = errors.New("frequency must be greater than 0")
+	errAlreadyPruning     = errors.New("pruning is already running")
+)
+
+// PruneConfig defines which heights must remain readable and how quickly the
+// versions that are no longer readable are removed.
+//
+// If both RetainedHeights and MinHeight are set, the stricter of the two is
+// applied. If neither is set, nothing is pruned.
+type PruneConfig struct {
+	// RetainedHeights is the number of most recent heights that must remain
+	// readable. If 0, the number of heights is not limited.
+	RetainedHeights uint64 `json:"retainedHeights"`
+	// MinHeight is the lowest height that must remain readable. If MinHeight
+	// is above the last written height, the last written height is used
+	// instead until MinHeight is reached.
+	MinHeight uint64 `json:"minHeight"`
+	// BatchSize is the maximum number of database keys that are inspected in a
+	// single batch.
+	BatchSize int `json:"batchSize"`
+	// BatchDelay is the amount of time to wait between batches.
+	BatchDelay time.Duration `json:"batchDelay"`
+	// Frequency is how often the retention window is re-evaluated after all
+	// the unreadable versions have been pruned.
+	Frequency time.Duration `json:"frequency"`
+}
+
+func (c *PruneConfig) Verify() error {
+	switch {
+	case c.BatchSize <= 0:
+		return errZeroBatchSize
+	case c.BatchDelay < 0:
+		return errNegativeBatchDelay
+	case c.Frequency <= 0:
+		return errZeroFrequency
+	default:
+		return nil
+	}
+}
+
+// minHeight returns the lowest height that must remain readable when
+// [lastHeight] is the last written height. The last written height always
+// remains readable.
+func (c *PruneConfig) minHeight(lastHeight uint64) uint64 {
+	minHeight := c.MinHeight
+	if c.RetainedHeights != 0 && lastHeight >= c.RetainedHeights {
+		minHeight = max(minHeight, lastHeight-c.RetainedHeights+1)
+	}
+	return min(minHeight, lastHeight)
+}
+
+// PruneStatus reports the progress of pruning.
+type PruneStatus struct {
+	// MinHeight is the lowest height that can be read.
+	MinHeight uint64 `json:"minHeight"`
+	// Pruning is true while versions that are not readable at MinHeight are
+	// being removed.
+	Pruning bool `json:"pruning"`
+	// KeysInspected is the number of database keys inspected by the current
+	// pass.
+	KeysInspected uint64 `json:"keysInspected"`
+	// CompletedHeight is the MinHeight of the last completed pass.
+	CompletedHeight uint64 `json:"completedHeight"`
+	// PassesCompleted is the number of completed passes.
+	PassesCompleted uint64 `json:"passesCompleted"`
+	// LastPassCompleted is the time the last pass was completed.
+	LastPassCompleted time.Time `json:"lastPassCompleted"`
+	// VersionsPruned is the number of versions removed since pruning started.
+	VersionsPruned uint64 `json:"versionsPruned"`
+	// Error is the error that stopped pruning, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// pruneCursor tracks the position of a pass between batches.
+type pruneCursor struct {
+	// start is the next database key to inspect.
+	start []byte
+	// key is the user key that is currently being inspected.
+	key []byte
+	// foundVisible is true if the version of [key] that is readable at the
+	// minimum height has already been inspected.
+	foundVisible bool
+}
+
+// MinHeight returns the lowest height that can be read. Readers opened below
+// this height return ErrHeightPruned.
+func (db *Database) MinHeight() uint64 {
+	return db.minHeight.Load()
+}
+
+// Prune removes all the versions that can no longer be read at the heights
+// retained by [config]. The retention window is re-evaluated every
+// [config.Frequency] until [ctx] is cancelled or an error occurs.
+//
+// Pruning happens in batches, so readers and writers are not blocked while a
+// pass is running. Readers opened at a height that becomes pruned will return
+// ErrHeightPruned.
+//
+// Note: Once a deletion is no longer readable, it is pruned as well. This means
+// that GetEntry will report ErrNotFound, rather than the deletion, for keys
+// that were deleted at or below the minimum height.
+func (db *Database) Prune(ctx context.Context, config PruneConfig) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+
+	db.pruneLock.Lock()
+	if db.pruning {
+		db.pruneLock.Unlock()
+		return errAlreadyPruning
+	}
+	db.pruning = true
+	db.pruneStatus = &PruneStatus{}
+	db.pruneErr = nil
+	db.pruneLock.Unlock()
+
+	err := db.prune(ctx, &config)
+
+	db.pruneLock.Lock()
+	defer db.pruneLock.Unlock()
+
+	db.pruning = false
+	db.pruneStatus.Pruning = false
+	if err != nil && err != ctx.Err() {
+		db.pruneStatus.Error = err.Error()
+		db.pruneErr = err
+	}
+	return err
+}
+
+func (db *Database) prune(ctx context.Context, config *PruneConfig) error {
+	// A pass is always executed at startup because a previous pass may have
+	// been interrupted.
+	completed := false
+	completedHeight := uint64(0)
+	for {
+		lastHeight, err := db.Height()
+		switch {
+		case err == database.ErrNotFound:
+		case err != nil:
+			return err
+		default:
+			// The minimum height is never lowered, as the versions below it may
+			// have already been removed.
+			minHeight := max(db.MinHeight(), config.minHeight(lastHeight))
+			if !completed || minHeight > completedHeight {
+				if err := db.prunePass(ctx, config, minHeight); err != nil {
+					return err
+				}
+				completed = true
+				completedHeight = minHeight
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(config.Frequency):
+		}
+	}
+}
+
+// prunePass removes all the versions that are not readable at or above
+// [minHeight].
+func (db *Database) prunePass(ctx context.Context, config *PruneConfig, minHeight uint64) error {
+	// The minimum height must be persisted before removing any versions so
+	// that readers are never served partially pruned state.
+	if err := database.PutUInt64(db.db, minHeightKey, minHeight); err != nil {
+		return err
+	}
+	db.minHeight.Store(minHeight)
+
+	db.pruneLock.Lock()
+	db.pruneStatus.MinHeight = minHeight
+	db.pruneStatus.Pruning = true
+	db.pruneStatus.KeysInspected = 0
+	db.pruneLock.Unlock()
+
+	cursor := &pruneCursor{}
+	for {
+		done, err := db.pruneBatch(cursor, minHeight, config.BatchSize)
+		if err != nil {
+			return err
+		}
+		if done {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(config.BatchDelay):
+		}
+	}
+
+	db.pruneLock.Lock()
+	defer db.pruneLock.Unlock()
+
+	db.pruneStatus.Pruning = false
+	db.pruneStatus.CompletedHeight = minHeight
+	db.pruneStatus.PassesCompleted++
+	db.pruneStatus.LastPassCompleted = time.Now()
+	return nil
+}
+
+// pruneBatch inspects up to [batchSize] database keys starting at the cursor
+// and removes the versions that are not readable at or above [minHeight].
+//
+// Returns true if all the database keys have been inspected.
+func (db *Database) pruneBatch(cursor *pruneCursor, minHeight uint64, batchSize int) (bool, error) {
+	it := db.db.NewIteratorWithStart(cursor.start)
+	defer it.Release()
+
+	var (
+		batch     = db.db.NewBatch()
+		inspected uint64
+		pruned    uint64
+		lastDBKey []byte
+		done      = true
+	)
+	for it.Next() {
+		if inspected == uint64(batchSize) {
+			done = false
+			break
+		}
+		inspected++
+
+		dbKey := it.Key()
+		lastDBKey = slices.Clone(dbKey)
+		if isDBKeyFromMetadata(dbKey) {
+			continue
+		}
+
+		key, height, err := parseDBKeyFromUser(dbKey)
+		if err != nil {
+			return false, err
+		}
+
+		if cursor.key == nil || !bytes.Equal(key, cursor.key) {
+			cursor.key = slices.Clone(key)
+			cursor.foundVisible = false
+		}
+
+		// Versions are sorted by decreasing height, so the first version at or
+		// below [minHeight] is the version that is readable at [minHeight].
+		// All the following versions can never be read again.
+		if height > minHeight {
+			continue
+		}
+		if !cursor.foundVisible {
+			cursor.foundVisible = true
+			if _, exists := parseDBValue(it.Value()); exists {
+				continue
+			}
+		}
+
+		if err := batch.Delete(lastDBKey); err != nil {
+			return false, err
+		}
+		pruned++
+	}
+	if err := it.Error(); err != nil {
+		return false, err
+	}
+	if err := batch.Write(); err != nil {
+		return false, err
+	}
+
+	if lastDBKey != nil {
+		// The smallest key that is larger than [lastDBKey].
+		cursor.start = append(lastDBKey, 0)
+	}
+
+	db.pruneLock.Lock()
+	defer db.pruneLock.Unlock()
+
+	db.pruneStatus.KeysInspected += inspected
+	db.pruneStatus.VersionsPruned += pruned
+	return done, nil
+}
+
+// verifyHeight returns ErrHeightPruned if [height] is below the minimum height.
+func (db *Database) verifyHeight(height uint64) error {
+	if height < db.MinHeight() {
+		return ErrHeightPruned
+	}
+	return nil
+}
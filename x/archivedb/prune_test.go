@@ -0,0 +1,370 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+)
+
+func TestPruneConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      PruneConfig
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: PruneConfig{
+				RetainedHeights: 10,
+				BatchSize:       1,
+				Frequency:       time.Second,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "zero batch size",
+			config: PruneConfig{
+				Frequency: time.Second,
+			},
+			expectedErr: errZeroBatchSize,
+		},
+		{
+			name: "negative batch delay",
+			config: PruneConfig{
+				BatchSize:  1,
+				BatchDelay: -time.Second,
+				Frequency:  time.Second,
+			},
+			expectedErr: errNegativeBatchDelay,
+		},
+		{
+			name: "zero frequency",
+			config: PruneConfig{
+				BatchSize: 1,
+			},
+			expectedErr: errZeroFrequency,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestPruneConfigMinHeight(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     PruneConfig
+		lastHeight uint64
+		expected   uint64
+	}{
+		{
+			name:       "no retention",
+			lastHeight: 100,
+			expected:   0,
+		},
+		{
+			name: "retained heights",
+			config: PruneConfig{
+				RetainedHeights: 10,
+			},
+			lastHeight: 100,
+			expected:   91,
+		},
+		{
+			name: "retained heights exceeds last height",
+			config: PruneConfig{
+				RetainedHeights: 200,
+			},
+			lastHeight: 100,
+			expected:   0,
+		},
+		{
+			name: "min height",
+			config: PruneConfig{
+				MinHeight: 50,
+			},
+			lastHeight: 100,
+			expected:   50,
+		},
+		{
+			name: "min height is stricter",
+			config: PruneConfig{
+				RetainedHeights: 60,
+				MinHeight:       50,
+			},
+			lastHeight: 100,
+			expected:   50,
+		},
+		{
+			name: "retained heights is stricter",
+			config: PruneConfig{
+				RetainedHeights: 10,
+				MinHeight:       50,
+			},
+			lastHeight: 100,
+			expected:   91,
+		},
+		{
+			name: "min height exceeds last height",
+			config: PruneConfig{
+				MinHeight: 200,
+			},
+			lastHeight: 100,
+			expected:   100,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, test.config.minHeight(test.lastHeight))
+		})
+	}
+}
+
+func TestPrunePass(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := New(baseDB)
+	require.NoError(err)
+	db.pruneStatus = &PruneStatus{}
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@1")))
+	require.NoError(batch.Put([]byte("key2"), []byte("value2@1")))
+	require.NoError(batch.Put([]byte("key3"), []byte("value3@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@2")))
+	require.NoError(batch.Delete([]byte("key2")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@3")))
+	require.NoError(batch.Put([]byte("key3"), []byte("value3@3")))
+	require.NoError(batch.Write())
+
+	config := &PruneConfig{
+		BatchSize: 1,
+		Frequency: time.Second,
+	}
+	require.NoError(db.prunePass(context.Background(), config, 2))
+
+	require.Equal(uint64(2), db.MinHeight())
+
+	// The minimum height is loaded when the database is opened
+	reopenedDB, err := New(baseDB)
+	require.NoError(err)
+	require.Equal(uint64(2), reopenedDB.MinHeight())
+
+	_, err = reopenedDB.Open(1).Get([]byte("key1"))
+	require.ErrorIs(err, ErrHeightPruned)
+
+	// key1@1 and both versions of key2 are no longer readable.
+	it := baseDB.NewIterator()
+	numKeys := 0
+	for it.Next() {
+		if !isDBKeyFromMetadata(it.Key()) {
+			numKeys++
+		}
+	}
+	require.NoError(it.Error())
+	it.Release()
+	require.Equal(4, numKeys)
+
+	reader := db.Open(1)
+	_, err = reader.Get([]byte("key1"))
+	require.ErrorIs(err, ErrHeightPruned)
+
+	it = reader.NewIterator()
+	require.False(it.Next())
+	err = it.Error()
+	require.ErrorIs(err, ErrHeightPruned)
+	it.Release()
+
+	reader = db.Open(2)
+	value, err := reader.Get([]byte("key1"))
+	require.NoError(err)
+	require.Equal([]byte("value1@2"), value)
+
+	_, err = reader.Get([]byte("key2"))
+	require.ErrorIs(err, database.ErrNotFound)
+
+	value, err = reader.Get([]byte("key3"))
+	require.NoError(err)
+	require.Equal([]byte("value3@1"), value)
+
+	reader = db.Open(3)
+	value, err = reader.Get([]byte("key1"))
+	require.NoError(err)
+	require.Equal([]byte("value1@3"), value)
+
+	value, err = reader.Get([]byte("key3"))
+	require.NoError(err)
+	require.Equal([]byte("value3@3"), value)
+
+	details, err := db.HealthCheck(context.Background())
+	require.NoError(err)
+	status := details.(map[string]interface{})["pruning"].(PruneStatus)
+	require.Equal(uint64(2), status.MinHeight)
+	require.Equal(uint64(2), status.CompletedHeight)
+	require.Equal(uint64(1), status.PassesCompleted)
+	require.Equal(uint64(3), status.VersionsPruned)
+	require.False(status.Pruning)
+}
+
+func TestPruneRetainsActiveReaders(t *testing.T) {
+	require := require.New(t)
+
+	db, err := New(memdb.New())
+	require.NoError(err)
+	db.pruneStatus = &PruneStatus{}
+
+	for height := uint64(1); height <= 10; height++ {
+		batch := db.NewBatch(height)
+		require.NoError(batch.Put([]byte("key"), []byte{byte(height)}))
+		require.NoError(batch.Write())
+	}
+
+	reader := db.Open(5)
+	it := reader.NewIterator()
+	defer it.Release()
+
+	config := &PruneConfig{
+		BatchSize: 3,
+		Frequency: time.Second,
+	}
+	require.NoError(db.prunePass(context.Background(), config, 5))
+
+	value, err := reader.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte{5}, value)
+
+	require.True(it.Next())
+	require.Equal([]byte("key"), it.Key())
+	require.Equal([]byte{5}, it.Value())
+	require.False(it.Next())
+	require.NoError(it.Error())
+}
+
+func TestPrune(t *testing.T) {
+	require := require.New(t)
+
+	db, err := New(memdb.New())
+	require.NoError(err)
+
+	for height := uint64(1); height <= 10; height++ {
+		batch := db.NewBatch(height)
+		require.NoError(batch.Put([]byte("key"), []byte{byte(height)}))
+		require.NoError(batch.Write())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	config := PruneConfig{
+		RetainedHeights: 3,
+		BatchSize:       1,
+		Frequency:       time.Millisecond,
+	}
+	done := make(chan error)
+	go func() {
+		done <- db.Prune(ctx, config)
+	}()
+
+	require.Eventually(func() bool {
+		return db.MinHeight() == 8
+	}, time.Minute, time.Millisecond)
+
+	batch := db.NewBatch(11)
+	require.NoError(batch.Put([]byte("key"), []byte{11}))
+	require.NoError(batch.Write())
+
+	require.Eventually(func() bool {
+		details, err := db.HealthCheck(context.Background())
+		require.NoError(err)
+		status := details.(map[string]interface{})["pruning"].(PruneStatus)
+		return status.CompletedHeight == 9
+	}, time.Minute, time.Millisecond)
+
+	_, err = db.Open(8).Get([]byte("key"))
+	require.ErrorIs(err, ErrHeightPruned)
+
+	value, err := db.Open(9).Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte{9}, value)
+
+	// Only one pruning job can be running at a time.
+	err = db.Prune(ctx, config)
+	require.ErrorIs(err, errAlreadyPruning)
+
+	cancel()
+	err = <-done
+	require.ErrorIs(err, context.Canceled)
+
+	_, err = db.HealthCheck(context.Background())
+	require.NoError(err)
+}
+
+func TestPruneMinHeightAheadOfLastHeight(t *testing.T) {
+	require := require.New(t)
+
+	db, err := New(memdb.New())
+	require.NoError(err)
+
+	for height := uint64(1); height <= 5; height++ {
+		batch := db.NewBatch(height)
+		require.NoError(batch.Put([]byte("key"), []byte{byte(height)}))
+		require.NoError(batch.Write())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	config := PruneConfig{
+		MinHeight: 10,
+		BatchSize: 1,
+		Frequency: time.Millisecond,
+	}
+	done := make(chan error)
+	go func() {
+		done <- db.Prune(ctx, config)
+	}()
+
+	// The last written height must remain readable.
+	require.Eventually(func() bool {
+		details, err := db.HealthCheck(context.Background())
+		require.NoError(err)
+		status := details.(map[string]interface{})["pruning"].(PruneStatus)
+		return status.PassesCompleted > 0
+	}, time.Minute, time.Millisecond)
+	require.Equal(uint64(5), db.MinHeight())
+
+	value, err := db.Open(5).Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte{5}, value)
+
+	// Heights written before MinHeight is reached must remain readable.
+	batch := db.NewBatch(6)
+	require.NoError(batch.Put([]byte("key"), []byte{6}))
+	require.NoError(batch.Write())
+
+	require.Eventually(func() bool {
+		return db.MinHeight() == 6
+	}, time.Minute, time.Millisecond)
+
+	value, err = db.Open(6).Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte{6}, value)
+
+	cancel()
+	err = <-done
+	require.ErrorIs(err, context.Canceled)
+}
@@ -5,7 +5,10 @@ package archivedb
 
 import "github.com/ava-labs/avalanchego/database"
 
-var _ database.KeyValueReader = (*Reader)(nil)
+var (
+	_ database.KeyValueReader = (*Reader)(nil)
+	_ database.Iteratee       = (*Reader)(nil)
+)
 
 type Reader struct {
 	db     *Database
@@ -34,7 +37,13 @@ func (r *Reader) Get(key []byte) ([]byte, error) {
 // GetEntry retrieves the value of the provided key, the height it was last
 // modified at, and a boolean to indicate if the last modification was an
 // insertion. If the key has never been modified, ErrNotFound will be returned.
+// If the height of the reader has been pruned, ErrHeightPruned will be
+// returned.
 func (r *Reader) GetEntry(key []byte) ([]byte, uint64, bool, error) {
+	if err := r.db.verifyHeight(r.height); err != nil {
+		return nil, 0, false, err
+	}
+
 	it := r.db.db.NewIteratorWithStartAndPrefix(newDBKeyFromUser(key, r.height))
 	defer it.Release()
 
@@ -59,3 +68,30 @@ func (r *Reader) GetEntry(key []byte) ([]byte, uint64, bool, error) {
 	}
 	return value, height, true, nil
 }
+
+// NewIterator iterates over all the keys that exist at the height of the
+// reader. Each key is returned with the value it had at the height of the
+// reader.
+//
+// Keys are returned in lexicographical order. If the height of the reader is
+// pruned while iterating, the remaining keys may not be returned.
+func (r *Reader) NewIterator() database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (r *Reader) NewIteratorWithStart(start []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (r *Reader) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (r *Reader) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if err := r.db.verifyHeight(r.height); err != nil {
+		return &database.IteratorError{
+			Err: err,
+		}
+	}
+	return newIterator(r.db.db, r.height, start, prefix)
+}
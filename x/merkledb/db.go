@@ -177,6 +177,10 @@ type Config struct {
 	Reg        prometheus.Registerer
 	TraceLevel TraceLevel
 	Tracer     trace.Tracer
+	// PebbleProfile selects the options that [NewPebbleDB] opens the
+	// underlying pebble database with. It's unused if the underlying database
+	// isn't opened by [NewPebbleDB].
+	PebbleProfile PebbleProfile
 }
 
 // merkleDB can only be edited by committing changes from a view.
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/pebble"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// DefaultPebbleProfile opens pebble with [pebble.DefaultConfig].
+	DefaultPebbleProfile PebbleProfile = ""
+	// MerkleDBPebbleProfile opens pebble with [MerkleDBPebbleConfig].
+	MerkleDBPebbleProfile PebbleProfile = "merkledb"
+)
+
+var (
+	ErrInvalidPebbleProfile = errors.New("invalid pebble profile")
+
+	// MerkleDBPebbleConfig is tuned for the workload merkledb generates.
+	//
+	// Every commit rewrites the intermediate nodes on the path to each changed
+	// key, so writes arrive as large batches of small, frequently overwritten
+	// nodes. Larger memtables let those overwrites be discarded before they
+	// are flushed, and more concurrent compactions keep L0 from stalling writes
+	// while the rewrites are compacted away.
+	//
+	// Node lookups are point reads, and many of them are for nodes that don't
+	// exist yet, so bloom filters let most of them skip reading sstables.
+	MerkleDBPebbleConfig = pebble.Config{
+		CacheSize:                   512 * units.MiB,
+		BytesPerSync:                512 * units.KiB,
+		WALBytesPerSync:             0, // Default to no background syncing.
+		MemTableStopWritesThreshold: 8,
+		MemTableSize:                256 * units.MiB,
+		MaxOpenFiles:                4096,
+		MaxConcurrentCompactions:    4,
+		L0CompactionThreshold:       4,
+		L0StopWritesThreshold:       24,
+		BloomFilterBitsPerKey:       10,
+	}
+)
+
+// PebbleProfile selects the options pebble is opened with by [NewPebbleDB].
+type PebbleProfile string
+
+// PebbleConfig returns the pebble config that [p] selects.
+func (p PebbleProfile) PebbleConfig() (pebble.Config, error) {
+	switch p {
+	case DefaultPebbleProfile:
+		return pebble.DefaultConfig, nil
+	case MerkleDBPebbleProfile:
+		return MerkleDBPebbleConfig, nil
+	default:
+		return pebble.Config{}, fmt.Errorf("%w: %q", ErrInvalidPebbleProfile, p)
+	}
+}
+
+// NewPebbleDB opens the pebble database at [path] with the options selected
+// by [config.PebbleProfile]. The result can be passed to [New] along with
+// [config].
+func NewPebbleDB(
+	path string,
+	config Config,
+	log logging.Logger,
+	namespace string,
+	reg prometheus.Registerer,
+) (database.Database, error) {
+	pebbleConfig, err := config.PebbleProfile.PebbleConfig()
+	if err != nil {
+		return nil, err
+	}
+	pebbleConfigBytes, err := json.Marshal(pebbleConfig)
+	if err != nil {
+		return nil, err
+	}
+	return pebble.New(path, pebbleConfigBytes, log, namespace, reg)
+}
@@ -0,0 +1,328 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/pebble"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// benchmarkInitialKeys is the number of key/values written before the
+	// update and proof workloads start.
+	benchmarkInitialKeys = 10_000
+	// benchmarkBatchSize is the number of key/values committed per iteration.
+	benchmarkBatchSize = 100
+	// benchmarkRangeProofSize is the number of key/values in each range proof.
+	benchmarkRangeProofSize = 100
+)
+
+func TestPebbleProfile(t *testing.T) {
+	tests := []struct {
+		profile        PebbleProfile
+		expectedConfig pebble.Config
+		expectedErr    error
+	}{
+		{
+			profile:        DefaultPebbleProfile,
+			expectedConfig: pebble.DefaultConfig,
+		},
+		{
+			profile:        MerkleDBPebbleProfile,
+			expectedConfig: MerkleDBPebbleConfig,
+		},
+		{
+			profile:     "unknown",
+			expectedErr: ErrInvalidPebbleProfile,
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.profile), func(t *testing.T) {
+			require := require.New(t)
+
+			config, err := test.profile.PebbleConfig()
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedConfig, config)
+		})
+	}
+}
+
+func TestNewPebbleDB(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	config := newDefaultConfig()
+	config.PebbleProfile = MerkleDBPebbleProfile
+
+	path := t.TempDir()
+	baseDB, err := NewPebbleDB(path, config, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	db, err := New(ctx, baseDB, config)
+	require.NoError(err)
+	require.NoError(db.Put([]byte{0}, []byte{0}))
+	root, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.NoError(db.Close())
+	require.NoError(baseDB.Close())
+
+	// The root should be reloaded from disk.
+	config.Reg = prometheus.NewRegistry()
+	baseDB, err = NewPebbleDB(path, config, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	db, err = New(ctx, baseDB, config)
+	require.NoError(err)
+	reloadedRoot, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(root, reloadedRoot)
+	require.NoError(db.Close())
+	require.NoError(baseDB.Close())
+}
+
+// Benchmark_MerkleDB_Backends replays synthetic merkledb workloads against
+// each on-disk database. In addition to the time per iteration, it reports:
+//
+//   - keys/s: the number of key/values handled per second.
+//   - write-amp: the number of bytes written to disk per byte of key/value
+//     data committed. Only reported on Linux.
+//   - disk-MiB: the size of the database directory once the workload is done.
+func Benchmark_MerkleDB_Backends(b *testing.B) {
+	backends := []struct {
+		name string
+		open func(path string, config Config) (database.Database, error)
+	}{
+		{
+			name: "leveldb",
+			open: func(path string, _ Config) (database.Database, error) {
+				return leveldb.New(path, nil, logging.NoLog{}, "", prometheus.NewRegistry())
+			},
+		},
+		{
+			name: "pebble",
+			open: func(path string, config Config) (database.Database, error) {
+				config.PebbleProfile = DefaultPebbleProfile
+				return NewPebbleDB(path, config, logging.NoLog{}, "", prometheus.NewRegistry())
+			},
+		},
+		{
+			name: "pebble_merkledb_profile",
+			open: func(path string, config Config) (database.Database, error) {
+				config.PebbleProfile = MerkleDBPebbleProfile
+				return NewPebbleDB(path, config, logging.NoLog{}, "", prometheus.NewRegistry())
+			},
+		},
+	}
+	workloads := []struct {
+		name        string
+		initialKeys int
+		// run runs one iteration of the workload and returns the number of
+		// key/values it handled and the number of key/value bytes it
+		// committed.
+		run func(b *testing.B, r *rand.Rand, db MerkleDB, keys [][]byte) (int, int)
+	}{
+		{
+			name: "insert",
+			run:  benchmarkInsert,
+		},
+		{
+			name:        "update",
+			initialKeys: benchmarkInitialKeys,
+			run:         benchmarkUpdate,
+		},
+		{
+			name:        "proof",
+			initialKeys: benchmarkInitialKeys,
+			run:         benchmarkProof,
+		},
+		{
+			name:        "commit",
+			initialKeys: benchmarkInitialKeys,
+			run:         benchmarkCommit,
+		},
+	}
+	for _, backend := range backends {
+		for _, workload := range workloads {
+			b.Run(fmt.Sprintf("%s_%s", backend.name, workload.name), func(b *testing.B) {
+				require := require.New(b)
+				ctx := context.Background()
+				r := rand.New(rand.NewSource(0)) // #nosec G404
+
+				config := newDefaultConfig()
+				config.IntermediateWriteBatchSize = 256 * units.KiB
+				config.IntermediateWriteBufferSize = units.MiB
+
+				path := b.TempDir()
+				baseDB, err := backend.open(path, config)
+				require.NoError(err)
+				db, err := New(ctx, baseDB, config)
+				require.NoError(err)
+
+				keys := make([][]byte, 0, workload.initialKeys)
+				batch := db.NewBatch()
+				for i := 0; i < workload.initialKeys; i++ {
+					key, value := randomKeyValue(r)
+					keys = append(keys, key)
+					require.NoError(batch.Put(key, value))
+				}
+				require.NoError(batch.Write())
+
+				startBytesWritten, measureWriteAmp := bytesWritten()
+				b.ResetTimer()
+
+				var numKeys, numBytes int
+				for i := 0; i < b.N; i++ {
+					n, size := workload.run(b, r, db, keys)
+					numKeys += n
+					numBytes += size
+				}
+				require.NoError(db.Close())
+				require.NoError(baseDB.Close())
+
+				b.StopTimer()
+				b.ReportMetric(float64(numKeys)/b.Elapsed().Seconds(), "keys/s")
+				if endBytesWritten, ok := bytesWritten(); measureWriteAmp && ok && numBytes > 0 {
+					b.ReportMetric(float64(endBytesWritten-startBytesWritten)/float64(numBytes), "write-amp")
+				}
+				b.ReportMetric(float64(dirSize(require, path))/units.MiB, "disk-MiB")
+			})
+		}
+	}
+}
+
+// benchmarkInsert commits a batch of new key/values.
+func benchmarkInsert(b *testing.B, r *rand.Rand, db MerkleDB, _ [][]byte) (int, int) {
+	var (
+		batch    = db.NewBatch()
+		numBytes int
+	)
+	for i := 0; i < benchmarkBatchSize; i++ {
+		key, value := randomKeyValue(r)
+		numBytes += len(key) + len(value)
+		require.NoError(b, batch.Put(key, value))
+	}
+	require.NoError(b, batch.Write())
+	return benchmarkBatchSize, numBytes
+}
+
+// benchmarkUpdate commits a batch of new values for existing keys.
+func benchmarkUpdate(b *testing.B, r *rand.Rand, db MerkleDB, keys [][]byte) (int, int) {
+	var (
+		batch    = db.NewBatch()
+		numBytes int
+	)
+	for i := 0; i < benchmarkBatchSize; i++ {
+		key := keys[r.Intn(len(keys))]
+		_, value := randomKeyValue(r)
+		numBytes += len(key) + len(value)
+		require.NoError(b, batch.Put(key, value))
+	}
+	require.NoError(b, batch.Write())
+	return benchmarkBatchSize, numBytes
+}
+
+// benchmarkProof generates a proof of an existing key and a range proof that
+// starts at it.
+func benchmarkProof(b *testing.B, r *rand.Rand, db MerkleDB, keys [][]byte) (int, int) {
+	ctx := context.Background()
+	key := keys[r.Intn(len(keys))]
+
+	_, err := db.GetProof(ctx, key)
+	require.NoError(b, err)
+	rangeProof, err := db.GetRangeProof(ctx, maybe.Some(key), maybe.Nothing[[]byte](), benchmarkRangeProofSize)
+	require.NoError(b, err)
+	return 1 + len(rangeProof.KeyValues), 0
+}
+
+// benchmarkCommit commits a view that inserts, updates and deletes key/values.
+func benchmarkCommit(b *testing.B, r *rand.Rand, db MerkleDB, keys [][]byte) (int, int) {
+	ctx := context.Background()
+
+	var (
+		ops      = make([]database.BatchOp, 0, benchmarkBatchSize)
+		numBytes int
+	)
+	for i := 0; i < benchmarkBatchSize; i++ {
+		var op database.BatchOp
+		switch i % 3 {
+		case 0:
+			op.Key, op.Value = randomKeyValue(r)
+		case 1:
+			op.Key = keys[r.Intn(len(keys))]
+			_, op.Value = randomKeyValue(r)
+		default:
+			op.Key = keys[r.Intn(len(keys))]
+			op.Delete = true
+		}
+		numBytes += len(op.Key) + len(op.Value)
+		ops = append(ops, op)
+	}
+
+	view, err := db.NewView(ctx, ViewChanges{BatchOps: ops})
+	require.NoError(b, err)
+	require.NoError(b, view.CommitToDB(ctx))
+	return benchmarkBatchSize, numBytes
+}
+
+func randomKeyValue(r *rand.Rand) ([]byte, []byte) {
+	key := make([]byte, 32)
+	_, _ = r.Read(key) // #nosec G404
+	value := make([]byte, r.Intn(128)+1)
+	_, _ = r.Read(value) // #nosec G404
+	return key, value
+}
+
+// bytesWritten returns the number of bytes this process has passed to write
+// syscalls. Returns false if the number isn't available on this platform.
+func bytesWritten() (uint64, bool) {
+	ioStats, err := os.ReadFile("/proc/self/io")
+	if err != nil {
+		return 0, false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(ioStats))
+	for scanner.Scan() {
+		value, ok := bytes.CutPrefix(scanner.Bytes(), []byte("wchar: "))
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(string(value), 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// dirSize returns the total size of the files in [path].
+func dirSize(require *require.Assertions, path string) int64 {
+	var size int64
+	require.NoError(filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	}))
+	return size
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/units"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+)
+
+const (
+	snapshotVersion = 0
+
+	// maxSnapshotChunkSize is the maximum number of bytes a single range proof
+	// in a snapshot may use.
+	maxSnapshotChunkSize = 512 * units.MiB
+)
+
+var (
+	ErrInvalidSnapshotVersion       = errors.New("invalid snapshot version")
+	ErrSnapshotBranchFactorMismatch = errors.New("snapshot branch factor doesn't match the database")
+	ErrSnapshotChunkTooLarge        = errors.New("snapshot chunk too large")
+	ErrSnapshotDBNotEmpty           = errors.New("database must be empty to import a snapshot")
+	ErrSnapshotRootMismatch         = errors.New("imported snapshot root doesn't match the expected root")
+)
+
+// A snapshot is a stream of the form:
+//
+//	[version]      uvarint
+//	[branchFactor] uvarint
+//	[rootID]       32 bytes
+//	[chunk]*
+//
+// where each [chunk] is a uvarint length followed by a protobuf encoded range
+// proof. The first range proof starts at the smallest key in the trie, and each
+// subsequent range proof starts immediately after the largest key of the
+// previous range proof. Every range proof is unbounded above, so each chunk
+// can be verified against [rootID] on its own.
+
+// ExportSnapshot writes all the key/values of the trie whose root was [rootID]
+// to [w]. The key/values are written as range proofs of at most [chunkSize]
+// key/values each.
+//
+// [db] must have sufficient history to generate range proofs at [rootID].
+func ExportSnapshot(
+	ctx context.Context,
+	db RangeProofer,
+	branchFactor BranchFactor,
+	rootID ids.ID,
+	chunkSize int,
+	w io.Writer,
+) error {
+	if chunkSize <= 0 {
+		return ErrInvalidMaxLength
+	}
+
+	bw := bufio.NewWriter(w)
+	header := binary.AppendUvarint(nil, snapshotVersion)
+	header = binary.AppendUvarint(header, uint64(branchFactor))
+	header = append(header, rootID[:]...)
+	if _, err := bw.Write(header); err != nil {
+		return err
+	}
+
+	// An empty trie has no key/values to write.
+	if rootID == ids.Empty {
+		return bw.Flush()
+	}
+
+	start := maybe.Nothing[[]byte]()
+	for {
+		proof, err := db.GetRangeProofAtRoot(ctx, rootID, start, maybe.Nothing[[]byte](), chunkSize)
+		if err != nil {
+			return fmt.Errorf("failed to get range proof: %w", err)
+		}
+
+		proofBytes, err := proto.Marshal(proof.ToProto())
+		if err != nil {
+			return err
+		}
+		if _, err := bw.Write(binary.AppendUvarint(nil, uint64(len(proofBytes)))); err != nil {
+			return err
+		}
+		if _, err := bw.Write(proofBytes); err != nil {
+			return err
+		}
+
+		if len(proof.KeyValues) < chunkSize {
+			return bw.Flush()
+		}
+		start = nextSnapshotStart(proof)
+	}
+}
+
+// ImportSnapshot reads a snapshot written by ExportSnapshot from [r] and
+// commits its key/values into [db], which must be empty. Every range proof is
+// verified before it is committed. Returns the root ID of the snapshot, which
+// is also the root of [db] once the import succeeds.
+func ImportSnapshot(
+	ctx context.Context,
+	db MerkleDB,
+	branchFactor BranchFactor,
+	r io.Reader,
+) (ids.ID, error) {
+	if err := branchFactor.Valid(); err != nil {
+		return ids.Empty, err
+	}
+
+	dbRootID, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if dbRootID != ids.Empty {
+		return ids.Empty, fmt.Errorf("%w: root %s", ErrSnapshotDBNotEmpty, dbRootID)
+	}
+
+	br := bufio.NewReader(r)
+	version, err := binary.ReadUvarint(br)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to read snapshot version: %w", err)
+	}
+	if version != snapshotVersion {
+		return ids.Empty, fmt.Errorf("%w: %d", ErrInvalidSnapshotVersion, version)
+	}
+
+	snapshotBranchFactor, err := binary.ReadUvarint(br)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to read snapshot branch factor: %w", err)
+	}
+	if snapshotBranchFactor != uint64(branchFactor) {
+		return ids.Empty, fmt.Errorf("%w: snapshot has %d but database has %d",
+			ErrSnapshotBranchFactorMismatch,
+			snapshotBranchFactor,
+			branchFactor,
+		)
+	}
+
+	var rootID ids.ID
+	if _, err := io.ReadFull(br, rootID[:]); err != nil {
+		return ids.Empty, fmt.Errorf("failed to read snapshot root: %w", err)
+	}
+
+	var (
+		tokenSize = BranchFactorToTokenSize[branchFactor]
+		start     = maybe.Nothing[[]byte]()
+		end       = maybe.Nothing[[]byte]()
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return ids.Empty, err
+		}
+
+		proofLen, err := binary.ReadUvarint(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return ids.Empty, fmt.Errorf("failed to read snapshot chunk length: %w", err)
+		}
+		if proofLen > maxSnapshotChunkSize {
+			return ids.Empty, fmt.Errorf("%w: %d > %d", ErrSnapshotChunkTooLarge, proofLen, maxSnapshotChunkSize)
+		}
+
+		proofBytes := make([]byte, proofLen)
+		if _, err := io.ReadFull(br, proofBytes); err != nil {
+			return ids.Empty, fmt.Errorf("failed to read snapshot chunk: %w", err)
+		}
+
+		var pbProof pb.RangeProof
+		if err := proto.Unmarshal(proofBytes, &pbProof); err != nil {
+			return ids.Empty, err
+		}
+		var proof RangeProof
+		if err := proof.UnmarshalProto(&pbProof); err != nil {
+			return ids.Empty, err
+		}
+
+		if err := proof.Verify(ctx, start, end, rootID, tokenSize); err != nil {
+			return ids.Empty, fmt.Errorf("failed to verify snapshot chunk: %w", err)
+		}
+		if err := db.CommitRangeProof(ctx, start, end, &proof); err != nil {
+			return ids.Empty, fmt.Errorf("failed to commit snapshot chunk: %w", err)
+		}
+
+		if len(proof.KeyValues) > 0 {
+			start = nextSnapshotStart(&proof)
+		}
+	}
+
+	// Each chunk only proves the key/values it contains, so the root must be
+	// checked to ensure that no chunks were omitted.
+	dbRootID, err = db.GetMerkleRoot(ctx)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if dbRootID != rootID {
+		return ids.Empty, fmt.Errorf("%w: got %s, expected %s", ErrSnapshotRootMismatch, dbRootID, rootID)
+	}
+	return rootID, nil
+}
+
+// nextSnapshotStart returns the smallest key that is larger than every key in
+// [proof].
+//
+// Invariant: [proof] has at least one key/value.
+func nextSnapshotStart(proof *RangeProof) maybe.Maybe[[]byte] {
+	largestKey := proof.KeyValues[len(proof.KeyValues)-1].Key
+	return maybe.Some(append(slices.Clip(largestKey), 0))
+}
@@ -0,0 +1,199 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// writeRandomKeyValues writes [numKeys] random key/values to [db] and returns
+// them.
+func writeRandomKeyValues(require *require.Assertions, r *rand.Rand, db *merkleDB, numKeys int) map[string][]byte {
+	keyValues := make(map[string][]byte, numKeys)
+	batch := db.NewBatch()
+	for i := 0; i < numKeys; i++ {
+		key := make([]byte, r.Intn(32)+1)   // #nosec G404
+		_, _ = r.Read(key)                  // #nosec G404
+		value := make([]byte, r.Intn(64)+1) // #nosec G404
+		_, _ = r.Read(value)                // #nosec G404
+
+		keyValues[string(key)] = value
+		require.NoError(batch.Put(key, value))
+	}
+	require.NoError(batch.Write())
+	return keyValues
+}
+
+func Test_Snapshot_ExportImport(t *testing.T) {
+	const numKeys = 100
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+
+	for _, bf := range validBranchFactors {
+		for _, chunkSize := range []int{1, 10, numKeys, 2 * numKeys} {
+			t.Run(fmt.Sprintf("branch factor %d chunk size %d", bf, chunkSize), func(t *testing.T) {
+				require := require.New(t)
+				ctx := context.Background()
+
+				db, err := getBasicDBWithBranchFactor(bf)
+				require.NoError(err)
+				keyValues := writeRandomKeyValues(require, r, db, numKeys)
+
+				rootID, err := db.GetMerkleRoot(ctx)
+				require.NoError(err)
+
+				snapshot := &bytes.Buffer{}
+				require.NoError(ExportSnapshot(ctx, db, bf, rootID, chunkSize, snapshot))
+
+				importedDB, err := getBasicDBWithBranchFactor(bf)
+				require.NoError(err)
+				importedRootID, err := ImportSnapshot(ctx, importedDB, bf, snapshot)
+				require.NoError(err)
+				require.Equal(rootID, importedRootID)
+
+				for key, expectedValue := range keyValues {
+					value, err := importedDB.Get([]byte(key))
+					require.NoError(err)
+					require.Equal(expectedValue, value)
+				}
+			})
+		}
+	}
+}
+
+func Test_Snapshot_HistoricalRoot(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	writeBasicBatch(t, db)
+
+	rootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	// Modify the trie after [rootID]
+	batch := db.NewBatch()
+	require.NoError(batch.Delete([]byte{0}))
+	require.NoError(batch.Put([]byte{5}, []byte{5}))
+	require.NoError(batch.Write())
+
+	snapshot := &bytes.Buffer{}
+	require.NoError(ExportSnapshot(ctx, db, BranchFactor16, rootID, 2, snapshot))
+
+	importedDB, err := getBasicDB()
+	require.NoError(err)
+	importedRootID, err := ImportSnapshot(ctx, importedDB, BranchFactor16, snapshot)
+	require.NoError(err)
+	require.Equal(rootID, importedRootID)
+
+	value, err := importedDB.Get([]byte{0})
+	require.NoError(err)
+	require.Equal([]byte{0}, value)
+}
+
+func Test_Snapshot_EmptyTrie(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	snapshot := &bytes.Buffer{}
+	require.NoError(ExportSnapshot(ctx, db, BranchFactor16, ids.Empty, 10, snapshot))
+
+	importedDB, err := getBasicDB()
+	require.NoError(err)
+	importedRootID, err := ImportSnapshot(ctx, importedDB, BranchFactor16, snapshot)
+	require.NoError(err)
+	require.Equal(ids.Empty, importedRootID)
+}
+
+func Test_Snapshot_ImportInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := getBasicDB()
+	require.NoError(t, err)
+	writeBasicBatch(t, db)
+
+	rootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(t, err)
+
+	snapshot := &bytes.Buffer{}
+	require.NoError(t, ExportSnapshot(ctx, db, BranchFactor16, rootID, 2, snapshot))
+	snapshotBytes := snapshot.Bytes()
+
+	tests := []struct {
+		name         string
+		branchFactor BranchFactor
+		snapshot     func() []byte
+		nonEmptyDB   bool
+		expectedErr  error
+	}{
+		{
+			name:         "invalid version",
+			branchFactor: BranchFactor16,
+			snapshot: func() []byte {
+				snapshot := bytes.Clone(snapshotBytes)
+				snapshot[0] = snapshotVersion + 1
+				return snapshot
+			},
+			expectedErr: ErrInvalidSnapshotVersion,
+		},
+		{
+			name:         "branch factor mismatch",
+			branchFactor: BranchFactor4,
+			snapshot: func() []byte {
+				return snapshotBytes
+			},
+			expectedErr: ErrSnapshotBranchFactorMismatch,
+		},
+		{
+			name:         "wrong root",
+			branchFactor: BranchFactor16,
+			snapshot: func() []byte {
+				// The root ID follows the 1 byte version and 1 byte branch
+				// factor.
+				snapshot := bytes.Clone(snapshotBytes)
+				snapshot[2]++
+				return snapshot
+			},
+			expectedErr: ErrInvalidProof,
+		},
+		{
+			name:         "non-empty database",
+			branchFactor: BranchFactor16,
+			snapshot: func() []byte {
+				return snapshotBytes
+			},
+			nonEmptyDB:  true,
+			expectedErr: ErrSnapshotDBNotEmpty,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			importedDB, err := getBasicDBWithBranchFactor(test.branchFactor)
+			require.NoError(err)
+			if test.nonEmptyDB {
+				require.NoError(importedDB.Put([]byte{0}, []byte{0}))
+			}
+
+			_, err = ImportSnapshot(ctx, importedDB, test.branchFactor, bytes.NewReader(test.snapshot()))
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
@@ -124,28 +124,16 @@ func (c *client) GetChangeProof(
 				return nil, err
 			}
 
-			// Ensure the response does not contain more than the requested number of leaves
-			// and the start and end roots match the requested roots.
-			if len(changeProof.KeyChanges) > int(req.KeyLimit) {
-				return nil, fmt.Errorf(
-					"%w: (%d) > %d)",
-					errTooManyKeys, len(changeProof.KeyChanges), req.KeyLimit,
-				)
-			}
-
-			endRoot, err := ids.ToID(req.EndRootHash)
-			if err != nil {
-				return nil, err
-			}
-
-			if err := db.VerifyChangeProof(
+			if err := verifyChangeProof(
 				ctx,
+				db,
 				&changeProof,
+				int(req.KeyLimit),
 				startKey,
 				endKey,
-				endRoot,
+				req.EndRootHash,
 			); err != nil {
-				return nil, fmt.Errorf("%w due to %w", errInvalidChangeProof, err)
+				return nil, err
 			}
 
 			return &merkledb.ChangeOrRangeProof{
@@ -195,6 +183,43 @@ func (c *client) GetChangeProof(
 	return getAndParse(ctx, c, reqBytes, parseFn)
 }
 
+// Verify [changeProof] is a valid change proof for keys in [start, end] for
+// end root [endRootBytes] using [db]. Returns [errTooManyKeys] if the response
+// contains more than [keyLimit] keys.
+func verifyChangeProof(
+	ctx context.Context,
+	db DB,
+	changeProof *merkledb.ChangeProof,
+	keyLimit int,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	endRootBytes []byte,
+) error {
+	endRoot, err := ids.ToID(endRootBytes)
+	if err != nil {
+		return err
+	}
+
+	// Ensure the response does not contain more than the requested number of leaves.
+	if len(changeProof.KeyChanges) > keyLimit {
+		return fmt.Errorf(
+			"%w: (%d) > %d)",
+			errTooManyKeys, len(changeProof.KeyChanges), keyLimit,
+		)
+	}
+
+	if err := db.VerifyChangeProof(
+		ctx,
+		changeProof,
+		start,
+		end,
+		endRoot,
+	); err != nil {
+		return fmt.Errorf("%w due to %w", errInvalidChangeProof, err)
+	}
+	return nil
+}
+
 // Verify [rangeProof] is a valid range proof for keys in [start, end] for
 // root [rootBytes]. Returns [errTooManyKeys] if the response contains more
 // than [keyLimit] keys.
@@ -0,0 +1,346 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+)
+
+const (
+	snapshotFileExtension     = ".snapshot"
+	changeProofsFileExtension = ".changes"
+
+	// maxChangeProofChunkSize is the maximum number of bytes a single change
+	// proof in a change proofs file may use.
+	maxChangeProofChunkSize = 512 * units.MiB
+)
+
+var (
+	errNoSnapshot                = errors.New("no snapshot in proof directory")
+	errMultipleSnapshots         = errors.New("multiple snapshots in proof directory")
+	errInvalidProofFileName      = errors.New("invalid proof file name")
+	errConflictingChangeProofs   = errors.New("multiple change proofs files with the same start root")
+	errUnusedChangeProofs        = errors.New("change proofs files don't extend the snapshot")
+	errChangeProofChunkTooLarge  = errors.New("change proof chunk too large")
+	errChangeProofsRootMismatch  = errors.New("applied change proofs root doesn't match the expected root")
+	errNonPositiveProofChunkSize = errors.New("proof chunk size must be positive")
+)
+
+// A proof directory contains exactly one snapshot file named
+// "<rootID>.snapshot", written by [WriteSnapshotFile], and any number of change
+// proofs files named "<startRootID>-<endRootID>.changes", written by
+// [WriteChangeProofsFile].
+//
+// A change proofs file is a sequence of chunks, where each chunk is a uvarint
+// length followed by a protobuf encoded change proof. The first change proof
+// starts at the smallest key, and each subsequent change proof starts
+// immediately after the largest key of the previous change proof. Every change
+// proof is unbounded above.
+
+type DirectoryClientConfig struct {
+	// Dir is the proof directory to load.
+	Dir string
+	// DB is the empty database that the proofs in [Dir] are loaded into.
+	// Proofs requested from the client are generated from [DB].
+	DB           merkledb.MerkleDB
+	BranchFactor merkledb.BranchFactor
+}
+
+// NewDirectoryClient returns a Client that serves proofs from the proof
+// directory [config.Dir].
+//
+// The snapshot in [config.Dir] is imported into [config.DB] and then every
+// change proofs file that extends it is applied, in order. Every proof is
+// verified before it is committed. The resulting client can serve any root
+// that [config.DB] has history for, which includes the root of the snapshot and
+// the end root of every change proofs file as long as [config.DB]'s history
+// length is sufficient.
+func NewDirectoryClient(ctx context.Context, config *DirectoryClientConfig) (Client, error) {
+	if config.DB == nil {
+		return nil, errNoProofSource
+	}
+
+	entries, err := os.ReadDir(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		snapshotFile     string
+		changeProofFiles = make(map[ids.ID]changeProofsFile)
+	)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		switch filepath.Ext(name) {
+		case snapshotFileExtension:
+			if snapshotFile != "" {
+				return nil, fmt.Errorf("%w: %s and %s", errMultipleSnapshots, snapshotFile, name)
+			}
+			snapshotFile = name
+		case changeProofsFileExtension:
+			file, err := parseChangeProofsFileName(name)
+			if err != nil {
+				return nil, err
+			}
+			if conflicting, ok := changeProofFiles[file.startRoot]; ok {
+				return nil, fmt.Errorf("%w: %s and %s", errConflictingChangeProofs, conflicting.name, name)
+			}
+			changeProofFiles[file.startRoot] = file
+		}
+	}
+	if snapshotFile == "" {
+		return nil, fmt.Errorf("%w: %s", errNoSnapshot, config.Dir)
+	}
+
+	f, err := os.Open(filepath.Join(config.Dir, snapshotFile))
+	if err != nil {
+		return nil, err
+	}
+	root, err := merkledb.ImportSnapshot(ctx, config.DB, config.BranchFactor, f)
+	_ = f.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to import snapshot %s: %w", snapshotFile, err)
+	}
+
+	for {
+		file, ok := changeProofFiles[root]
+		if !ok {
+			break
+		}
+		delete(changeProofFiles, root)
+
+		if err := applyChangeProofsFile(ctx, config.DB, filepath.Join(config.Dir, file.name), file.endRoot); err != nil {
+			return nil, fmt.Errorf("failed to apply change proofs %s: %w", file.name, err)
+		}
+		root = file.endRoot
+	}
+	if len(changeProofFiles) != 0 {
+		return nil, fmt.Errorf("%w: %d files remain after reaching root %s",
+			errUnusedChangeProofs,
+			len(changeProofFiles),
+			root,
+		)
+	}
+
+	return NewLocalClient(&LocalClientConfig{
+		DB:           config.DB,
+		BranchFactor: config.BranchFactor,
+	})
+}
+
+// WriteSnapshotFile writes a snapshot of [db] at [rootID] into the proof
+// directory [dir]. See [merkledb.ExportSnapshot].
+func WriteSnapshotFile(
+	ctx context.Context,
+	db merkledb.RangeProofer,
+	branchFactor merkledb.BranchFactor,
+	rootID ids.ID,
+	chunkSize int,
+	dir string,
+) error {
+	return writeProofFile(
+		filepath.Join(dir, rootID.String()+snapshotFileExtension),
+		func(w io.Writer) error {
+			return merkledb.ExportSnapshot(ctx, db, branchFactor, rootID, chunkSize, w)
+		},
+	)
+}
+
+// WriteChangeProofsFile writes the changes made to [db] from [startRootID] to
+// [endRootID] into the proof directory [dir]. The changes are written as change
+// proofs of at most [chunkSize] key changes each.
+//
+// [db] must have sufficient history to generate change proofs from
+// [startRootID] to [endRootID].
+func WriteChangeProofsFile(
+	ctx context.Context,
+	db merkledb.ChangeProofer,
+	startRootID ids.ID,
+	endRootID ids.ID,
+	chunkSize int,
+	dir string,
+) error {
+	if chunkSize <= 0 {
+		return errNonPositiveProofChunkSize
+	}
+
+	name := fmt.Sprintf("%s-%s%s", startRootID, endRootID, changeProofsFileExtension)
+	return writeProofFile(
+		filepath.Join(dir, name),
+		func(w io.Writer) error {
+			start := maybe.Nothing[[]byte]()
+			for {
+				proof, err := db.GetChangeProof(ctx, startRootID, endRootID, start, maybe.Nothing[[]byte](), chunkSize)
+				if err != nil {
+					return fmt.Errorf("failed to get change proof: %w", err)
+				}
+				if proof.Empty() {
+					return nil
+				}
+
+				proofBytes, err := proto.Marshal(proof.ToProto())
+				if err != nil {
+					return err
+				}
+				if _, err := w.Write(binary.AppendUvarint(nil, uint64(len(proofBytes)))); err != nil {
+					return err
+				}
+				if _, err := w.Write(proofBytes); err != nil {
+					return err
+				}
+
+				if len(proof.KeyChanges) < chunkSize {
+					return nil
+				}
+				largestKey := proof.KeyChanges[len(proof.KeyChanges)-1].Key
+				start = maybe.Some(append(slices.Clip(largestKey), 0))
+			}
+		},
+	)
+}
+
+type changeProofsFile struct {
+	name      string
+	startRoot ids.ID
+	endRoot   ids.ID
+}
+
+func parseChangeProofsFileName(name string) (changeProofsFile, error) {
+	roots := strings.TrimSuffix(name, changeProofsFileExtension)
+	startRootStr, endRootStr, ok := strings.Cut(roots, "-")
+	if !ok {
+		return changeProofsFile{}, fmt.Errorf("%w: %s", errInvalidProofFileName, name)
+	}
+	startRoot, err := ids.FromString(startRootStr)
+	if err != nil {
+		return changeProofsFile{}, fmt.Errorf("%w: %s: %w", errInvalidProofFileName, name, err)
+	}
+	endRoot, err := ids.FromString(endRootStr)
+	if err != nil {
+		return changeProofsFile{}, fmt.Errorf("%w: %s: %w", errInvalidProofFileName, name, err)
+	}
+	return changeProofsFile{
+		name:      name,
+		startRoot: startRoot,
+		endRoot:   endRoot,
+	}, nil
+}
+
+// applyChangeProofsFile verifies and commits every change proof in the file at
+// [path] into [db]. [db] must be at the start root of the file.
+func applyChangeProofsFile(ctx context.Context, db merkledb.MerkleDB, path string, endRoot ids.ID) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var (
+		r     = bufio.NewReader(f)
+		start = maybe.Nothing[[]byte]()
+		end   = maybe.Nothing[[]byte]()
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		proofLen, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read change proof length: %w", err)
+		}
+		if proofLen > maxChangeProofChunkSize {
+			return fmt.Errorf("%w: %d > %d", errChangeProofChunkTooLarge, proofLen, maxChangeProofChunkSize)
+		}
+
+		proofBytes := make([]byte, proofLen)
+		if _, err := io.ReadFull(r, proofBytes); err != nil {
+			return fmt.Errorf("failed to read change proof: %w", err)
+		}
+
+		var pbProof pb.ChangeProof
+		if err := proto.Unmarshal(proofBytes, &pbProof); err != nil {
+			return err
+		}
+		var proof merkledb.ChangeProof
+		if err := proof.UnmarshalProto(&pbProof); err != nil {
+			return err
+		}
+
+		if err := db.VerifyChangeProof(ctx, &proof, start, end, endRoot); err != nil {
+			return fmt.Errorf("%w due to %w", errInvalidChangeProof, err)
+		}
+		if err := db.CommitChangeProof(ctx, &proof); err != nil {
+			return err
+		}
+
+		if len(proof.KeyChanges) > 0 {
+			largestKey := proof.KeyChanges[len(proof.KeyChanges)-1].Key
+			start = maybe.Some(append(slices.Clip(largestKey), 0))
+		}
+	}
+
+	// Each change proof only proves the key changes it contains, so the root
+	// must be checked to ensure that no change proofs were omitted.
+	root, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if root != endRoot {
+		return fmt.Errorf("%w: got %s, expected %s", errChangeProofsRootMismatch, root, endRoot)
+	}
+	return nil
+}
+
+// writeProofFile atomically creates the file at [path] with the contents
+// written by [write].
+func writeProofFile(path string, write func(w io.Writer) error) error {
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	if err := write(w); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
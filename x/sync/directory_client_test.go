@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+)
+
+func TestDirectoryClientSync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+	sourceDB, err := generateTrie(t, r, 3*maxKeyValuesLimit)
+	require.NoError(err)
+	snapshotRoot, err := sourceDB.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	dir := t.TempDir()
+	require.NoError(WriteSnapshotFile(ctx, sourceDB, merkledb.BranchFactor16, snapshotRoot, maxKeyValuesLimit, dir))
+
+	// Write two consecutive change proofs files.
+	midRoot := modifyTrie(require, r, sourceDB, defaultRequestKeyLimit/4)
+	require.NoError(WriteChangeProofsFile(ctx, sourceDB, snapshotRoot, midRoot, 10, dir))
+	syncRoot := modifyTrie(require, r, sourceDB, defaultRequestKeyLimit/4)
+	require.NoError(WriteChangeProofsFile(ctx, sourceDB, midRoot, syncRoot, 10, dir))
+
+	proofDB, err := merkledb.New(ctx, memdb.New(), newDefaultDBConfig())
+	require.NoError(err)
+	client, err := NewDirectoryClient(ctx, &DirectoryClientConfig{
+		Dir:          dir,
+		DB:           proofDB,
+		BranchFactor: merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+
+	db, err := merkledb.New(ctx, memdb.New(), newDefaultDBConfig())
+	require.NoError(err)
+	syncToRoot(require, db, client, syncRoot)
+}
+
+func TestNewDirectoryClientErrors(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+	sourceDB, err := generateTrie(t, r, defaultRequestKeyLimit)
+	require.NoError(t, err)
+	snapshotRoot, err := sourceDB.GetMerkleRoot(ctx)
+	require.NoError(t, err)
+	syncRoot := modifyTrie(require.New(t), r, sourceDB, 10)
+
+	tests := []struct {
+		name        string
+		writeDir    func(require *require.Assertions, dir string)
+		expectedErr error
+	}{
+		{
+			name:        "no snapshot",
+			writeDir:    func(*require.Assertions, string) {},
+			expectedErr: errNoSnapshot,
+		},
+		{
+			name: "multiple snapshots",
+			writeDir: func(require *require.Assertions, dir string) {
+				require.NoError(WriteSnapshotFile(ctx, sourceDB, merkledb.BranchFactor16, snapshotRoot, 10, dir))
+				require.NoError(WriteSnapshotFile(ctx, sourceDB, merkledb.BranchFactor16, syncRoot, 10, dir))
+			},
+			expectedErr: errMultipleSnapshots,
+		},
+		{
+			name: "invalid change proofs file name",
+			writeDir: func(require *require.Assertions, dir string) {
+				require.NoError(WriteSnapshotFile(ctx, sourceDB, merkledb.BranchFactor16, snapshotRoot, 10, dir))
+				require.NoError(os.WriteFile(filepath.Join(dir, "invalid"+changeProofsFileExtension), nil, 0o600))
+			},
+			expectedErr: errInvalidProofFileName,
+		},
+		{
+			name: "unused change proofs",
+			writeDir: func(require *require.Assertions, dir string) {
+				require.NoError(WriteSnapshotFile(ctx, sourceDB, merkledb.BranchFactor16, syncRoot, 10, dir))
+				require.NoError(WriteChangeProofsFile(ctx, sourceDB, snapshotRoot, syncRoot, 10, dir))
+			},
+			expectedErr: errUnusedChangeProofs,
+		},
+		{
+			name: "truncated change proofs",
+			writeDir: func(require *require.Assertions, dir string) {
+				require.NoError(WriteSnapshotFile(ctx, sourceDB, merkledb.BranchFactor16, snapshotRoot, 10, dir))
+				require.NoError(WriteChangeProofsFile(ctx, sourceDB, snapshotRoot, syncRoot, 5, dir))
+
+				// Drop every change proof after the first one.
+				path := filepath.Join(dir, snapshotRoot.String()+"-"+syncRoot.String()+changeProofsFileExtension)
+				changeProofs, err := os.ReadFile(path)
+				require.NoError(err)
+				proofLen, n := binary.Uvarint(changeProofs)
+				require.NoError(os.WriteFile(path, changeProofs[:n+int(proofLen)], 0o600))
+			},
+			expectedErr: errChangeProofsRootMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			dir := t.TempDir()
+			test.writeDir(require, dir)
+
+			proofDB, err := merkledb.New(ctx, memdb.New(), newDefaultDBConfig())
+			require.NoError(err)
+			_, err = NewDirectoryClient(ctx, &DirectoryClientConfig{
+				Dir:          dir,
+				DB:           proofDB,
+				BranchFactor: merkledb.BranchFactor16,
+			})
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+)
+
+var (
+	_ Client = (*localClient)(nil)
+
+	errNoProofSource = errors.New("no proof source provided")
+)
+
+// localClient fulfills state sync requests from a local database rather than
+// from the network. The proofs it returns are verified exactly as the proofs
+// returned by [client] are.
+type localClient struct {
+	db        DB
+	tokenSize int
+}
+
+type LocalClientConfig struct {
+	// DB is the database that proofs are generated from.
+	DB           DB
+	BranchFactor merkledb.BranchFactor
+}
+
+// NewLocalClient returns a Client that generates the requested proofs from
+// [config.DB].
+func NewLocalClient(config *LocalClientConfig) (Client, error) {
+	if config.DB == nil {
+		return nil, errNoProofSource
+	}
+	if err := config.BranchFactor.Valid(); err != nil {
+		return nil, err
+	}
+	return &localClient{
+		db:        config.DB,
+		tokenSize: merkledb.BranchFactorToTokenSize[config.BranchFactor],
+	}, nil
+}
+
+func (c *localClient) GetRangeProof(
+	ctx context.Context,
+	req *pb.SyncGetRangeProofRequest,
+) (*merkledb.RangeProof, error) {
+	if err := validateRangeProofRequest(req); err != nil {
+		return nil, err
+	}
+
+	root, err := ids.ToID(req.RootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		start = maybeBytesToMaybe(req.StartKey)
+		end   = maybeBytesToMaybe(req.EndKey)
+	)
+	rangeProof, err := c.db.GetRangeProofAtRoot(ctx, root, start, end, int(req.KeyLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get range proof at root %s: %w", root, err)
+	}
+
+	if err := verifyRangeProof(
+		ctx,
+		rangeProof,
+		int(req.KeyLimit),
+		start,
+		end,
+		req.RootHash,
+		c.tokenSize,
+	); err != nil {
+		return nil, err
+	}
+	return rangeProof, nil
+}
+
+func (c *localClient) GetChangeProof(
+	ctx context.Context,
+	req *pb.SyncGetChangeProofRequest,
+	verificationDB DB,
+) (*merkledb.ChangeOrRangeProof, error) {
+	if err := validateChangeProofRequest(req); err != nil {
+		return nil, err
+	}
+
+	startRoot, err := ids.ToID(req.StartRootHash)
+	if err != nil {
+		return nil, err
+	}
+	endRoot, err := ids.ToID(req.EndRootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		start = maybeBytesToMaybe(req.StartKey)
+		end   = maybeBytesToMaybe(req.EndKey)
+	)
+	changeProof, err := c.db.GetChangeProof(ctx, startRoot, endRoot, start, end, int(req.KeyLimit))
+	switch {
+	case err == nil:
+		if err := verifyChangeProof(
+			ctx,
+			verificationDB,
+			changeProof,
+			int(req.KeyLimit),
+			start,
+			end,
+			req.EndRootHash,
+		); err != nil {
+			return nil, err
+		}
+		return &merkledb.ChangeOrRangeProof{
+			ChangeProof: changeProof,
+		}, nil
+	case errors.Is(err, merkledb.ErrNoEndRoot) || !errors.Is(err, merkledb.ErrInsufficientHistory):
+		return nil, fmt.Errorf("failed to get change proof from %s to %s: %w", startRoot, endRoot, err)
+	}
+
+	// [c.db] doesn't have sufficient history to generate a change proof, so
+	// a range proof for the end root is returned instead, just as a
+	// [NetworkServer] would.
+	rangeProof, err := c.GetRangeProof(ctx, &pb.SyncGetRangeProofRequest{
+		RootHash:   req.EndRootHash,
+		StartKey:   req.StartKey,
+		EndKey:     req.EndKey,
+		KeyLimit:   req.KeyLimit,
+		BytesLimit: req.BytesLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &merkledb.ChangeOrRangeProof{
+		RangeProof: rangeProof,
+	}, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/x/merkledb"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+)
+
+// syncToRoot syncs [db] to [syncRoot] using proofs from [client].
+func syncToRoot(require *require.Assertions, db merkledb.MerkleDB, client Client, syncRoot ids.ID) {
+	syncer, err := NewManager(ManagerConfig{
+		DB:                    db,
+		Client:                client,
+		TargetRoot:            syncRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		BranchFactor:          merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+	require.NoError(syncer.Start(context.Background()))
+	require.NoError(syncer.Wait(context.Background()))
+
+	newRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(syncRoot, newRoot)
+}
+
+// modifyTrie writes [count] random key/values to [db] and returns the new root.
+func modifyTrie(require *require.Assertions, r *rand.Rand, db merkledb.MerkleDB, count int) ids.ID {
+	batch := db.NewBatch()
+	for i := 0; i < count; i++ {
+		key := make([]byte, r.Intn(50)+1)
+		_, err := r.Read(key)
+		require.NoError(err)
+		value := make([]byte, r.Intn(50)+1)
+		_, err = r.Read(value)
+		require.NoError(err)
+		require.NoError(batch.Put(key, value))
+	}
+	require.NoError(batch.Write())
+
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	return root
+}
+
+func TestLocalClientSync(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+	dbToSync, err := generateTrie(t, r, 3*maxKeyValuesLimit)
+	require.NoError(err)
+	syncRoot, err := dbToSync.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	client, err := NewLocalClient(&LocalClientConfig{
+		DB:           dbToSync,
+		BranchFactor: merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+
+	db, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		newDefaultDBConfig(),
+	)
+	require.NoError(err)
+
+	// Sync from an empty database using range proofs.
+	syncToRoot(require, db, client, syncRoot)
+
+	// Sync the changes using change proofs.
+	syncRoot = modifyTrie(require, r, dbToSync, defaultRequestKeyLimit/2)
+	syncToRoot(require, db, client, syncRoot)
+}
+
+func TestLocalClientInsufficientHistory(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+	dbToSync, err := generateTrie(t, r, defaultRequestKeyLimit)
+	require.NoError(err)
+
+	client, err := NewLocalClient(&LocalClientConfig{
+		DB:           dbToSync,
+		BranchFactor: merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+
+	db, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		newDefaultDBConfig(),
+	)
+	require.NoError(err)
+	require.NoError(db.Put([]byte{0}, []byte{0}))
+	startRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	// [dbToSync] never had [startRoot], so a range proof is returned instead
+	// of a change proof.
+	endRoot, err := dbToSync.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	proof, err := client.GetChangeProof(
+		context.Background(),
+		&pb.SyncGetChangeProofRequest{
+			StartRootHash: startRoot[:],
+			EndRootHash:   endRoot[:],
+			EndKey:        &pb.MaybeBytes{IsNothing: true},
+			KeyLimit:      defaultRequestKeyLimit,
+			BytesLimit:    defaultRequestByteSizeLimit,
+		},
+		db,
+	)
+	require.NoError(err)
+	require.Nil(proof.ChangeProof)
+	require.NotNil(proof.RangeProof)
+}